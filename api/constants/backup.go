@@ -47,8 +47,20 @@ const (
 const (
 	BackupDestTypeOSS types.BackupDestType = "OSS"
 	BackupDestTypeNFS types.BackupDestType = "NFS"
+	BackupDestTypeCOS types.BackupDestType = "COS"
+	BackupDestTypeS3  types.BackupDestType = "S3"
 )
 
+const (
+	S3AddressingStyleVirtual = "virtual"
+	S3AddressingStylePath    = "path"
+)
+
+// IsObjectStorageDest returns true if the destination type is one of OSS, COS and S3
+func IsObjectStorageDest(destType types.BackupDestType) bool {
+	return destType == BackupDestTypeOSS || destType == BackupDestTypeCOS || destType == BackupDestTypeS3
+}
+
 const (
 	LogArchiveDestStateEnable types.LogArchiveDestState = "ENABLE"
 	LogArchiveDestStateDefer  types.LogArchiveDestState = "DEFER"
@@ -58,3 +70,9 @@ const (
 	ArchiveBindingOptional  types.ArchiveBinding = "Optional"
 	ArchiveBindingMandatory types.ArchiveBinding = "Mandatory"
 )
+
+const (
+	BackupVerificationPhaseRestoring types.BackupVerificationPhase = "RESTORING"
+	BackupVerificationPhasePassed    types.BackupVerificationPhase = "PASSED"
+	BackupVerificationPhaseFailed    types.BackupVerificationPhase = "FAILED"
+)
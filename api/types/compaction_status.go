@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MajorCompactionStatus is the major compaction (merge) status of a tenant
+type MajorCompactionStatus struct {
+	TenantID           int64                       `json:"tenantID"`
+	FrozenScn          int64                       `json:"frozenScn"`
+	GlobalBroadcastScn int64                       `json:"globalBroadcastScn"`
+	LastScn            int64                       `json:"lastScn"`
+	LastFinishTime     string                      `json:"lastFinishTime,omitempty"`
+	Status             string                      `json:"status"`
+	IsError            bool                        `json:"isError"`
+	IsSuspended        bool                        `json:"isSuspended"`
+	Info               string                      `json:"info,omitempty"`
+	Zones              []ZoneMajorCompactionStatus `json:"zones,omitempty"`
+	// Time when the status was queried from oceanbase, it's refreshed at most once in a minute
+	RefreshTime *metav1.Time `json:"refreshTime,omitempty"`
+}
+
+// TenantFrozenScn records the frozen scn of a tenant before major freeze is triggered
+type TenantFrozenScn struct {
+	TenantID  int64 `json:"tenantID"`
+	FrozenScn int64 `json:"frozenScn"`
+}
+
+type ZoneMajorCompactionStatus struct {
+	Zone           string `json:"zone"`
+	BroadcastScn   int64  `json:"broadcastScn"`
+	LastScn        int64  `json:"lastScn"`
+	LastFinishTime string `json:"lastFinishTime,omitempty"`
+	Status         string `json:"status"`
+}
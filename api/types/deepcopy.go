@@ -36,6 +36,30 @@ func (in *BackupVolumeSpec) DeepCopy() *BackupVolumeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MajorCompactionStatus) DeepCopyInto(out *MajorCompactionStatus) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneMajorCompactionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshTime != nil {
+		in, out := &in.RefreshTime, &out.RefreshTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorCompactionStatus.
+func (in *MajorCompactionStatus) DeepCopy() *MajorCompactionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MajorCompactionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorTemplate) DeepCopyInto(out *MonitorTemplate) {
 	*out = *in
@@ -56,6 +80,21 @@ func (in *MonitorTemplate) DeepCopy() *MonitorTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailurePolicy) DeepCopyInto(out *NodeFailurePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailurePolicy.
+func (in *NodeFailurePolicy) DeepCopy() *NodeFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OBServerReplicaStatus) DeepCopyInto(out *OBServerReplicaStatus) {
 	*out = *in
@@ -123,6 +162,11 @@ func (in *OBZoneTopology) DeepCopyInto(out *OBZoneTopology) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBZoneTopology.
@@ -247,6 +291,46 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestartStatus) DeepCopyInto(out *RollingRestartStatus) {
+	*out = *in
+	if in.RestartedZones != nil {
+		in, out := &in.RestartedZones, &out.RestartedZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestartStatus.
+func (in *RollingRestartStatus) DeepCopy() *RollingRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleInStatus) DeepCopyInto(out *ScaleInStatus) {
+	*out = *in
+	if in.OBServers != nil {
+		in, out := &in.OBServers, &out.OBServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInStatus.
+func (in *ScaleInStatus) DeepCopy() *ScaleInStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleInStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
@@ -263,6 +347,36 @@ func (in *StorageSpec) DeepCopy() *StorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantFrozenScn) DeepCopyInto(out *TenantFrozenScn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantFrozenScn.
+func (in *TenantFrozenScn) DeepCopy() *TenantFrozenScn {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantFrozenScn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneMajorCompactionStatus) DeepCopyInto(out *ZoneMajorCompactionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneMajorCompactionStatus.
+func (in *ZoneMajorCompactionStatus) DeepCopy() *ZoneMajorCompactionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneMajorCompactionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OBServerTemplate) DeepCopyInto(out *OBServerTemplate) {
 	*out = *in
@@ -274,6 +388,11 @@ func (in *OBServerTemplate) DeepCopyInto(out *OBServerTemplate) {
 		in, out := &in.Storage, &out.Storage
 		*out = (*in).DeepCopy()
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBServerTemplate.
@@ -0,0 +1,23 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package types
+
+// NodeFailurePolicy describes how observers are handled when the nodes they run on fail
+type NodeFailurePolicy struct {
+	// Relocate observers on failed nodes onto healthy nodes, the observers are deleted from cluster and their pvcs are dropped.
+	// Only observers with all pvcs bound to local volumes are relocated
+	Relocate bool `json:"relocate,omitempty"`
+	// Duration of node keeping NotReady before relocating observers on it, e.g. 10m
+	//+kubebuilder:default="10m"
+	NotReadyTimeout string `json:"notReadyTimeout,omitempty"`
+}
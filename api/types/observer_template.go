@@ -12,8 +12,16 @@ See the Mulan PSL v2 for more details.
 
 package types
 
+import corev1 "k8s.io/api/core/v1"
+
 type OBServerTemplate struct {
 	Image    string                `json:"image"`
 	Resource *ResourceSpec         `json:"resource"`
 	Storage  *OceanbaseStorageSpec `json:"storage"`
+	// Overrides of observer pods, which are merged into the pods generated by operator in the way of strategic
+	// merge patch. Volumes mounted by operator and command of observer container could not be overridden.
+	// It is only applied when pods are created, so it could not be changed after creation.
+	//+kubebuilder:pruning:PreserveUnknownFields
+	//+kubebuilder:validation:Schemaless
+	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 }
@@ -20,4 +20,9 @@ type OBZoneTopology struct {
 	Affinity     *corev1.Affinity    `json:"affinity,omitempty"`
 	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
 	Replica      int                 `json:"replica"`
+	// Overrides of observer pods in the zone, which are merged after podTemplate of observer template.
+	// It could not be changed after the zone is created.
+	//+kubebuilder:pruning:PreserveUnknownFields
+	//+kubebuilder:validation:Schemaless
+	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 }
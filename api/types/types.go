@@ -18,11 +18,15 @@ type BackupPolicyStatusType string
 type BackupDestType string
 type LogArchiveDestState string
 type ArchiveBinding string
+type BackupVerificationPhase string
 
 type BackupDestination struct {
-	Path            string         `json:"path"`
-	Type            BackupDestType `json:"type,omitempty"`
-	OSSAccessSecret string         `json:"ossAccessSecret,omitempty"`
+	Path string         `json:"path"`
+	Type BackupDestType `json:"type,omitempty"`
+	// Secret of access credentials of object storage. Keys accessId and accessKey are required for all types,
+	// appId is required for COS, region is required for S3, and addressingStyle (virtual or path) is optional for S3.
+	// Endpoint is optional, it is appended to path as host if path does not contain one.
+	OSSAccessSecret string `json:"ossAccessSecret,omitempty"`
 }
 
 type RestoreJobStatus string
@@ -30,3 +34,6 @@ type RestoreJobStatus string
 type TenantRole string
 type TenantOperationStatus string
 type TenantOperationType string
+
+type ClusterOperationStatus string
+type ClusterOperationType string
@@ -41,17 +41,28 @@ type OBClusterSpec struct {
 	UserSecrets      *apitypes.OBUserSecrets    `json:"userSecrets"`
 	//+kubebuilder:default=default
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Policy of handling observers on failed nodes, observers are only relocated when it's enabled explicitly
+	NodeFailurePolicy *apitypes.NodeFailurePolicy `json:"nodeFailurePolicy,omitempty"`
 }
 
 // OBClusterStatus defines the observed state of OBCluster
 type OBClusterStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	Image            string                         `json:"image"`
-	OperationContext *tasktypes.OperationContext    `json:"operationContext,omitempty"`
-	Status           string                         `json:"status"`
-	OBZoneStatus     []apitypes.OBZoneReplicaStatus `json:"obzones"`
-	Parameters       []apitypes.Parameter           `json:"parameters"`
+	Image            string                          `json:"image"`
+	OperationContext *tasktypes.OperationContext     `json:"operationContext,omitempty"`
+	Status           string                          `json:"status"`
+	OBZoneStatus     []apitypes.OBZoneReplicaStatus  `json:"obzones"`
+	Parameters       []apitypes.Parameter            `json:"parameters"`
+	RollingRestart   *apitypes.RollingRestartStatus  `json:"rollingRestart,omitempty"`
+	MajorCompaction  *apitypes.MajorCompactionStatus `json:"majorCompaction,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Flows that would run for the spec proposed in annotation oceanbase.oceanbase.com/plan-spec
+	Plan *tasktypes.ChangePlan `json:"plan,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -20,9 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,14 +34,25 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	apitypes "github.com/oceanbase/ob-operator/api/types"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	secretconst "github.com/oceanbase/ob-operator/internal/const/secret"
+	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
+	k8sresource "github.com/oceanbase/ob-operator/pkg/k8s/resource"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/connector"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
+	obutil "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
 )
 
+// webhookConnectTimeout limits time spent on connecting to obcluster in webhook
+const webhookConnectTimeout = 5 * time.Second
+
 // log is for logging in this package.
 var obclusterlog = logf.Log.WithName("obcluster-resource")
 
@@ -144,12 +158,21 @@ func (r *OBCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 	mode, exist := r.GetAnnotations()[oceanbaseconst.AnnotationsMode]
 	if existOld && exist && oldMode != mode {
 		return nil, errors.New("mode cannot be changed")
-	} else if oldMode != oceanbaseconst.ModeStandalone && (oldCluster.Spec.OBServerTemplate.Resource.Cpu != r.Spec.OBServerTemplate.Resource.Cpu || oldCluster.Spec.OBServerTemplate.Resource.Memory != r.Spec.OBServerTemplate.Resource.Memory) {
-		return nil, errors.New("forbid to modify cpu or memory quota of non-standalone cluster")
+	}
+	if oldCluster.Spec.OBServerTemplate.Resource.Cpu.Cmp(r.Spec.OBServerTemplate.Resource.Cpu) != 0 || oldCluster.Spec.OBServerTemplate.Resource.Memory.Cmp(r.Spec.OBServerTemplate.Resource.Memory) != 0 {
+		if err := r.validateResourceChange(oldMode); err != nil {
+			return nil, err
+		}
 	}
 	if r.Spec.BackupVolume == nil && oldCluster.Spec.BackupVolume != nil {
 		return nil, errors.New("forbid to remove backup volume")
 	}
+	restartAt := r.GetAnnotations()[oceanbaseconst.AnnotationsRestartAt]
+	if restartAt != "" && restartAt != oldCluster.GetAnnotations()[oceanbaseconst.AnnotationsRestartAt] {
+		if err := r.validateRollingRestart(); err != nil {
+			return nil, err
+		}
+	}
 	var err error
 	if r.Spec.BackupVolume != nil && oldCluster.Spec.BackupVolume == nil {
 		if mode != oceanbaseconst.ModeStandalone && mode != oceanbaseconst.ModeService {
@@ -196,6 +219,32 @@ func (r *OBCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 		return nil, err
 	}
 
+	// Pod template overrides are only applied when pods are created
+	if !equality.Semantic.DeepEqual(r.Spec.OBServerTemplate.PodTemplate, oldCluster.Spec.OBServerTemplate.PodTemplate) {
+		err = errors.Join(err, field.Forbidden(field.NewPath("spec").Child("observer").Child("podTemplate"), "podTemplate can not be changed after creation"))
+	}
+	for i, zone := range r.Spec.Topology {
+		for _, oldZone := range oldCluster.Spec.Topology {
+			if zone.Zone == oldZone.Zone && !equality.Semantic.DeepEqual(zone.PodTemplate, oldZone.PodTemplate) {
+				err = errors.Join(err, field.Forbidden(field.NewPath("spec").Child("topology").Index(i).Child("podTemplate"), "podTemplate of existing zone can not be changed"))
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range r.Spec.Topology {
+		for _, oldZone := range oldCluster.Spec.Topology {
+			if zone.Zone == oldZone.Zone && zone.Replica < oldZone.Replica {
+				err = errors.Join(err, r.validateZoneScaleIn(zone))
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, r.validateMutation()
 }
 
@@ -327,6 +376,23 @@ func (r *OBCluster) validateMutation() error {
 		}
 	}
 
+	allErrs = append(allErrs, r.validatePodTemplate(r.Spec.OBServerTemplate.PodTemplate, field.NewPath("spec").Child("observer").Child("podTemplate"))...)
+	for i, zone := range r.Spec.Topology {
+		allErrs = append(allErrs, r.validatePodTemplate(zone.PodTemplate, field.NewPath("spec").Child("topology").Index(i).Child("podTemplate"))...)
+	}
+
+	if policy := r.Spec.NodeFailurePolicy; policy != nil {
+		policyPath := field.NewPath("spec").Child("nodeFailurePolicy")
+		if policy.NotReadyTimeout != "" {
+			if _, err := time.ParseDuration(policy.NotReadyTimeout); err != nil {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("notReadyTimeout"), policy.NotReadyTimeout, "invalid duration, e.g. 10m"))
+			}
+		}
+		if policy.Relocate && modeExist && mode == oceanbaseconst.ModeStandalone {
+			allErrs = append(allErrs, field.Forbidden(policyPath.Child("relocate"), "relocating observers is not supported in standalone mode"))
+		}
+	}
+
 	if r.Spec.ServiceAccount != "" {
 		sa := v1.ServiceAccount{}
 		err := clt.Get(context.Background(), types.NamespacedName{
@@ -348,6 +414,225 @@ func (r *OBCluster) validateMutation() error {
 	return apierrors.NewInvalid(GroupVersion.WithKind("OBCluster").GroupKind(), r.Name, allErrs)
 }
 
+// validateResourceChange checks that observers could be resized, either in place or by recreating pods with the same ip
+func (r *OBCluster) validateResourceChange(mode string) error {
+	if err := r.validateMemoryLimit(); err != nil {
+		return err
+	}
+	if mode == oceanbaseconst.ModeStandalone {
+		return nil
+	}
+	observerList := &OBServerList{}
+	err := clt.List(context.TODO(), observerList, client.InNamespace(r.Namespace), client.MatchingLabels{
+		oceanbaseconst.LabelRefOBCluster: r.Name,
+	})
+	if err != nil {
+		return err
+	}
+	for _, observer := range observerList.Items {
+		if observer.SupportStaticIP() {
+			continue
+		}
+		if !r.supportInPlaceResize(&observer) {
+			return errors.New("forbid to modify cpu or memory quota of cluster whose observers could neither be resized in place nor keep ip address")
+		}
+	}
+	return nil
+}
+
+// validateRollingRestart checks that all observers keep their ip addresses after their pods are recreated
+func (r *OBCluster) validateRollingRestart() error {
+	observerList := &OBServerList{}
+	err := clt.List(context.TODO(), observerList, client.InNamespace(r.Namespace), client.MatchingLabels{
+		oceanbaseconst.LabelRefOBCluster: r.Name,
+	})
+	if err != nil {
+		return err
+	}
+	for _, observer := range observerList.Items {
+		if !observer.SupportStaticIP() {
+			return field.Invalid(field.NewPath("metadata").Child("annotations").Child(oceanbaseconst.AnnotationsRestartAt), r.GetAnnotations()[oceanbaseconst.AnnotationsRestartAt], "forbid to restart observers which can not keep ip address, observer "+observer.Name+" is neither in service mode nor using calico")
+		}
+	}
+	return nil
+}
+
+// validateMemoryLimit forbids memory_limit in parameters exceeding memory of observer
+func (r *OBCluster) validateMemoryLimit() error {
+	for _, parameter := range r.Spec.Parameters {
+		if parameter.Name != "memory_limit" {
+			continue
+		}
+		memoryLimit, err := obutil.ParseCapacity(parameter.Value)
+		if err != nil {
+			return fmt.Errorf("invalid memory_limit %s: %w", parameter.Value, err)
+		}
+		if memoryLimit > r.Spec.OBServerTemplate.Resource.Memory.Value() {
+			return fmt.Errorf("memory_limit %s exceeds memory %s of observer, decrease it together with memory", parameter.Value, r.Spec.OBServerTemplate.Resource.Memory.String())
+		}
+	}
+	return nil
+}
+
+// supportInPlaceResize tries resizing the pod of observer in dry run mode
+func (r *OBCluster) supportInPlaceResize(observer *OBServer) bool {
+	pod := &v1.Pod{}
+	err := clt.Get(context.TODO(), types.NamespacedName{Namespace: observer.Namespace, Name: observer.Name}, pod)
+	if err != nil {
+		return false
+	}
+	resources := v1.ResourceList{v1.ResourceMemory: r.Spec.OBServerTemplate.Resource.Memory}
+	if !r.Spec.OBServerTemplate.Resource.Cpu.IsZero() {
+		resources[v1.ResourceCPU] = r.Spec.OBServerTemplate.Resource.Cpu
+	}
+	err = k8sresource.ResizePodInPlace(context.TODO(), clt, pod, oceanbaseconst.ContainerName, v1.ResourceRequirements{
+		Limits:   resources,
+		Requests: resources,
+	}, true)
+	return err == nil
+}
+
+// validateZoneScaleIn rejects decreasing replica of zone if units on observers to delete could not be migrated to the remaining ones.
+// The check is only skipped when the cluster is not running, the request is rejected if the cluster could not be connected
+// or units could not be queried. Tasks of obzone will check it again before deleting observers.
+func (r *OBCluster) validateZoneScaleIn(zone apitypes.OBZoneTopology) error {
+	if r.Status.Status != clusterstatus.Running {
+		return nil
+	}
+	fldPath := field.NewPath("spec").Child("topology")
+	observerList := &OBServerList{}
+	err := clt.List(context.TODO(), observerList, client.InNamespace(r.Namespace), client.MatchingLabels{
+		oceanbaseconst.LabelRefOBZone: fmt.Sprintf("%s-%d-%s", r.Spec.ClusterName, r.Spec.ClusterId, zone.Zone),
+	})
+	if err != nil {
+		return field.InternalError(fldPath, fmt.Errorf("list observers of zone %s: %w", zone.Zone, err))
+	}
+	if len(observerList.Items) == 0 {
+		return nil
+	}
+	observersToDelete := SelectOBServersToDelete(observerList.Items, zone.Replica)
+	deleting := make(map[string]struct{}, len(observersToDelete))
+	for _, observer := range observersToDelete {
+		deleting[observer.Name] = struct{}{}
+	}
+	remainingServers := make([]string, 0, len(observerList.Items))
+	for _, observer := range observerList.Items {
+		if _, ok := deleting[observer.Name]; ok || observer.Status.Status == serverstatus.Deleting {
+			continue
+		}
+		remainingServers = append(remainingServers, observer.Status.GetConnectAddr())
+	}
+	manager, err := r.getOperationManager(observerList.Items)
+	if err != nil {
+		return field.InternalError(fldPath, fmt.Errorf("check units of zone %s for scale in: %w", zone.Zone, err))
+	}
+	resourceTotal, err := manager.GetResourceTotal(zone.Zone)
+	if err != nil {
+		return field.InternalError(fldPath, fmt.Errorf("get resource of zone %s: %w", zone.Zone, err))
+	}
+	units, err := manager.ListUnitsWithZone(zone.Zone)
+	if err != nil {
+		return field.InternalError(fldPath, fmt.Errorf("list units of zone %s: %w", zone.Zone, err))
+	}
+	if err := obutil.CheckZoneScaleIn(units, resourceTotal, remainingServers); err != nil {
+		return field.Invalid(fldPath, zone.Replica, fmt.Sprintf("units of zone %s could not be migrated to remaining observers: %s", zone.Zone, err.Error()))
+	}
+	return nil
+}
+
+// getOperationManager connects to the sys tenant of obcluster with operator user through all the observers at the same time,
+// the first connected one is used. It gives up after webhookConnectTimeout to respond within the timeout of admission webhook.
+func (r *OBCluster) getOperationManager(observers []OBServer) (*operation.OceanbaseOperationManager, error) {
+	secret := &v1.Secret{}
+	err := clt.Get(context.TODO(), types.NamespacedName{Namespace: r.Namespace, Name: r.Spec.UserSecrets.Operator}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("get secret of operator user: %w", err)
+	}
+	password := string(secret.Data[secretconst.PasswordKeyName])
+	// buffered so that connecting goroutines never block after timeout
+	connected := make(chan *operation.OceanbaseOperationManager, len(observers))
+	failed := make(chan error, len(observers))
+	for _, observer := range observers {
+		go func(addr string) {
+			s := connector.NewOceanBaseDataSource(addr, oceanbaseconst.SqlPort, oceanbaseconst.OperatorUser, oceanbaseconst.SysTenant, password, oceanbaseconst.DefaultDatabase)
+			manager, err := operation.GetOceanbaseOperationManager(s)
+			if err != nil {
+				failed <- err
+				return
+			}
+			connected <- manager
+		}(observer.Status.GetConnectAddr())
+	}
+	timer := time.NewTimer(webhookConnectTimeout)
+	defer timer.Stop()
+	var errs error
+	for range observers {
+		select {
+		case manager := <-connected:
+			manager.Logger = &obclusterlog
+			return manager, nil
+		case err := <-failed:
+			errs = errors.Join(errs, err)
+		case <-timer.C:
+			return nil, errors.New("timeout to connect to obcluster")
+		}
+	}
+	return nil, fmt.Errorf("failed to connect to obcluster: %w", errs)
+}
+
+// validatePodTemplate forbids overriding volumes mounted by operator and command of observer container
+func (r *OBCluster) validatePodTemplate(tmpl *v1.PodTemplateSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if tmpl == nil {
+		return allErrs
+	}
+	for i, volume := range tmpl.Spec.Volumes {
+		if r.isVolumeOwnedByOperator(volume.Name) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("spec").Child("volumes").Index(i), fmt.Sprintf("volume %s is managed by operator", volume.Name)))
+		}
+	}
+	ownedPaths := map[string]struct{}{
+		oceanbaseconst.DataPath:   {},
+		oceanbaseconst.ClogPath:   {},
+		oceanbaseconst.LogPath:    {},
+		oceanbaseconst.BackupPath: {},
+	}
+	for i, container := range tmpl.Spec.Containers {
+		if container.Name != oceanbaseconst.ContainerName {
+			continue
+		}
+		containerPath := path.Child("spec").Child("containers").Index(i)
+		if len(container.Command) > 0 {
+			allErrs = append(allErrs, field.Forbidden(containerPath.Child("command"), "command of observer container is managed by operator"))
+		}
+		if len(container.Args) > 0 {
+			allErrs = append(allErrs, field.Forbidden(containerPath.Child("args"), "args of observer container is managed by operator"))
+		}
+		if len(container.Resources.Limits) > 0 || len(container.Resources.Requests) > 0 {
+			allErrs = append(allErrs, field.Forbidden(containerPath.Child("resources"), "resources of observer container are set by spec.observer.resource"))
+		}
+		for j, mount := range container.VolumeMounts {
+			if _, ok := ownedPaths[mount.MountPath]; ok {
+				allErrs = append(allErrs, field.Forbidden(containerPath.Child("volumeMounts").Index(j), fmt.Sprintf("mount path %s is managed by operator", mount.MountPath)))
+			}
+		}
+	}
+	return allErrs
+}
+
+func (r *OBCluster) isVolumeOwnedByOperator(name string) bool {
+	if r.Spec.BackupVolume != nil && r.Spec.BackupVolume.Volume != nil && r.Spec.BackupVolume.Volume.Name == name {
+		return true
+	}
+	for _, suffix := range []string{oceanbaseconst.DataVolumeSuffix, oceanbaseconst.ClogVolumeSuffix, oceanbaseconst.LogVolumeSuffix} {
+		if strings.HasSuffix(name, "-"+suffix) {
+			return true
+		}
+	}
+	// Volume of single pvc is named after observer
+	return strings.HasPrefix(name, fmt.Sprintf("%s-%d-", r.Spec.ClusterName, r.Spec.ClusterId))
+}
+
 func (r *OBCluster) checkSecretExistence(ns, secretName, fieldName string) *field.Error {
 	if secretName == "" {
 		return field.Invalid(field.NewPath("spec").Child("userSecrets").Child(fieldName), secretName, fmt.Sprintf("Empty credential %s is not permitted", fieldName))
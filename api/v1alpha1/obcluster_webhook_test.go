@@ -119,15 +119,24 @@ var _ = Describe("Test OBCluster Webhook", Label("webhook"), func() {
 		}, 300, 1).Should(BeTrue())
 	})
 
-	It("Forbid to modify resources of Non-standalone cluster", func() {
+	It("Validate resources modification of Non-standalone cluster", func() {
 		cluster := newOBCluster("test", 1, 1)
 		Expect(k8sClient.Create(ctx, cluster)).Should(Succeed())
-		By("Modify resources of Non-standalone cluster")
+		By("Modify cpu of Non-standalone cluster without observers to be resized")
 		cluster.Spec.OBServerTemplate.Resource.Cpu = resource.MustParse("3")
+		Expect(k8sClient.Update(ctx, cluster)).Should(Succeed())
+
+		By("Decrease memory below memory_limit")
+		cluster.Spec.OBServerTemplate.Resource.Memory = resource.MustParse("8Gi")
 		Expect(k8sClient.Update(ctx, cluster)).ShouldNot(Succeed())
-		cluster = newOBCluster("test", 1, 1)
-		cluster.Spec.OBServerTemplate.Resource.Memory = resource.MustParse("20Gi")
-		Expect(k8sClient.Update(ctx, cluster)).ShouldNot(Succeed())
+
+		By("Decrease memory together with memory_limit")
+		for i, param := range cluster.Spec.Parameters {
+			if param.Name == "memory_limit" {
+				cluster.Spec.Parameters[i].Value = "7G"
+			}
+		}
+		Expect(k8sClient.Update(ctx, cluster)).Should(Succeed())
 
 		Expect(k8sClient.Delete(ctx, cluster)).Should(Succeed())
 	})
@@ -206,6 +215,78 @@ var _ = Describe("Test OBCluster Webhook", Label("webhook"), func() {
 		Expect(k8sClient.Delete(ctx, cluster)).Should(Succeed())
 	})
 
+	It("Validate pod template overrides", func() {
+		cluster := newOBCluster("test-cluster-pod-template", 1, 1)
+		cluster.Spec.OBServerTemplate.PodTemplate = &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:    oceanbaseconst.ContainerName,
+					Command: []string{"sleep", "infinity"},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).ShouldNot(Succeed())
+
+		cluster.Spec.OBServerTemplate.PodTemplate = nil
+		cluster.Spec.Topology[0].PodTemplate = &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "custom-" + oceanbaseconst.DataVolumeSuffix,
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).ShouldNot(Succeed())
+	})
+
+	It("Forbid changing pod template overrides", func() {
+		cluster := newOBCluster("test-cluster-pod-template-update", 1, 1)
+		Expect(k8sClient.Create(ctx, cluster)).Should(Succeed())
+
+		cluster.Spec.OBServerTemplate.PodTemplate = &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "dba"},
+			},
+		}
+		Expect(k8sClient.Update(ctx, cluster)).ShouldNot(Succeed())
+
+		cluster.Spec.OBServerTemplate.PodTemplate = nil
+		cluster.Spec.Topology[0].PodTemplate = &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "dba"},
+			},
+		}
+		Expect(k8sClient.Update(ctx, cluster)).ShouldNot(Succeed())
+
+		Expect(k8sClient.Delete(ctx, cluster)).Should(Succeed())
+	})
+
+	It("Validate rolling restart of observers without static ip", func() {
+		cluster := newOBCluster("test-restart", 1, 1)
+		Expect(k8sClient.Create(ctx, cluster)).Should(Succeed())
+		observer := &OBServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restart-zone0-server",
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					oceanbaseconst.LabelRefOBCluster: cluster.Name,
+				},
+			},
+			Spec: OBServerSpec{
+				ClusterName:      cluster.Spec.ClusterName,
+				ClusterId:        cluster.Spec.ClusterId,
+				Zone:             "zone0",
+				OBServerTemplate: cluster.Spec.OBServerTemplate,
+			},
+		}
+		Expect(k8sClient.Create(ctx, observer)).Should(Succeed())
+		cluster.SetAnnotations(map[string]string{oceanbaseconst.AnnotationsRestartAt: "2024-01-01T00:00:00Z"})
+		Expect(k8sClient.Update(ctx, cluster)).ShouldNot(Succeed())
+
+		Expect(k8sClient.Delete(ctx, observer)).Should(Succeed())
+		Expect(k8sClient.Update(ctx, cluster)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, cluster)).Should(Succeed())
+	})
+
 	It("Validate memory limit", func() {
 		cluster := newOBCluster("test-memory", 1, 1)
 		cluster.Spec.OBServerTemplate.Resource.Memory = resource.MustParse("16Gi")
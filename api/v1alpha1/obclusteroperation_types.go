@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// OBClusterOperationSpec defines the desired state of OBClusterOperation
+type OBClusterOperationSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	OBCluster      string                         `json:"obcluster"`
+	Type           apitypes.ClusterOperationType  `json:"type"`
+	StopZone       *OBClusterOpZoneSpec           `json:"stopZone,omitempty"`
+	StartZone      *OBClusterOpZoneSpec           `json:"startZone,omitempty"`
+	DeleteOBServer *OBClusterOpDeleteOBServerSpec `json:"deleteOBServer,omitempty"`
+	MajorFreeze    *OBClusterOpMajorFreezeSpec    `json:"majorFreeze,omitempty"`
+}
+
+type OBClusterOpZoneSpec struct {
+	Zone string `json:"zone"`
+}
+
+// OBClusterOpDeleteOBServerSpec removes the observer by decreasing replica of its zone by one,
+// the observer is deleted from oceanbase after its units are migrated to other observers of the zone
+type OBClusterOpDeleteOBServerSpec struct {
+	OBServer string `json:"observer"`
+}
+
+type OBClusterOpMajorFreezeSpec struct {
+	// Tenants to freeze, all tenants are frozen if empty
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+// OBClusterOperationStatus defines the observed state of OBClusterOperation
+type OBClusterOperationStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+	Status           apitypes.ClusterOperationStatus `json:"status"`
+	OperationContext *tasktypes.OperationContext     `json:"operationContext,omitempty"`
+	StartTime        *metav1.Time                    `json:"startTime,omitempty"`
+	FinishTime       *metav1.Time                    `json:"finishTime,omitempty"`
+	Message          string                          `json:"message,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
+	// Frozen scn of tenants before major freeze is triggered, compaction is done once they have advanced
+	FrozenScnsBeforeFreeze []apitypes.TenantFrozenScn `json:"frozenScnsBeforeFreeze,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.obcluster`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="FinishTime",type="date",JSONPath=".status.finishTime",priority=1
+//+kubebuilder:printcolumn:name="Message",type=string,JSONPath=".status.message",priority=1
+
+// OBClusterOperation is the Schema for the obclusteroperations API
+type OBClusterOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OBClusterOperationSpec   `json:"spec,omitempty"`
+	Status OBClusterOperationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OBClusterOperationList contains a list of OBClusterOperation
+type OBClusterOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OBClusterOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OBClusterOperation{}, &OBClusterOperationList{})
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	obutil "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
+)
+
+// log is for logging in this package.
+var obclusteroperationlog = logf.Log.WithName("obclusteroperation-resource")
+var clusterOpClt client.Client
+
+func (r *OBClusterOperation) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	clusterOpClt = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+
+//+kubebuilder:webhook:path=/mutate-oceanbase-oceanbase-com-v1alpha1-obclusteroperation,mutating=true,failurePolicy=fail,sideEffects=None,groups=oceanbase.oceanbase.com,resources=obclusteroperations,verbs=create;update,versions=v1alpha1,name=mobclusteroperation.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OBClusterOperation{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *OBClusterOperation) Default() {
+	r.Spec.Type = apitypes.ClusterOperationType(strings.ToUpper(string(r.Spec.Type)))
+
+	if r.Spec.OBCluster == "" {
+		return
+	}
+	obcluster := &OBCluster{}
+	err := clusterOpClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      r.Spec.OBCluster,
+	}, obcluster)
+	if err != nil {
+		obclusteroperationlog.Error(err, "get obcluster")
+		return
+	}
+	labels := r.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[oceanbaseconst.LabelRefOBCluster] = obcluster.GetName()
+	r.SetLabels(labels)
+	for _, ownerRef := range r.GetOwnerReferences() {
+		if ownerRef.UID == obcluster.GetUID() {
+			return
+		}
+	}
+	r.SetOwnerReferences(append(r.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: obcluster.APIVersion,
+		Kind:       obcluster.Kind,
+		Name:       obcluster.GetName(),
+		UID:        obcluster.GetUID(),
+	}))
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+//+kubebuilder:webhook:path=/validate-oceanbase-oceanbase-com-v1alpha1-obclusteroperation,mutating=false,failurePolicy=fail,sideEffects=None,groups=oceanbase.oceanbase.com,resources=obclusteroperations,verbs=create;update,versions=v1alpha1,name=vobclusteroperation.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OBClusterOperation{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OBClusterOperation) ValidateCreate() (admission.Warnings, error) {
+	return nil, r.validateMutation()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OBClusterOperation) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	_ = old
+	warnings := []string{"Updating operation resource can not trigger any action, please create a new one if you want to do that"}
+	return warnings, r.validateMutation()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OBClusterOperation) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *OBClusterOperation) validateMutation() error {
+	var allErrs field.ErrorList
+
+	if r.Spec.OBCluster == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("obcluster"), "name of obcluster is required"))
+		return allErrs.ToAggregate()
+	}
+	obcluster := &OBCluster{}
+	err := clusterOpClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      r.Spec.OBCluster,
+	}, obcluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("obcluster"), r.Spec.OBCluster, "Given obcluster not found"))
+		} else {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("spec").Child("obcluster"), err))
+		}
+		return allErrs.ToAggregate()
+	}
+
+	switch r.Spec.Type {
+	case constants.ClusterOpRestart:
+	case constants.ClusterOpMajorFreeze:
+		if r.Spec.MajorFreeze != nil {
+			for i, tenantName := range r.Spec.MajorFreeze.Tenants {
+				if !obutil.IsValidIdentifier(tenantName) {
+					allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("majorFreeze").Child("tenants").Index(i), tenantName, "Invalid tenant name, which should start with character or underscore and contain character, digit and underscore only"))
+				}
+			}
+		}
+	case constants.ClusterOpStopZone:
+		if r.Spec.StopZone == nil || r.Spec.StopZone.Zone == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("stopZone").Child("zone"), "name of zone to stop is required"))
+		} else if !r.zoneInTopology(obcluster, r.Spec.StopZone.Zone) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("stopZone").Child("zone"), r.Spec.StopZone.Zone, "Zone not found in topology of obcluster"))
+		}
+	case constants.ClusterOpStartZone:
+		if r.Spec.StartZone == nil || r.Spec.StartZone.Zone == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("startZone").Child("zone"), "name of zone to start is required"))
+		} else if !r.zoneInTopology(obcluster, r.Spec.StartZone.Zone) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("startZone").Child("zone"), r.Spec.StartZone.Zone, "Zone not found in topology of obcluster"))
+		}
+	case constants.ClusterOpDeleteOBServer:
+		if r.Spec.DeleteOBServer == nil || r.Spec.DeleteOBServer.OBServer == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("deleteOBServer").Child("observer"), "name of observer to delete is required"))
+		} else {
+			observer := &OBServer{}
+			err := clusterOpClt.Get(context.Background(), types.NamespacedName{
+				Namespace: r.GetNamespace(),
+				Name:      r.Spec.DeleteOBServer.OBServer,
+			}, observer)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("deleteOBServer").Child("observer"), r.Spec.DeleteOBServer.OBServer, "Failed to get observer of given name"))
+			} else if observer.Labels[oceanbaseconst.LabelRefOBCluster] != r.Spec.OBCluster {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("deleteOBServer").Child("observer"), r.Spec.DeleteOBServer.OBServer, fmt.Sprintf("OBServer does not belong to obcluster %s", r.Spec.OBCluster)))
+			} else if r.zoneReplica(obcluster, observer.Spec.Zone) <= 1 {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("deleteOBServer").Child("observer"), r.Spec.DeleteOBServer.OBServer, fmt.Sprintf("OBServer is the last one of zone %s, delete the zone instead", observer.Spec.Zone)))
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("type"), string(r.Spec.Type)+" type of operation is not supported"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+func (r *OBClusterOperation) zoneInTopology(obcluster *OBCluster, zone string) bool {
+	for _, z := range obcluster.Spec.Topology {
+		if z.Zone == zone {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *OBClusterOperation) zoneReplica(obcluster *OBCluster, zone string) int {
+	for _, z := range obcluster.Spec.Topology {
+		if z.Zone == zone {
+			return z.Replica
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiconsts "github.com/oceanbase/ob-operator/api/constants"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+)
+
+var _ = Describe("Test OBClusterOperation Webhook", Label("webhook"), Serial, func() {
+	clusterName := "test-cluster-for-cluster-operation"
+
+	It("Create cluster", func() {
+		c := newOBCluster(clusterName, 1, 1)
+		Expect(k8sClient.Create(ctx, c)).Should(Succeed())
+	})
+
+	It("Check operation types", func() {
+		op := newClusterOperation(clusterName)
+		op.Spec.Type = "illegal-operation-type"
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+	})
+
+	It("Check obcluster of operation", func() {
+		op := newClusterOperation("cluster-not-exist")
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.OBCluster = ""
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+	})
+
+	It("Check operation restart", func() {
+		op := newClusterOperation(clusterName)
+		op.Spec.Type = apiconsts.ClusterOpRestart
+		Expect(k8sClient.Create(ctx, op)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, op)).Should(Succeed())
+	})
+
+	It("Check operation stop and start zone", func() {
+		op := newClusterOperation(clusterName)
+		op.Spec.Type = apiconsts.ClusterOpStopZone
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.StopZone = &OBClusterOpZoneSpec{Zone: "zone-not-exist"}
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+
+		op.Spec.Type = apiconsts.ClusterOpStartZone
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.StartZone = &OBClusterOpZoneSpec{Zone: "zone-not-exist"}
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.StartZone = &OBClusterOpZoneSpec{Zone: "zone0"}
+		Expect(k8sClient.Create(ctx, op)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, op)).Should(Succeed())
+	})
+
+	It("Check operation major freeze", func() {
+		op := newClusterOperation(clusterName)
+		op.Spec.Type = apiconsts.ClusterOpMajorFreeze
+		op.Spec.MajorFreeze = &OBClusterOpMajorFreezeSpec{Tenants: []string{"t1", "t2; alter system stop zone zone0"}}
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.MajorFreeze.Tenants = []string{"t1", "t2"}
+		Expect(k8sClient.Create(ctx, op)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, op)).Should(Succeed())
+	})
+
+	It("Check operation delete observer", func() {
+		op := newClusterOperation(clusterName)
+		op.Spec.Type = apiconsts.ClusterOpDeleteOBServer
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		op.Spec.DeleteOBServer = &OBClusterOpDeleteOBServerSpec{OBServer: "observer-not-exist"}
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+
+		By("Delete the last observer of zone")
+		c := newOBCluster(clusterName, 1, 1)
+		observer := &OBServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-zone0-server",
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					oceanbaseconst.LabelRefOBCluster: clusterName,
+				},
+			},
+			Spec: OBServerSpec{
+				ClusterName:      c.Spec.ClusterName,
+				Zone:             "zone0",
+				OBServerTemplate: c.Spec.OBServerTemplate,
+			},
+		}
+		Expect(k8sClient.Create(ctx, observer)).Should(Succeed())
+		op.Spec.DeleteOBServer = &OBClusterOpDeleteOBServerSpec{OBServer: observer.Name}
+		Expect(k8sClient.Create(ctx, op)).ShouldNot(Succeed())
+		Expect(k8sClient.Delete(ctx, observer)).Should(Succeed())
+	})
+})
@@ -42,6 +42,11 @@ type OBParameterStatus struct {
 	OperationContext *tasktypes.OperationContext `json:"operationContext,omitempty"`
 	Status           string                      `json:"status"`
 	Parameter        []apitypes.ParameterValue   `json:"parameter"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
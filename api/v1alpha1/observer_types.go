@@ -22,6 +22,7 @@ import (
 
 	apitypes "github.com/oceanbase/ob-operator/api/types"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -44,6 +45,12 @@ type OBServerSpec struct {
 	BackupVolume     *apitypes.BackupVolumeSpec `json:"backupVolume,omitempty"`
 	//+kubebuilder:default=default
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Overrides of observer pod from zone topology, which are merged after podTemplate of observer template
+	//+kubebuilder:pruning:PreserveUnknownFields
+	//+kubebuilder:validation:Schemaless
+	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+	// Stop the observer in cluster and keep it out of service while the pod and pvcs are retained, used for maintenance of the node.
+	Maintenance bool `json:"maintenance,omitempty"`
 }
 
 // OBServerStatus defines the observed state of OBServer
@@ -61,6 +68,11 @@ type OBServerStatus struct {
 	OBStatus         string                      `json:"obStatus,omitempty"`
 	StartServiceTime int64                       `json:"startServiceTime,omitempty"`
 	CNI              string                      `json:"cni,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation of itself, its obzone or its obcluster
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -114,3 +126,44 @@ func (s *OBServer) SupportStaticIP() bool {
 		return modeAnnoExist && (mode == oceanbaseconst.ModeStandalone || mode == oceanbaseconst.ModeService)
 	}
 }
+
+// IsScaleInCandidate tells whether the observer is chosen by user to be removed first when replica of obzone decreases
+func (s *OBServer) IsScaleInCandidate() bool {
+	return s.GetAnnotations()[oceanbaseconst.AnnotationsScaleInCandidate] == "true"
+}
+
+// SelectOBServersToDelete picks observers to delete so that replica observers remain,
+// unrecoverable observers are always deleted, then scale-in candidates, then the last ones in list.
+// Observers being deleted are neither counted nor picked again.
+func SelectOBServersToDelete(observers []OBServer, replica int) []OBServer {
+	toDelete := make([]OBServer, 0)
+	working := make([]OBServer, 0, len(observers))
+	for _, observer := range observers {
+		switch observer.Status.Status {
+		case serverstatus.Deleting:
+		case serverstatus.Unrecoverable:
+			toDelete = append(toDelete, observer)
+		default:
+			working = append(working, observer)
+		}
+	}
+	excess := len(working) - replica
+	if excess <= 0 {
+		return toDelete
+	}
+	picked := make(map[string]bool, excess)
+	for _, observer := range working {
+		if len(picked) < excess && observer.IsScaleInCandidate() {
+			picked[observer.Name] = true
+		}
+	}
+	for i := len(working) - 1; i >= 0 && len(picked) < excess; i-- {
+		picked[working[i].Name] = true
+	}
+	for _, observer := range working {
+		if picked[observer.Name] {
+			toDelete = append(toDelete, observer)
+		}
+	}
+	return toDelete
+}
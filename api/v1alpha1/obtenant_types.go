@@ -59,8 +59,21 @@ type TenantCredentials struct {
 
 // Source for restoring or creating standby
 type TenantSourceSpec struct {
-	Tenant  *string            `json:"tenant,omitempty"`
-	Restore *RestoreSourceSpec `json:"restore,omitempty"`
+	Tenant   *string                   `json:"tenant,omitempty"`
+	Restore  *RestoreSourceSpec        `json:"restore,omitempty"`
+	External *ExternalTenantSourceSpec `json:"external,omitempty"`
+}
+
+// Primary tenant which is not managed by ob-operator in the same namespace,
+// e.g. in another kubernetes cluster or in an unmanaged OceanBase cluster
+type ExternalTenantSourceSpec struct {
+	// Address of one observer where the primary tenant has units
+	Address string `json:"address"`
+	//+kubebuilder:default=2881
+	Port       int    `json:"port,omitempty"`
+	TenantName string `json:"tenantName"`
+	// Secret that stores password of standbyro user of the primary tenant
+	StandbyROSecret string `json:"standbyRoSecret"`
 }
 
 type ResourcePoolSpec struct {
@@ -103,6 +116,15 @@ type OBTenantStatus struct {
 	TenantRole  apitypes.TenantRole `json:"tenantRole,omitempty"`
 	Source      *TenantSourceStatus `json:"source,omitempty"`
 	Credentials TenantCredentials   `json:"credentials,omitempty"`
+
+	MajorCompaction *apitypes.MajorCompactionStatus `json:"majorCompaction,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Flows that would run for the spec proposed in annotation oceanbase.oceanbase.com/plan-spec
+	Plan *tasktypes.ChangePlan `json:"plan,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 type TenantSourceStatus struct {
@@ -130,6 +152,23 @@ func (in *OBTenantStatus) DeepCopyInto(out *OBTenantStatus) {
 		*out = new(TenantSourceStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MajorCompaction != nil {
+		in, out := &in.MajorCompaction, &out.MajorCompaction
+		*out = new(apitypes.MajorCompactionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(tasktypes.ChangePlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *TenantSourceStatus) DeepCopyInto(out *TenantSourceStatus) {
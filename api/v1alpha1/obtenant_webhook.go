@@ -210,8 +210,12 @@ func (r *OBTenant) validateMutation() error {
 	if r.Spec.TenantRole == constants.TenantRoleStandby {
 		if r.Spec.Source == nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source"), r.Spec.Source, "Standby tenant must have non-nil source field"))
-		} else if r.Spec.Source.Restore == nil && r.Spec.Source.Tenant == nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("tenantRole"), r.Spec.TenantRole, "Standby must have a source option, but both restore and tenantRef are nil now"))
+		} else if r.Spec.Source.Restore == nil && r.Spec.Source.Tenant == nil && r.Spec.Source.External == nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("tenantRole"), r.Spec.TenantRole, "Standby must have a source option, but restore, tenantRef and external are all nil now"))
+		} else if r.Spec.Source.Tenant != nil && r.Spec.Source.External != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("external"), r.Spec.Source.External, "Source tenant and external primary can not be specified at the same time"))
+		} else if r.Spec.Source.External != nil {
+			allErrs = append(allErrs, r.validateExternalSource(r.Spec.Source.External)...)
 		} else if r.Spec.Source.Tenant != nil {
 			tenant := &OBTenant{}
 			err = tenantClt.Get(context.TODO(), types.NamespacedName{
@@ -266,9 +270,9 @@ func (r *OBTenant) validateMutation() error {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore"), res, "Restore must have a source option, but both archiveSource, bakDataSource and sourceUri are nil now"))
 		}
 
-		if res.ArchiveSource != nil && res.ArchiveSource.Type == constants.BackupDestTypeOSS {
+		if res.ArchiveSource != nil && constants.IsObjectStorageDest(res.ArchiveSource.Type) {
 			if res.ArchiveSource.OSSAccessSecret == "" {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), res.ArchiveSource.OSSAccessSecret, "Tenant restoring from OSS type backup data must have a OSSAccessSecret"))
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), res.ArchiveSource.OSSAccessSecret, "Tenant restoring from object storage must have a OSSAccessSecret"))
 			} else {
 				secret := &v1.Secret{}
 				err := tenantClt.Get(context.Background(), types.NamespacedName{
@@ -281,19 +285,18 @@ func (r *OBTenant) validateMutation() error {
 					}
 					allErrs = append(allErrs, field.InternalError(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), err))
 				} else {
-					if _, ok := secret.Data["accessId"]; !ok {
-						allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), res.ArchiveSource.OSSAccessSecret, "accessId field not found in given OSSAccessSecret"))
-					}
-					if _, ok := secret.Data["accessKey"]; !ok {
-						allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), res.ArchiveSource.OSSAccessSecret, "accessKey field not found in given OSSAccessSecret"))
+					for _, key := range destSecretRequiredKeys[res.ArchiveSource.Type] {
+						if _, ok := secret.Data[key]; !ok {
+							allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("archiveSource").Child("ossAccessSecret"), res.ArchiveSource.OSSAccessSecret, key+" field not found in given OSSAccessSecret"))
+						}
 					}
 				}
 			}
 		}
 
-		if res.BakDataSource != nil && res.BakDataSource.Type == constants.BackupDestTypeOSS {
+		if res.BakDataSource != nil && constants.IsObjectStorageDest(res.BakDataSource.Type) {
 			if res.BakDataSource.OSSAccessSecret == "" {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), res.BakDataSource.OSSAccessSecret, "Tenant restoring from OSS type backup data must have a OSSAccessSecret"))
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), res.BakDataSource.OSSAccessSecret, "Tenant restoring from object storage must have a OSSAccessSecret"))
 			} else {
 				secret := &v1.Secret{}
 				err := tenantClt.Get(context.Background(), types.NamespacedName{
@@ -306,11 +309,10 @@ func (r *OBTenant) validateMutation() error {
 					}
 					allErrs = append(allErrs, field.InternalError(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), err))
 				} else {
-					if _, ok := secret.Data["accessId"]; !ok {
-						allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), res.BakDataSource.OSSAccessSecret, "accessId field not found in given OSSAccessSecret"))
-					}
-					if _, ok := secret.Data["accessKey"]; !ok {
-						allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), res.BakDataSource.OSSAccessSecret, "accessKey field not found in given OSSAccessSecret"))
+					for _, key := range destSecretRequiredKeys[res.BakDataSource.Type] {
+						if _, ok := secret.Data[key]; !ok {
+							allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("source").Child("restore").Child("bakDataSource").Child("ossAccessSecret"), res.BakDataSource.OSSAccessSecret, key+" field not found in given OSSAccessSecret"))
+						}
 					}
 				}
 			}
@@ -328,3 +330,33 @@ func (r *OBTenant) ValidateDelete() (admission.Warnings, error) {
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+func (r *OBTenant) validateExternalSource(external *ExternalTenantSourceSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	externalPath := field.NewPath("spec").Child("source").Child("external")
+	if external.Address == "" {
+		allErrs = append(allErrs, field.Required(externalPath.Child("address"), "Address of external primary tenant is required"))
+	}
+	if external.TenantName == "" {
+		allErrs = append(allErrs, field.Required(externalPath.Child("tenantName"), "Name of external primary tenant is required"))
+	}
+	if external.StandbyROSecret == "" {
+		allErrs = append(allErrs, field.Required(externalPath.Child("standbyRoSecret"), "Standby ro secret of external primary tenant is required"))
+		return allErrs
+	}
+	secret := &v1.Secret{}
+	err := tenantClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      external.StandbyROSecret,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.Invalid(externalPath.Child("standbyRoSecret"), external.StandbyROSecret, "Given standbyRoSecret not found"))
+		} else {
+			allErrs = append(allErrs, field.InternalError(externalPath.Child("standbyRoSecret"), err))
+		}
+	} else if _, ok := secret.Data["password"]; !ok {
+		allErrs = append(allErrs, field.Invalid(externalPath.Child("standbyRoSecret"), external.StandbyROSecret, "password field not found in given standbyRoSecret"))
+	}
+	return allErrs
+}
@@ -106,6 +106,23 @@ var _ = Describe("Test OBTenant Webhook", Label("webhook"), Serial, func() {
 		Expect(k8sClient.Create(ctx, t)).ShouldNot(Succeed())
 	})
 
+	It("Check standby with external primary tenant", func() {
+		t := newOBTenant(tenantName, clusterName)
+		t.Spec.TenantRole = "Standby"
+		t.Spec.Source = &TenantSourceSpec{
+			External: &ExternalTenantSourceSpec{},
+		}
+		Expect(k8sClient.Create(ctx, t)).ShouldNot(Succeed())
+		t.Spec.Source.External.Address = "10.0.0.1"
+		t.Spec.Source.External.TenantName = "primary"
+		t.Spec.Source.External.StandbyROSecret = "secret-not-exist"
+		Expect(k8sClient.Create(ctx, t)).ShouldNot(Succeed())
+		primaryTenantName := "tenant-not-exist"
+		t.Spec.Source.External.StandbyROSecret = defaultSecretName
+		t.Spec.Source.Tenant = &primaryTenantName
+		Expect(k8sClient.Create(ctx, t)).ShouldNot(Succeed())
+	})
+
 	It("Check standby with restore until without a limit key", func() {
 		t := newOBTenant(tenantName, clusterName)
 
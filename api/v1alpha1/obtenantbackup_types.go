@@ -30,11 +30,16 @@ type OBTenantBackupSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	Type          apitypes.BackupJobType `json:"type"`           // Type of backup job
-	TenantName    string                 `json:"tenantName"`     // Name of tenant in database
-	TenantSecret  string                 `json:"tenantSecret"`   // Secret that stores root password of tenant
-	ObClusterName string                 `json:"obClusterName"`  // Name of obcluster resource
-	Path          string                 `json:"path,omitempty"` // Path to store backup files
+	Type          apitypes.BackupJobType `json:"type"`                    // Type of backup job
+	TenantName    string                 `json:"tenantName,omitempty"`    // Name of tenant in database
+	TenantSecret  string                 `json:"tenantSecret,omitempty"`  // Secret that stores root password of tenant
+	ObClusterName string                 `json:"obClusterName,omitempty"` // Name of obcluster resource
+	Path          string                 `json:"path,omitempty"`          // Path to store backup files
+
+	// On-demand backup jobs could reference an obtenant resource or a backup policy instead of
+	// specifying tenantName, tenantSecret and obClusterName explicitly
+	TenantCRName     string `json:"tenantCRName,omitempty"`     // Name of obtenant resource
+	BackupPolicyName string `json:"backupPolicyName,omitempty"` // Name of obtenantbackuppolicy resource
 
 	EncryptionSecret string `json:"encryptionSecret,omitempty"` // Secret that stores backup encryption key
 }
@@ -52,6 +57,16 @@ type OBTenantBackupStatus struct {
 	BackupJob        *model.OBBackupJob          `json:"backupJob,omitempty"`
 	ArchiveLogJob    *model.OBArchiveLogJob      `json:"archiveLogJob,omitempty"`
 	DataCleanJob     *model.OBBackupCleanJob     `json:"dataCleanJob,omitempty"`
+
+	// Filled when full or incremental backup job completed
+	BackupSetID          int64  `json:"backupSetId,omitempty"`
+	MinRestoreScn        int64  `json:"minRestoreScn,omitempty"`
+	MinRestoreScnDisplay string `json:"minRestoreScnDisplay,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 // fix: implementation of DeepCopyInto needed by zz_generated.deepcopy.go
@@ -78,6 +93,13 @@ func (in *OBTenantBackupStatus) DeepCopyInto(out *OBTenantBackupStatus) {
 		*out = new(model.OBBackupCleanJob)
 		**out = **in
 	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 //+kubebuilder:object:root=true
@@ -86,6 +108,7 @@ func (in *OBTenantBackupStatus) DeepCopyInto(out *OBTenantBackupStatus) {
 //+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
 //+kubebuilder:printcolumn:name="TenantName",type=string,JSONPath=`.spec.tenantName`
 //+kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.spec.path`,priority=100
+//+kubebuilder:printcolumn:name="BackupSetID",type=integer,JSONPath=`.status.backupSetId`,priority=100
 //+kubebuilder:printcolumn:name="StartedAt",type=string,JSONPath=`.status.startedAt`
 //+kubebuilder:printcolumn:name="EndedAt",type=string,JSONPath=`.status.endedAt`,description="In ArchiveLogJob, EndedAt is CheckpointScnDisplay field, in other jobs, EndedAt is EndTimestamp field"
 
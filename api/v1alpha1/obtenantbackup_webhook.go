@@ -0,0 +1,147 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+)
+
+// log is for logging in this package.
+var obtenantbackuplog = logf.Log.WithName("obtenantbackup-resource")
+var tenantBackupClt client.Client
+
+func (r *OBTenantBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	tenantBackupClt = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-oceanbase-oceanbase-com-v1alpha1-obtenantbackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=oceanbase.oceanbase.com,resources=obtenantbackups,verbs=create;update,versions=v1alpha1,name=mobtenantbackup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OBTenantBackup{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *OBTenantBackup) Default() {
+	r.Spec.Type = apitypes.BackupJobType(strings.ToUpper(string(r.Spec.Type)))
+}
+
+//+kubebuilder:webhook:path=/validate-oceanbase-oceanbase-com-v1alpha1-obtenantbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=oceanbase.oceanbase.com,resources=obtenantbackups,verbs=create;update,versions=v1alpha1,name=vobtenantbackup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OBTenantBackup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OBTenantBackup) ValidateCreate() (admission.Warnings, error) {
+	obtenantbackuplog.Info("validate create", "name", r.Name)
+	return nil, r.validateMutation()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OBTenantBackup) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	oldBackup, ok := old.(*OBTenantBackup)
+	if !ok {
+		return nil, apierrors.NewBadRequest("old object is not an OBTenantBackup")
+	}
+	if !equality.Semantic.DeepEqual(r.Spec, oldBackup.Spec) {
+		return nil, field.Forbidden(field.NewPath("spec"), "spec of backup job is immutable, please create a new one")
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OBTenantBackup) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *OBTenantBackup) validateMutation() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	switch r.Spec.Type {
+	case constants.BackupJobTypeFull, constants.BackupJobTypeIncr:
+	case constants.BackupJobTypeArchive, constants.BackupJobTypeClean:
+		// Archive and clean jobs are maintained by backup policy, they could not be created on demand
+		if _, ok := r.GetLabels()[oceanbaseconst.LabelRefBackupPolicy]; !ok {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("type"), r.Spec.Type, "only FULL and INC backup jobs could be created on demand"))
+		}
+	case "":
+		allErrs = append(allErrs, field.Required(specPath.Child("type"), "type of backup job is required"))
+	default:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("type"), r.Spec.Type, "type of backup job must be one of FULL, INC, ARCHIVE and CLEAN"))
+	}
+
+	explicit := r.Spec.ObClusterName != "" && r.Spec.TenantName != "" && r.Spec.TenantSecret != ""
+	switch {
+	case explicit:
+		if err := r.checkReferenceExists(&OBCluster{}, r.Spec.ObClusterName, specPath.Child("obClusterName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	case r.Spec.TenantCRName != "" && r.Spec.BackupPolicyName != "":
+		allErrs = append(allErrs, field.Invalid(specPath.Child("backupPolicyName"), r.Spec.BackupPolicyName, "tenantCRName and backupPolicyName can not be specified at the same time"))
+	case r.Spec.BackupPolicyName != "":
+		if err := r.checkReferenceExists(&OBTenantBackupPolicy{}, r.Spec.BackupPolicyName, specPath.Child("backupPolicyName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	case r.Spec.TenantCRName != "":
+		if err := r.checkReferenceExists(&OBTenant{}, r.Spec.TenantCRName, specPath.Child("tenantCRName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	default:
+		allErrs = append(allErrs, field.Required(specPath, "tenant to back up is not specified, set tenantName, tenantSecret and obClusterName, or reference an obtenant or a backup policy"))
+	}
+
+	if r.Spec.EncryptionSecret != "" {
+		if err := r.checkReferenceExists(&v1.Secret{}, r.Spec.EncryptionSecret, specPath.Child("encryptionSecret")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("OBTenantBackup").GroupKind(), r.Name, allErrs)
+}
+
+func (r *OBTenantBackup) checkReferenceExists(obj client.Object, name string, fldPath *field.Path) *field.Error {
+	err := tenantBackupClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      name,
+	}, obj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(fldPath, name)
+		}
+		return field.InternalError(fldPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package v1alpha1
+
+import (
+	apiconsts "github.com/oceanbase/ob-operator/api/constants"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test OBTenantBackup Webhook", Label("webhook"), Serial, func() {
+
+	clusterName := "test-cluster-for-obtb"
+	tenantName := "test-tenant-for-obtb"
+
+	It("Create cluster and tenant", func() {
+		c := newOBCluster(clusterName, 1, 1)
+		t := newOBTenant(tenantName, clusterName)
+		Expect(k8sClient.Create(ctx, c)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, t)).Should(Succeed())
+	})
+
+	It("Create backup with explicit tenant", func() {
+		b := newTenantBackup(clusterName)
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Check existence of cluster", func() {
+		b := newTenantBackup("cluster-that-does-not-exist")
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+	})
+
+	It("Check type of backup", func() {
+		b := newTenantBackup(clusterName)
+		b.Spec.Type = ""
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.Type = "123"
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.Type = apiconsts.BackupJobTypeArchive
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.Type = apiconsts.BackupJobTypeClean
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Labels = map[string]string{oceanbaseconst.LabelRefBackupPolicy: "test-policy"}
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Type is case insensitive", func() {
+		b := newTenantBackup(clusterName)
+		b.Spec.Type = "inc"
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		Expect(b.Spec.Type).Should(Equal(apiconsts.BackupJobTypeIncr))
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Check target tenant", func() {
+		b := newTenantBackup(clusterName)
+		b.Spec.TenantSecret = ""
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.TenantCRName = "tenant-that-does-not-exist"
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.TenantCRName = tenantName
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Check referenced backup policy", func() {
+		b := newTenantBackup(clusterName)
+		b.Spec.ObClusterName = ""
+		b.Spec.BackupPolicyName = "policy-that-does-not-exist"
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.TenantCRName = tenantName
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+	})
+
+	It("Check encryption secret", func() {
+		b := newTenantBackup(clusterName)
+		b.Spec.EncryptionSecret = "secret-not-exist"
+		Expect(k8sClient.Create(ctx, b)).ShouldNot(Succeed())
+		b.Spec.EncryptionSecret = defaultSecretName
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Spec is immutable", func() {
+		b := newTenantBackup(clusterName)
+		Expect(k8sClient.Create(ctx, b)).Should(Succeed())
+		b.Spec.Type = apiconsts.BackupJobTypeIncr
+		Expect(k8sClient.Update(ctx, b)).ShouldNot(Succeed())
+		Expect(k8sClient.Delete(ctx, b)).Should(Succeed())
+	})
+
+	It("Delete cluster and tenant", func() {
+		Expect(k8sClient.Delete(ctx, newOBTenant(tenantName, clusterName))).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, newOBCluster(clusterName, 1, 1))).Should(Succeed())
+	})
+})
@@ -15,6 +15,7 @@ See the Mulan PSL v2 for more details.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apitypes "github.com/oceanbase/ob-operator/api/types"
@@ -39,6 +40,8 @@ type OBTenantBackupPolicySpec struct {
 	LogArchive    LogArchiveConfig `json:"logArchive"`
 	DataBackup    DataBackupConfig `json:"dataBackup"`
 	DataClean     CleanPolicy      `json:"dataClean,omitempty"`
+
+	Verification *BackupVerificationConfig `json:"verification,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -56,6 +59,13 @@ type OBTenantBackupPolicyStatus struct {
 	LatestIncrementalJob *model.OBBackupJob      `json:"latestIncrementalJob,omitempty"`
 	LatestArchiveLogJob  *model.OBArchiveLogJob  `json:"latestArchiveLogJob,omitempty"`
 	LatestBackupCleanJob *model.OBBackupCleanJob `json:"latestBackupCleanJob,omitempty"`
+
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -86,6 +96,18 @@ func (in *OBTenantBackupPolicyStatus) DeepCopyInto(out *OBTenantBackupPolicyStat
 		*out = new(model.OBBackupCleanJob)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBTenantBackupPolicyStatus.
@@ -152,6 +174,35 @@ type CleanPolicy struct {
 	Name           string `json:"name,omitempty"`
 	RecoveryWindow string `json:"recoveryWindow,omitempty"`
 	Disabled       string `json:"disabled,omitempty"`
+	// Keep the latest N full backup sets and the incremental backup sets based on them, 0 means no limit
+	KeepLastFullBackups int `json:"keepLastFullBackups,omitempty"`
+	// Keep at least one full backup set older than the duration (e.g. 30d), even if other rules would delete it.
+	// It must not be longer than recoveryWindow, backup sets out of recovery window are deleted by the database
+	KeepOneOlderThan string `json:"keepOneOlderThan,omitempty"`
+	// Delete the oldest backup sets once total size of backup sets exceeds the limit
+	MaxBackupSize *resource.Quantity `json:"maxBackupSize,omitempty"`
+}
+
+// BackupVerificationConfig contains the configuration for verifying backup periodically.
+// The latest backup is restored into a scratch tenant, which is deleted after the smoke check.
+type BackupVerificationConfig struct {
+	ObClusterName string             `json:"obClusterName"` // Name of obcluster to create the scratch tenant in
+	Crontab       string             `json:"crontab"`
+	Pools         []ResourcePoolSpec `json:"pools"` // Resource pools of the scratch tenant
+	//+kubebuilder:default="SELECT 1"
+	SmokeCheckSQL string `json:"smokeCheckSQL,omitempty"`
+	//+kubebuilder:default="24h"
+	Timeout string `json:"timeout,omitempty"` // Max duration of restoring and checking
+}
+
+// BackupVerificationStatus records the latest verification of backup
+type BackupVerificationStatus struct {
+	Phase        apitypes.BackupVerificationPhase `json:"phase"`
+	TenantCRName string                           `json:"tenantCRName,omitempty"`
+	StartedAt    *metav1.Time                     `json:"startedAt,omitempty"`
+	FinishedAt   *metav1.Time                     `json:"finishedAt,omitempty"`
+	Duration     string                           `json:"duration,omitempty"`
+	Message      string                           `json:"message,omitempty"`
 }
 
 func (in *OBTenantBackupPolicy) CopyStatus(out *OBTenantBackupPolicy) {
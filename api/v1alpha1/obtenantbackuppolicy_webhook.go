@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	v1 "k8s.io/api/core/v1"
@@ -46,6 +48,22 @@ import (
 var backupLog = logf.Log.WithName("obtenantbackuppolicy-resource")
 var bakClt client.Client
 
+var destPathPatterns = map[apitypes.BackupDestType]*regexp.Regexp{
+	constants.BackupDestTypeOSS: regexp.MustCompile(`^oss://[^/]+/[^/].*\?host=.+$`),
+	constants.BackupDestTypeCOS: regexp.MustCompile(`^cos://[^/]+/[^/].*$`),
+	constants.BackupDestTypeS3:  regexp.MustCompile(`^s3://[^/]+/[^/].*$`),
+}
+
+var destSecretRequiredKeys = map[apitypes.BackupDestType][]string{
+	constants.BackupDestTypeOSS: {"accessId", "accessKey"},
+	constants.BackupDestTypeCOS: {"accessId", "accessKey", "appId"},
+	constants.BackupDestTypeS3:  {"accessId", "accessKey", "region"},
+}
+
+func isLegalBackupDestType(destType apitypes.BackupDestType) bool {
+	return destType == constants.BackupDestTypeNFS || constants.IsObjectStorageDest(destType)
+}
+
 func (r *OBTenantBackupPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	bakClt = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -81,10 +99,10 @@ func (r *OBTenantBackupPolicy) Default() {
 	// only "default" is permitted
 	r.Spec.DataClean.Name = "default"
 
-	if r.Spec.DataBackup.Destination.Type == constants.BackupDestTypeOSS {
+	if constants.IsObjectStorageDest(r.Spec.DataBackup.Destination.Type) {
 		r.Spec.DataBackup.Destination.Path = strings.ReplaceAll(r.Spec.DataBackup.Destination.Path, "/?", "?")
 	}
-	if r.Spec.LogArchive.Destination.Type == constants.BackupDestTypeOSS {
+	if constants.IsObjectStorageDest(r.Spec.LogArchive.Destination.Type) {
 		r.Spec.LogArchive.Destination.Path = strings.ReplaceAll(r.Spec.LogArchive.Destination.Path, "/?", "?")
 	}
 	if r.Spec.TenantCRName != "" {
@@ -220,8 +238,6 @@ func (r *OBTenantBackupPolicy) validateBackupPolicy() error {
 		return err
 	}
 
-	ossPathPattern := regexp.MustCompile("^oss://[^/]+/[^/].*\\?host=.+$")
-
 	if r.Spec.DataBackup.EncryptionSecret != "" {
 		sec := &v1.Secret{}
 		err := bakClt.Get(context.Background(), types.NamespacedName{
@@ -241,96 +257,102 @@ func (r *OBTenantBackupPolicy) validateBackupPolicy() error {
 	}
 
 	// Check types of destinations are legal
-	if r.Spec.LogArchive.Destination.Type != constants.BackupDestTypeNFS && r.Spec.LogArchive.Destination.Type != constants.BackupDestTypeOSS {
-		return field.Invalid(field.NewPath("spec").Child("logArchive").Child("destination").Child("type"), r.Spec.LogArchive.Destination.Type, "invalid destination type, only NFS and OSS are supported")
+	if !isLegalBackupDestType(r.Spec.LogArchive.Destination.Type) {
+		return field.Invalid(field.NewPath("spec").Child("logArchive").Child("destination").Child("type"), r.Spec.LogArchive.Destination.Type, "invalid destination type, only NFS, OSS, COS and S3 are supported")
 	}
-	if r.Spec.DataBackup.Destination.Type != constants.BackupDestTypeNFS && r.Spec.DataBackup.Destination.Type != constants.BackupDestTypeOSS {
-		return field.Invalid(field.NewPath("spec").Child("dataBackup").Child("destination").Child("type"), r.Spec.DataBackup.Destination.Type, "invalid destination type, only NFS and OSS are supported")
+	if !isLegalBackupDestType(r.Spec.DataBackup.Destination.Type) {
+		return field.Invalid(field.NewPath("spec").Child("dataBackup").Child("destination").Child("type"), r.Spec.DataBackup.Destination.Type, "invalid destination type, only NFS, OSS, COS and S3 are supported")
 	}
 
-	// Check oss access of destinations
-	if r.Spec.DataBackup.Destination.Type == constants.BackupDestTypeOSS && r.Spec.DataBackup.Destination.OSSAccessSecret != "" {
-		if !ossPathPattern.MatchString(r.Spec.DataBackup.Destination.Path) {
-			return field.Invalid(field.NewPath("spec").Child("dataBackup").Child("destination").Child("path"), r.Spec.DataBackup.Destination.Path, "invalid path, pattern: ^oss://[^/]+/[^/].*\\?host=.+$")
-		}
+	// Check access of object storage destinations
+	err = r.validateDestinationAccess(&r.Spec.DataBackup.Destination, field.NewPath("spec").Child("dataBackup").Child("destination"))
+	if err != nil {
+		return err
+	}
+	err = r.validateDestinationAccess(&r.Spec.LogArchive.Destination, field.NewPath("spec").Child("logArchive").Child("destination"))
+	if err != nil {
+		return err
+	}
 
-		secret := &v1.Secret{}
-		err := bakClt.Get(context.Background(), types.NamespacedName{
-			Namespace: r.GetNamespace(),
-			Name:      r.Spec.DataBackup.Destination.OSSAccessSecret,
-		}, secret)
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return field.Invalid(
-					field.NewPath("spec").Child("dataBackup").Child("destination").Child("ossAccessSecret"),
-					r.Spec.DataBackup.Destination.OSSAccessSecret,
-					"Given OSSAccessSecret not found",
-				)
-			}
-			return err
-		}
-		var allErrs field.ErrorList
-
-		if _, ok := secret.Data["accessId"]; !ok {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("dataBackup").Child("destination").Child("ossAccessSecret"),
-				r.Spec.DataBackup.Destination.OSSAccessSecret,
-				"accessId field not found in given OSSAccessSecret",
-			))
-		}
-		if _, ok := secret.Data["accessKey"]; !ok {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("dataBackup").Child("destination").Child("ossAccessSecret"),
-				r.Spec.DataBackup.Destination.OSSAccessSecret,
-				"accessKey field not found in given OSSAccessSecret",
-			))
-		}
-		if len(allErrs) != 0 {
-			return allErrs.ToAggregate()
+	return r.validateVerification()
+}
+
+func (r *OBTenantBackupPolicy) validateVerification() error {
+	verification := r.Spec.Verification
+	if verification == nil {
+		return nil
+	}
+	fldPath := field.NewPath("spec").Child("verification")
+	cluster := &OBCluster{}
+	err := bakClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      verification.ObClusterName,
+	}, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Invalid(fldPath.Child("obClusterName"), verification.ObClusterName, "Given cluster not found")
 		}
+		return field.InternalError(fldPath.Child("obClusterName"), err)
 	}
 
-	if r.Spec.LogArchive.Destination.Type == constants.BackupDestTypeOSS && r.Spec.LogArchive.Destination.OSSAccessSecret != "" {
-		if !ossPathPattern.MatchString(r.Spec.LogArchive.Destination.Path) {
-			return field.Invalid(field.NewPath("spec").Child("logArchive").Child("destination").Child("path"), r.Spec.LogArchive.Destination.Path, "invalid path, pattern: ^oss://[^/]+/[^/].*\\?host=.+$")
+	var allErrs field.ErrorList
+	if fieldErr := validateScheduleFormat(verification.Crontab, fldPath.Child("crontab")); fieldErr != nil {
+		allErrs = append(allErrs, fieldErr)
+	}
+	if len(verification.Pools) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("pools"), "pools of scratch tenant are required"))
+	}
+	if verification.Timeout != "" {
+		if _, err := time.ParseDuration(verification.Timeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), verification.Timeout, err.Error()))
 		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("OBTenantBackupPolicy").GroupKind(), r.Name, allErrs)
+}
 
-		secret := &v1.Secret{}
-		err := bakClt.Get(context.Background(), types.NamespacedName{
-			Namespace: r.GetNamespace(),
-			Name:      r.Spec.LogArchive.Destination.OSSAccessSecret,
-		}, secret)
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return field.Invalid(
-					field.NewPath("spec").Child("logArchive").Child("destination").Child("ossAccessSecret"),
-					r.Spec.LogArchive.Destination.OSSAccessSecret,
-					"Given OSSAccessSecret not found",
-				)
-			}
-			return err
+func (r *OBTenantBackupPolicy) validateDestinationAccess(dest *apitypes.BackupDestination, destPath *field.Path) error {
+	if !constants.IsObjectStorageDest(dest.Type) || dest.OSSAccessSecret == "" {
+		return nil
+	}
+	pathPattern, ok := destPathPatterns[dest.Type]
+	if ok && !pathPattern.MatchString(dest.Path) {
+		return field.Invalid(destPath.Child("path"), dest.Path, "invalid path, pattern: "+pathPattern.String())
+	}
+
+	secret := &v1.Secret{}
+	err := bakClt.Get(context.Background(), types.NamespacedName{
+		Namespace: r.GetNamespace(),
+		Name:      dest.OSSAccessSecret,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Invalid(destPath.Child("ossAccessSecret"), dest.OSSAccessSecret, "Given OSSAccessSecret not found")
 		}
+		return err
+	}
 
-		var allErrs field.ErrorList
-		if _, ok := secret.Data["accessId"]; !ok {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("logArchive").Child("destination").Child("ossAccessSecret"),
-				r.Spec.LogArchive.Destination.OSSAccessSecret,
-				"accessId field not found in given OSSAccessSecret",
-			))
+	var allErrs field.ErrorList
+	for _, key := range destSecretRequiredKeys[dest.Type] {
+		if _, ok := secret.Data[key]; !ok {
+			allErrs = append(allErrs, field.Invalid(destPath.Child("ossAccessSecret"), dest.OSSAccessSecret, key+" field not found in given OSSAccessSecret"))
 		}
-		if _, ok := secret.Data["accessKey"]; !ok {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("logArchive").Child("destination").Child("ossAccessSecret"),
-				r.Spec.LogArchive.Destination.OSSAccessSecret,
-				"accessKey field not found in given OSSAccessSecret",
-			))
+	}
+	if style, ok := secret.Data["addressingStyle"]; ok && dest.Type == constants.BackupDestTypeS3 {
+		if string(style) != constants.S3AddressingStyleVirtual && string(style) != constants.S3AddressingStylePath {
+			allErrs = append(allErrs, field.Invalid(destPath.Child("ossAccessSecret"), dest.OSSAccessSecret, "addressingStyle field in given OSSAccessSecret must be virtual or path"))
 		}
-		if len(allErrs) != 0 {
-			return allErrs.ToAggregate()
+	}
+	if dest.Type != constants.BackupDestTypeOSS && !strings.Contains(dest.Path, "host=") {
+		if _, ok := secret.Data["endpoint"]; !ok {
+			allErrs = append(allErrs, field.Invalid(destPath.Child("path"), dest.Path, "host must be specified in path or endpoint field of OSSAccessSecret"))
 		}
 	}
-
+	if len(allErrs) != 0 {
+		return allErrs.ToAggregate()
+	}
 	return nil
 }
 
@@ -345,6 +367,20 @@ func (r *OBTenantBackupPolicy) validateInterval() error {
 	if !recoveryPattern.MatchString(r.Spec.DataClean.RecoveryWindow) {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataClean").Child("recoveryWindow"), r.Spec.DataClean.RecoveryWindow, "invalid recoveryWindow"))
 	}
+	if r.Spec.DataClean.KeepOneOlderThan != "" {
+		if !recoveryPattern.MatchString(r.Spec.DataClean.KeepOneOlderThan) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataClean").Child("keepOneOlderThan"), r.Spec.DataClean.KeepOneOlderThan, "invalid keepOneOlderThan"))
+		} else if recoveryPattern.MatchString(r.Spec.DataClean.RecoveryWindow) && daysOf(r.Spec.DataClean.KeepOneOlderThan) > daysOf(r.Spec.DataClean.RecoveryWindow) {
+			// Backup sets out of recovery window are deleted by the clean policy of database, they could not be kept by operator
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataClean").Child("keepOneOlderThan"), r.Spec.DataClean.KeepOneOlderThan, "keepOneOlderThan must not be longer than recoveryWindow"))
+		}
+	}
+	if r.Spec.DataClean.KeepLastFullBackups < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataClean").Child("keepLastFullBackups"), r.Spec.DataClean.KeepLastFullBackups, "keepLastFullBackups must not be negative"))
+	}
+	if r.Spec.DataClean.MaxBackupSize != nil && r.Spec.DataClean.MaxBackupSize.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataClean").Child("maxBackupSize"), r.Spec.DataClean.MaxBackupSize.String(), "maxBackupSize must be positive"))
+	}
 	if r.Spec.JobKeepWindow != "" {
 		jobKeepPattern := regexp.MustCompile(`^[1-9]\d*d$`)
 		if !jobKeepPattern.MatchString(r.Spec.JobKeepWindow) {
@@ -357,6 +393,12 @@ func (r *OBTenantBackupPolicy) validateInterval() error {
 	return apierrors.NewInvalid(GroupVersion.WithKind("OBTenantBackupPolicy").GroupKind(), r.Name, allErrs)
 }
 
+// daysOf parses duration in days like 7d, the format should be checked before
+func daysOf(duration string) int {
+	days, _ := strconv.Atoi(strings.TrimSuffix(duration, "d"))
+	return days
+}
+
 func (r *OBTenantBackupPolicy) validateBackupCrontab() error {
 	var allErrs field.ErrorList
 	err := validateScheduleFormat(r.Spec.DataBackup.FullCrontab, field.NewPath("spec").Child("dataBackup").Child("fullCrontab"))
@@ -13,6 +13,10 @@ See the Mulan PSL v2 for more details.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	apiconsts "github.com/oceanbase/ob-operator/api/constants"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -112,6 +116,29 @@ var _ = Describe("Test OBTenantBackupPolicy Webhook", Label("webhook"), Serial,
 		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
 	})
 
+	It("Check addressing style of s3 secret", func() {
+		s3Secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "s3-secret-wrong-style",
+				Namespace: defaultNamespace,
+			},
+			Data: map[string][]byte{
+				"accessId":        []byte("id"),
+				"accessKey":       []byte("key"),
+				"region":          []byte("us-east-1"),
+				"endpoint":        []byte("s3.us-east-1.amazonaws.com"),
+				"addressingStyle": []byte("subdomain"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, s3Secret)).Should(Succeed())
+		p := newBackupPolicy(policyName, tenantName, clusterName)
+		p.Spec.DataBackup.Destination.Type = apiconsts.BackupDestTypeS3
+		p.Spec.DataBackup.Destination.Path = "s3://bucket/backup"
+		p.Spec.DataBackup.Destination.OSSAccessSecret = s3Secret.Name
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		Expect(k8sClient.Delete(ctx, s3Secret)).Should(Succeed())
+	})
+
 	It("Check oss path", func() {
 		p := newBackupPolicy(policyName, tenantName, clusterName)
 		p.Spec.LogArchive.Destination.Path = "oss://bucket/backup?host=oss-cn-hangzhou.aliyuncs.com"
@@ -187,6 +214,21 @@ var _ = Describe("Test OBTenantBackupPolicy Webhook", Label("webhook"), Serial,
 		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
 	})
 
+	It("Check retention rules", func() {
+		p := newBackupPolicy(policyName, tenantName, clusterName)
+		p.Spec.DataClean.KeepOneOlderThan = "30h"
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.DataClean.KeepOneOlderThan = "30d"
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.DataClean.RecoveryWindow = "30d"
+		p.Spec.DataClean.KeepLastFullBackups = -1
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.DataClean.KeepLastFullBackups = 3
+		size := resource.MustParse("0")
+		p.Spec.DataClean.MaxBackupSize = &size
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+	})
+
 	It("Check intervals 3", func() {
 		p := newBackupPolicy(policyName, tenantName, clusterName)
 		p.Spec.LogArchive.SwitchPieceInterval = "1sec"
@@ -194,4 +236,24 @@ var _ = Describe("Test OBTenantBackupPolicy Webhook", Label("webhook"), Serial,
 		p.Spec.LogArchive.SwitchPieceInterval = "1h"
 		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
 	})
+
+	It("Check verification", func() {
+		t := newOBTenant(tenantName, clusterName)
+		p := newBackupPolicy(policyName, tenantName, clusterName)
+		p.Spec.Verification = &BackupVerificationConfig{
+			ObClusterName: "cluster-that-does-not-exist",
+			Crontab:       "0 3 * * 0",
+			Pools:         t.Spec.Pools,
+		}
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.Verification.ObClusterName = clusterName
+		p.Spec.Verification.Crontab = "* * *"
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.Verification.Crontab = "0 3 * * 0"
+		p.Spec.Verification.Pools = nil
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+		p.Spec.Verification.Pools = t.Spec.Pools
+		p.Spec.Verification.Timeout = "1d"
+		Expect(k8sClient.Create(ctx, p)).ShouldNot(Succeed())
+	})
 })
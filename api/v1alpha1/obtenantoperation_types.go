@@ -62,6 +62,11 @@ type OBTenantOperationStatus struct {
 	OperationContext *tasktypes.OperationContext    `json:"operationContext,omitempty"`
 	PrimaryTenant    *OBTenant                      `json:"primaryTenant,omitempty"`
 	SecondaryTenant  *OBTenant                      `json:"secondaryTenant,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -34,6 +34,8 @@ type OBTenantRestoreSpec struct {
 	Source        RestoreSourceSpec   `json:"source"`
 	Option        string              `json:"restoreOption"`
 	PrimaryTenant *string             `json:"primaryTenant,omitempty"`
+
+	ExternalPrimary *ExternalTenantSourceSpec `json:"externalPrimary,omitempty"`
 }
 
 type RestoreSourceSpec struct {
@@ -63,10 +65,23 @@ type OBTenantRestoreStatus struct {
 	Status           apitypes.RestoreJobStatus   `json:"status"`
 	RestoreProgress  *model.RestoreHistory       `json:"restoreProgress,omitempty"`
 	OperationContext *tasktypes.OperationContext `json:"operationContext,omitempty"`
+	// Range that the tenant could be restored to, computed from backup sets and archive log pieces in the source
+	RestorableWindow *model.RestorableWindow `json:"restorableWindow,omitempty"`
+	Message          string                  `json:"message,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation, status is kept as it was
+	Paused bool `json:"paused,omitempty"`
 }
 
 func (in *OBTenantRestoreStatus) DeepCopyInto(out *OBTenantRestoreStatus) {
 	*out = *in
+	if in.RestorableWindow != nil {
+		in, out := &in.RestorableWindow, &out.RestorableWindow
+		*out = new(model.RestorableWindow)
+		**out = **in
+	}
 	if in.RestoreProgress != nil {
 		in, out := &in.RestoreProgress, &out.RestoreProgress
 		*out = new(model.RestoreHistory)
@@ -77,6 +92,13 @@ func (in *OBTenantRestoreStatus) DeepCopyInto(out *OBTenantRestoreStatus) {
 		*out = new(tasktypes.OperationContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 //+kubebuilder:object:root=true
@@ -39,6 +39,8 @@ type OBZoneSpec struct {
 	BackupVolume     *apitypes.BackupVolumeSpec `json:"backupVolume,omitempty"`
 	//+kubebuilder:default=default
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Stop the zone in cluster and keep it out of service while the pods and pvcs of observers are retained.
+	Maintenance bool `json:"maintenance,omitempty"`
 }
 
 // OBZoneStatus defines the observed state of OBZone
@@ -49,6 +51,12 @@ type OBZoneStatus struct {
 	OperationContext *tasktypes.OperationContext      `json:"operationContext,omitempty"`
 	Status           string                           `json:"status"`
 	OBServerStatus   []apitypes.OBServerReplicaStatus `json:"observers"`
+	ScaleIn          *apitypes.ScaleInStatus          `json:"scaleIn,omitempty"`
+
+	// Records of the latest finished task flows
+	FlowHistory []tasktypes.FlowRecord `json:"flowHistory,omitempty"`
+	// Whether reconciliation is paused by annotation of itself or its obcluster
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -211,3 +211,31 @@ func newTenantOperation(tenantName string) *OBTenantOperation {
 		},
 	}
 }
+
+func newClusterOperation(clusterName string) *OBClusterOperation {
+	return &OBClusterOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      rand.String(32),
+		},
+		Spec: OBClusterOperationSpec{
+			OBCluster: clusterName,
+			Type:      apiconsts.ClusterOpRestart,
+		},
+	}
+}
+
+func newTenantBackup(clusterName string) *OBTenantBackup {
+	return &OBTenantBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      rand.String(32),
+		},
+		Spec: OBTenantBackupSpec{
+			Type:          apiconsts.BackupJobTypeFull,
+			ObClusterName: clusterName,
+			TenantName:    "t1",
+			TenantSecret:  defaultSecretName,
+		},
+	}
+}
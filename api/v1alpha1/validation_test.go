@@ -16,6 +16,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 )
 
 var _ = Describe("Test validations", Label("validation"), func() {
@@ -30,4 +34,34 @@ var _ = Describe("Test validations", Label("validation"), func() {
 		threshold := resource.MustParse("10Gi")
 		Expect(overflow.AsApproximateFloat64() > threshold.AsApproximateFloat64()).To(BeTrue())
 	})
+
+	It("Select observers to delete", func() {
+		newServer := func(name, status string, candidate bool) OBServer {
+			observer := OBServer{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			observer.Status.Status = status
+			if candidate {
+				observer.Annotations = map[string]string{oceanbaseconst.AnnotationsScaleInCandidate: "true"}
+			}
+			return observer
+		}
+		names := func(observers []OBServer) []string {
+			result := make([]string, 0, len(observers))
+			for _, observer := range observers {
+				result = append(result, observer.Name)
+			}
+			return result
+		}
+		observers := []OBServer{
+			newServer("a", serverstatus.Running, false),
+			newServer("b", serverstatus.Running, true),
+			newServer("c", serverstatus.Running, false),
+			newServer("d", serverstatus.Deleting, false),
+		}
+		Expect(names(SelectOBServersToDelete(observers, 3))).To(BeEmpty())
+		Expect(names(SelectOBServersToDelete(observers, 2))).To(Equal([]string{"b"}))
+		Expect(names(SelectOBServersToDelete(observers, 1))).To(Equal([]string{"b", "c"}))
+
+		observers[0].Status.Status = serverstatus.Unrecoverable
+		Expect(names(SelectOBServersToDelete(observers, 2))).To(Equal([]string{"a"}))
+	})
 })
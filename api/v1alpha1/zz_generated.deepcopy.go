@@ -22,13 +22,64 @@ package v1alpha1
 
 import (
 	"github.com/oceanbase/ob-operator/api/types"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationConfig) DeepCopyInto(out *BackupVerificationConfig) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]ResourcePoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationConfig.
+func (in *BackupVerificationConfig) DeepCopy() *BackupVerificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanPolicy) DeepCopyInto(out *CleanPolicy) {
 	*out = *in
+	if in.MaxBackupSize != nil {
+		in, out := &in.MaxBackupSize, &out.MaxBackupSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanPolicy.
@@ -57,6 +108,21 @@ func (in *DataBackupConfig) DeepCopy() *DataBackupConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTenantSourceSpec) DeepCopyInto(out *ExternalTenantSourceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTenantSourceSpec.
+func (in *ExternalTenantSourceSpec) DeepCopy() *ExternalTenantSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalTenantSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalityType) DeepCopyInto(out *LocalityType) {
 	*out = *in
@@ -162,6 +228,189 @@ func (in *OBClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOpDeleteOBServerSpec) DeepCopyInto(out *OBClusterOpDeleteOBServerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOpDeleteOBServerSpec.
+func (in *OBClusterOpDeleteOBServerSpec) DeepCopy() *OBClusterOpDeleteOBServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOpDeleteOBServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOpMajorFreezeSpec) DeepCopyInto(out *OBClusterOpMajorFreezeSpec) {
+	*out = *in
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOpMajorFreezeSpec.
+func (in *OBClusterOpMajorFreezeSpec) DeepCopy() *OBClusterOpMajorFreezeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOpMajorFreezeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOpZoneSpec) DeepCopyInto(out *OBClusterOpZoneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOpZoneSpec.
+func (in *OBClusterOpZoneSpec) DeepCopy() *OBClusterOpZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOpZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOperation) DeepCopyInto(out *OBClusterOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOperation.
+func (in *OBClusterOperation) DeepCopy() *OBClusterOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OBClusterOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOperationList) DeepCopyInto(out *OBClusterOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OBClusterOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOperationList.
+func (in *OBClusterOperationList) DeepCopy() *OBClusterOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OBClusterOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOperationSpec) DeepCopyInto(out *OBClusterOperationSpec) {
+	*out = *in
+	if in.StopZone != nil {
+		in, out := &in.StopZone, &out.StopZone
+		*out = new(OBClusterOpZoneSpec)
+		**out = **in
+	}
+	if in.StartZone != nil {
+		in, out := &in.StartZone, &out.StartZone
+		*out = new(OBClusterOpZoneSpec)
+		**out = **in
+	}
+	if in.DeleteOBServer != nil {
+		in, out := &in.DeleteOBServer, &out.DeleteOBServer
+		*out = new(OBClusterOpDeleteOBServerSpec)
+		**out = **in
+	}
+	if in.MajorFreeze != nil {
+		in, out := &in.MajorFreeze, &out.MajorFreeze
+		*out = new(OBClusterOpMajorFreezeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOperationSpec.
+func (in *OBClusterOperationSpec) DeepCopy() *OBClusterOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OBClusterOperationStatus) DeepCopyInto(out *OBClusterOperationStatus) {
+	*out = *in
+	if in.OperationContext != nil {
+		in, out := &in.OperationContext, &out.OperationContext
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FrozenScnsBeforeFreeze != nil {
+		in, out := &in.FrozenScnsBeforeFreeze, &out.FrozenScnsBeforeFreeze
+		*out = make([]types.TenantFrozenScn, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterOperationStatus.
+func (in *OBClusterOperationStatus) DeepCopy() *OBClusterOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OBClusterOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OBClusterSpec) DeepCopyInto(out *OBClusterSpec) {
 	*out = *in
@@ -195,6 +444,11 @@ func (in *OBClusterSpec) DeepCopyInto(out *OBClusterSpec) {
 		in, out := &in.UserSecrets, &out.UserSecrets
 		*out = (*in).DeepCopy()
 	}
+	if in.NodeFailurePolicy != nil {
+		in, out := &in.NodeFailurePolicy, &out.NodeFailurePolicy
+		*out = new(types.NodeFailurePolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterSpec.
@@ -228,6 +482,28 @@ func (in *OBClusterStatus) DeepCopyInto(out *OBClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(types.RollingRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MajorCompaction != nil {
+		in, out := &in.MajorCompaction, &out.MajorCompaction
+		*out = new(types.MajorCompactionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(tasktypes.ChangePlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBClusterStatus.
@@ -332,6 +608,13 @@ func (in *OBParameterStatus) DeepCopyInto(out *OBParameterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBParameterStatus.
@@ -526,6 +809,11 @@ func (in *OBServerSpec) DeepCopyInto(out *OBServerSpec) {
 		in, out := &in.BackupVolume, &out.BackupVolume
 		*out = (*in).DeepCopy()
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(v1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBServerSpec.
@@ -545,6 +833,13 @@ func (in *OBServerStatus) DeepCopyInto(out *OBServerStatus) {
 		in, out := &in.OperationContext, &out.OperationContext
 		*out = (*in).DeepCopy()
 	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBServerStatus.
@@ -648,7 +943,7 @@ func (in *OBTenantBackupPolicy) DeepCopyInto(out *OBTenantBackupPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -707,7 +1002,12 @@ func (in *OBTenantBackupPolicySpec) DeepCopyInto(out *OBTenantBackupPolicySpec)
 	*out = *in
 	out.LogArchive = in.LogArchive
 	out.DataBackup = in.DataBackup
-	out.DataClean = in.DataClean
+	in.DataClean.DeepCopyInto(&out.DataClean)
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBTenantBackupPolicySpec.
@@ -933,6 +1233,13 @@ func (in *OBTenantOperationStatus) DeepCopyInto(out *OBTenantOperationStatus) {
 		*out = new(OBTenant)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBTenantOperationStatus.
@@ -1013,6 +1320,11 @@ func (in *OBTenantRestoreSpec) DeepCopyInto(out *OBTenantRestoreSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ExternalPrimary != nil {
+		in, out := &in.ExternalPrimary, &out.ExternalPrimary
+		*out = new(ExternalTenantSourceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBTenantRestoreSpec.
@@ -1154,6 +1466,18 @@ func (in *OBZoneStatus) DeepCopyInto(out *OBZoneStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ScaleIn != nil {
+		in, out := &in.ScaleIn, &out.ScaleIn
+		*out = new(types.ScaleInStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowHistory != nil {
+		in, out := &in.FlowHistory, &out.FlowHistory
+		*out = make([]tasktypes.FlowRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OBZoneStatus.
@@ -1325,6 +1649,11 @@ func (in *TenantSourceSpec) DeepCopyInto(out *TenantSourceSpec) {
 		*out = new(RestoreSourceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalTenantSourceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSourceSpec.
@@ -46,6 +46,10 @@ func init() {
 // @SecurityDefinitions.apiKey ApiKeyAuth
 // @in header
 // @name Cookie
+// @SecurityDefinitions.apiKey BearerAuth
+// @in header
+// @name Authorization
+// @description API token created by /api/v1/tokens, in the form of "Bearer <token>"
 func main() {
 	httpServer := server.NewHTTPServer()
 	err := httpServer.RegisterRouter()
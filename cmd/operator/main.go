@@ -189,6 +189,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "OBTenantOperation")
 		os.Exit(1)
 	}
+	if err = (&controller.OBClusterOperationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(config.OBClusterOperationControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OBClusterOperation")
+		os.Exit(1)
+	}
 	if err = (controller.NewOBResourceRescueReconciler(mgr)).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OBResourceRescue")
 		os.Exit(1)
@@ -198,6 +206,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OBTenantBackupPolicy")
 			os.Exit(1)
 		}
+		if err = (&v1alpha1.OBTenantBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OBTenantBackup")
+			os.Exit(1)
+		}
 		if err = (&v1alpha1.OBTenant{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OBTenant")
 			os.Exit(1)
@@ -214,6 +226,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OBResourceRescue")
 			os.Exit(1)
 		}
+		if err = (&v1alpha1.OBClusterOperation{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OBClusterOperation")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
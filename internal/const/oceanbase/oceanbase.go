@@ -18,13 +18,19 @@ var UpgradeEssentialParameters = [...]string{"server_permanent_offline_time", "e
 var ReservedParameters = [...]string{"cpu_count", "datafile_size", "log_disk_size", "enable_syslog_recycle", "max_syslog_file_count"}
 
 const (
-	BootstrapTimeoutSeconds       = 300
-	LocalityChangeTimeoutSeconds  = 3600
-	DefaultStateWaitTimeout       = 300
-	TimeConsumingStateWaitTimeout = 3600
-	ServerDeleteTimeoutSeconds    = 86400
-	GigaConverter                 = 1 << 30
-	MegaConverter                 = 1 << 20
+	BootstrapTimeoutSeconds        = 300
+	LocalityChangeTimeoutSeconds   = 3600
+	DefaultStateWaitTimeout        = 300
+	TimeConsumingStateWaitTimeout  = 3600
+	ServerDeleteTimeoutSeconds     = 86400
+	MajorCompactionTimeoutSeconds  = 86400
+	MajorCompactionCheckGapSeconds = 10
+	MajorCompactionRefreshSeconds  = 60
+	ScaleInCheckGapSeconds         = 10
+	DefaultNodeNotReadyTimeout     = "10m"
+	RelocationCheckGapSeconds      = 60
+	GigaConverter                  = 1 << 30
+	MegaConverter                  = 1 << 20
 )
 
 const (
@@ -48,6 +54,9 @@ var (
 	MinLogDiskSize     = resource.MustParse("10Gi")
 )
 
+// MinCpuCount is the least cpu_count that observer starts with
+const MinCpuCount = 16
+
 const (
 	SqlPort = 2881
 	RpcPort = 2882
@@ -78,6 +87,10 @@ const (
 	AnnotationsSinglePVC               = "oceanbase.oceanbase.com/single-pvc"
 	AnnotationsMode                    = "oceanbase.oceanbase.com/mode"
 	AnnotationsSourceClusterAddress    = "oceanbase.oceanbase.com/source-cluster-address"
+	AnnotationsRestartAt               = "oceanbase.oceanbase.com/restart-at"
+	AnnotationsScaleInCandidate        = "oceanbase.oceanbase.com/scale-in-candidate"
+	AnnotationsPlanSpec                = "oceanbase.oceanbase.com/plan-spec"
+	AnnotationsPauseReconcile          = "oceanbase.oceanbase.com/pause-reconcile"
 )
 
 const (
@@ -122,6 +135,7 @@ const (
 
 const (
 	SysTenant       = "sys"
+	SysTenantID     = 1
 	SysTenantPool   = "sys_pool"
 	DefaultDatabase = "oceanbase"
 	DefaultRegion   = "default"
@@ -164,6 +178,8 @@ const (
 const (
 	TaskMaxRetryTimes         = 99
 	TaskRetryBackoffThreshold = 16
+	// Scaling resources is retried a few times only, it hardly succeeds later if units do not fit in new resources
+	ScaleResourceMaxRetryTimes = 9
 )
 
 const (
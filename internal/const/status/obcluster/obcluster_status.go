@@ -27,4 +27,5 @@ const (
 	ExpandPVC           = "expand pvc"
 	Failed              = "failed"
 	MountBackupVolume   = "mount backup volume"
+	RollingRestart      = "rolling restart"
 )
@@ -26,4 +26,8 @@ const (
 	ExpandPVC         = "expand pvc"
 	FinalizerFinished = "finalizer finished"
 	MountBackupVolume = "mount backup volume"
+	Maintenance       = "maintenance"
+	EnterMaintenance  = "enter maintenance"
+	ExitMaintenance   = "exit maintenance"
+	Relocate          = "relocate"
 )
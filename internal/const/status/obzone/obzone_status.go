@@ -26,4 +26,7 @@ const (
 	ScaleUp             = "scale up"
 	ExpandPVC           = "expand pvc"
 	MountBackupVolume   = "mount backup volume"
+	Maintenance         = "maintenance"
+	EnterMaintenance    = "enter maintenance"
+	ExitMaintenance     = "exit maintenance"
 )
@@ -22,5 +22,6 @@ const (
 	OBTenantRestoreControllerName      = "obtenantrestore-controller"
 	OBTenantBackupPolicyControllerName = "obtenantbackuppolicy-controller"
 	OBTenantOperationControllerName    = "obtenantoperation-controller"
+	OBClusterOperationControllerName   = "obclusteroperation-controller"
 	OBResourceRescueControllerName     = "obresourcerescue-controller"
 )
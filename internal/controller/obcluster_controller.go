@@ -31,6 +31,7 @@ import (
 	resobcluster "github.com/oceanbase/ob-operator/internal/resource/obcluster"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 )
 
 // OBClusterReconciler reconciles a OBCluster object
@@ -73,6 +74,7 @@ func (r *OBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	err := r.Client.Get(ctx, req.NamespacedName, obcluster)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBCluster", req.NamespacedName)
 			// obcluster not found, just return
 			return ctrl.Result{}, nil
 		}
@@ -90,7 +92,7 @@ func (r *OBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		Logger:    &logger,
 		Recorder:  telemetry.NewRecorder(ctx, r.Recorder),
 	}
-	coordinator := coordinator.NewCoordinator(obclusterManager, &logger)
+	coordinator := coordinator.NewCoordinator(obclusterManager, &logger).WithMetrics("OBCluster", req.NamespacedName).WithPriority(priority.High)
 	return coordinator.Coordinate()
 }
 
@@ -0,0 +1,83 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
+	resclusteroperation "github.com/oceanbase/ob-operator/internal/resource/obclusteroperation"
+	"github.com/oceanbase/ob-operator/internal/telemetry"
+	"github.com/oceanbase/ob-operator/pkg/coordinator"
+)
+
+// OBClusterOperationReconciler reconciles a OBClusterOperation object
+type OBClusterOperationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=oceanbase.oceanbase.com,resources=obclusteroperations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=oceanbase.oceanbase.com,resources=obclusteroperations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=oceanbase.oceanbase.com,resources=obclusteroperations/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=oceanbase.oceanbase.com,resources=obclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=oceanbase.oceanbase.com,resources=observers,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.15.0/pkg/reconcile
+func (r *OBClusterOperationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	operation := &v1alpha1.OBClusterOperation{}
+	err := r.Client.Get(ctx, req.NamespacedName, operation)
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBClusterOperation", req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mgr := &resclusteroperation.ObClusterOperationManager{
+		Ctx:      ctx,
+		Resource: operation,
+		Client:   r.Client,
+		Logger:   &logger,
+		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
+	}
+
+	coordinator := coordinator.NewCoordinator(mgr, &logger).WithMetrics("OBClusterOperation", req.NamespacedName)
+	return coordinator.Coordinate()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OBClusterOperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OBClusterOperation{}).
+		WithEventFilter(preds).
+		Complete(r)
+}
@@ -58,6 +58,7 @@ func (r *OBParameterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	err := r.Client.Get(ctx, req.NamespacedName, obparameter)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBParameter", req.NamespacedName)
 			// obparameter not found, just return
 			return ctrl.Result{}, nil
 		}
@@ -75,7 +76,7 @@ func (r *OBParameterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Logger:      &logger,
 		Recorder:    telemetry.NewRecorder(ctx, r.Recorder),
 	}
-	coordinator := coordinator.NewCoordinator(obparameterManager, &logger)
+	coordinator := coordinator.NewCoordinator(obparameterManager, &logger).WithMetrics("OBParameter", req.NamespacedName)
 	return coordinator.Coordinate()
 }
 
@@ -34,6 +34,7 @@ import (
 	resobserver "github.com/oceanbase/ob-operator/internal/resource/observer"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 )
 
 // OBServerReconciler reconciles a OBServer object
@@ -53,6 +54,7 @@ type OBServerReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -70,6 +72,7 @@ func (r *OBServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	err := r.Client.Get(ctx, req.NamespacedName, observer)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBServer", req.NamespacedName)
 			// observer not found, just return
 			return ctrl.Result{}, nil
 		}
@@ -97,14 +100,14 @@ func (r *OBServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			}
 		}
 		if needExecuteFinalizer {
-			err = observerManager.DeleteOBServerInCluster()
+			err = observerManager.DeleteOBServerInCluster(ctx)
 			if err != nil {
 				logger.Error(err, "delete observer failed")
 				return ctrl.Result{}, errors.Wrapf(err, "delete observer %s failed", observer.Name)
 			}
 		}
 	}
-	coordinator := coordinator.NewCoordinator(observerManager, &logger)
+	coordinator := coordinator.NewCoordinator(observerManager, &logger).WithMetrics("OBServer", req.NamespacedName).WithPriority(priority.High)
 	result, err := coordinator.Coordinate()
 	if err != nil {
 		return result, err
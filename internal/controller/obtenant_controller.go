@@ -64,6 +64,7 @@ func (r *OBTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	err := r.Client.Get(ctx, req.NamespacedName, obtenant)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBTenant", req.NamespacedName)
 			// observer not found, just return
 			return ctrl.Result{}, nil
 		}
@@ -92,7 +93,7 @@ func (r *OBTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
 	}
 
-	coordinator := coordinator.NewCoordinator(obtenantManager, &logger)
+	coordinator := coordinator.NewCoordinator(obtenantManager, &logger).WithMetrics("OBTenant", req.NamespacedName)
 	return coordinator.Coordinate()
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +29,7 @@ import (
 	resobbackup "github.com/oceanbase/ob-operator/internal/resource/obtenantbackup"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 
 	"github.com/oceanbase/ob-operator/api/constants"
 	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
@@ -53,6 +55,9 @@ func (r *OBTenantBackupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	logger := log.FromContext(ctx)
 	crJob := &v1alpha1.OBTenantBackup{}
 	if err := r.Get(ctx, req.NamespacedName, crJob); err != nil {
+		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBTenantBackup", req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -73,7 +78,7 @@ func (r *OBTenantBackupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
 	}
 
-	result, err := coordinator.NewCoordinator(mgr, &logger).Coordinate()
+	result, err := coordinator.NewCoordinator(mgr, &logger).WithMetrics("OBTenantBackup", req.NamespacedName).WithPriority(priority.Low).Coordinate()
 	if result.RequeueAfter < time.Second*5 {
 		result.RequeueAfter = time.Second * 5
 	}
@@ -17,6 +17,7 @@ package controller
 import (
 	"context"
 
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -28,6 +29,7 @@ import (
 	resbackuppolicy "github.com/oceanbase/ob-operator/internal/resource/obtenantbackuppolicy"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 )
 
 // OBTenantBackupPolicyReconciler reconciles a OBTenantBackupPolicy object
@@ -54,6 +56,9 @@ func (r *OBTenantBackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 	policy := &v1alpha1.OBTenantBackupPolicy{}
 	err := r.Client.Get(ctx, req.NamespacedName, policy)
 	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBTenantBackupPolicy", req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -77,7 +82,7 @@ func (r *OBTenantBackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		Recorder:     telemetry.NewRecorder(ctx, r.Recorder),
 	}
 
-	coordinator := coordinator.NewCoordinator(mgr, &logger)
+	coordinator := coordinator.NewCoordinator(mgr, &logger).WithMetrics("OBTenantBackupPolicy", req.NamespacedName).WithPriority(priority.Low)
 	return coordinator.Coordinate()
 }
 
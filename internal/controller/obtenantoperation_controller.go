@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -55,6 +56,9 @@ func (r *OBTenantOperationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	operation := &v1alpha1.OBTenantOperation{}
 	err := r.Client.Get(ctx, req.NamespacedName, operation)
 	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBTenantOperation", req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -66,7 +70,7 @@ func (r *OBTenantOperationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
 	}
 
-	coordinator := coordinator.NewCoordinator(mgr, &logger)
+	coordinator := coordinator.NewCoordinator(mgr, &logger).WithMetrics("OBTenantOperation", req.NamespacedName)
 	return coordinator.Coordinate()
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +31,7 @@ import (
 	restenantrestore "github.com/oceanbase/ob-operator/internal/resource/obtenantrestore"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 )
 
 // OBTenantRestoreReconciler reconciles a OBTenantRestore object
@@ -57,6 +59,9 @@ func (r *OBTenantRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	restore := &v1alpha1.OBTenantRestore{}
 	err := r.Client.Get(ctx, req.NamespacedName, restore)
 	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBTenantRestore", req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -68,7 +73,7 @@ func (r *OBTenantRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
 	}
 
-	coordinator := coordinator.NewCoordinator(mgr, &logger)
+	coordinator := coordinator.NewCoordinator(mgr, &logger).WithMetrics("OBTenantRestore", req.NamespacedName).WithPriority(priority.Low)
 	_, err = coordinator.Coordinate()
 	return ctrl.Result{
 		RequeueAfter: 10 * time.Second,
@@ -30,6 +30,7 @@ import (
 	resobzone "github.com/oceanbase/ob-operator/internal/resource/obzone"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	"github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 )
 
 // OBZoneReconciler reconciles a OBZone object
@@ -62,6 +63,7 @@ func (r *OBZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	err := r.Client.Get(ctx, req.NamespacedName, obzone)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
+			coordinator.CleanMetrics("OBZone", req.NamespacedName)
 			// obzone not found, just return
 			return ctrl.Result{}, nil
 		}
@@ -79,7 +81,7 @@ func (r *OBZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		Logger:   &logger,
 		Recorder: telemetry.NewRecorder(ctx, r.Recorder),
 	}
-	coordinator := coordinator.NewCoordinator(obzoneManager, &logger)
+	coordinator := coordinator.NewCoordinator(obzoneManager, &logger).WithMetrics("OBZone", req.NamespacedName).WithPriority(priority.High)
 	return coordinator.Coordinate()
 }
 
@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+const (
+	// TokenPrefix makes API tokens recognizable, e.g. by secret scanners
+	TokenPrefix = "obd_"
+	// DefaultSyncInterval is the longest time for a replica to notice tokens created or revoked by other replicas
+	DefaultSyncInterval = 5 * time.Second
+	// ContextKeyAPIToken is the key of API token in context of requests authenticated with it
+	ContextKeyAPIToken = "apiToken"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid API token")
+	ErrTokenExpired = errors.New("API token is expired")
+	ErrNotFound     = errors.New("API token not found")
+)
+
+// APIToken is a long-lived credential of automation, which is granted a role binding within that of its owner
+type APIToken struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+	// Scope of the token, checked by the authorization middleware as role binding of users
+	Scope     rbac.RoleBinding `json:"scope"`
+	CreatedAt int64            `json:"createdAt"`
+	// Unix time after which the token is rejected, 0 means never
+	ExpiresAt int64 `json:"expiresAt"`
+	// Hex encoded SHA-256 of the secret part of the token, the token itself is never stored
+	SecretHash string `json:"secretHash"`
+}
+
+// Expired tells whether the token is expired
+func (t *APIToken) Expired() bool {
+	return t.ExpiresAt > 0 && time.Now().Unix() > t.ExpiresAt
+}
+
+// Enabled tells whether API tokens are enabled
+func Enabled() bool {
+	return os.Getenv("API_TOKENS_SECRET") != ""
+}
+
+// Store keeps API tokens in a secret, data of which are token IDs mapped to tokens in JSON.
+// Tokens are cached and synced at most once in syncInterval.
+type Store struct {
+	clientset    kubernetes.Interface
+	namespace    string
+	name         string
+	syncInterval time.Duration
+
+	mu       sync.Mutex
+	tokens   map[string]*APIToken
+	syncedAt time.Time
+}
+
+// NewStore returns a store of API tokens in the secret
+func NewStore(clientset kubernetes.Interface, namespace, name string, syncInterval time.Duration) *Store {
+	return &Store{
+		clientset:    clientset,
+		namespace:    namespace,
+		name:         name,
+		syncInterval: syncInterval,
+		tokens:       make(map[string]*APIToken),
+	}
+}
+
+var store *Store
+var storeOnce sync.Once
+
+// GetStore returns the store in the secret named by env API_TOKENS_SECRET in USER_NAMESPACE
+func GetStore() (*Store, error) {
+	if !Enabled() {
+		return nil, errors.New("API tokens are not enabled")
+	}
+	ns := os.Getenv("USER_NAMESPACE")
+	if ns == "" {
+		return nil, errors.New("env USER_NAMESPACE is not set")
+	}
+	storeOnce.Do(func() {
+		store = NewStore(client.GetClient().ClientSet, ns, os.Getenv("API_TOKENS_SECRET"), DefaultSyncInterval)
+	})
+	return store, nil
+}
+
+// Create creates a token of the owner with the scope, it returns the token record and the token string,
+// which is shown only once
+func (s *Store) Create(ctx context.Context, owner, name string, scope *rbac.RoleBinding, ttl time.Duration) (*APIToken, string, error) {
+	idBytes := make([]byte, 8)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, "", err
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", err
+	}
+	secret := hex.EncodeToString(secretBytes)
+	now := time.Now()
+	token := &APIToken{
+		ID:    hex.EncodeToString(idBytes),
+		Name:  name,
+		Owner: owner,
+		Scope: rbac.RoleBinding{
+			Role:       scope.Role,
+			Namespaces: append([]string{}, scope.Namespaces...),
+		},
+		CreatedAt:  now.Unix(),
+		SecretHash: hashSecret(secret),
+	}
+	if ttl > 0 {
+		token.ExpiresAt = now.Add(ttl).Unix()
+	}
+	err := s.update(ctx, func(tokens map[string]*APIToken) error {
+		tokens[token.ID] = token
+		return nil
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "create API token")
+	}
+	return token, TokenPrefix + token.ID + "." + secret, nil
+}
+
+// Delete revokes the token
+func (s *Store) Delete(ctx context.Context, id string) error {
+	return s.update(ctx, func(tokens map[string]*APIToken) error {
+		if _, exist := tokens[id]; !exist {
+			return ErrNotFound
+		}
+		delete(tokens, id)
+		return nil
+	})
+}
+
+// Get returns the token of the ID
+func (s *Store) Get(ctx context.Context, id string) (*APIToken, error) {
+	tokens, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, exist := tokens[id]
+	if !exist {
+		return nil, ErrNotFound
+	}
+	return token, nil
+}
+
+// List lists tokens ordered by creation time, tokens of all owners are listed if owner is empty
+func (s *Store) List(ctx context.Context, owner string) ([]*APIToken, error) {
+	tokens, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		if owner == "" || token.Owner == owner {
+			list = append(list, token)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CreatedAt != list[j].CreatedAt {
+			return list[i].CreatedAt < list[j].CreatedAt
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// Authenticate returns the token matching the token string
+func (s *Store) Authenticate(ctx context.Context, raw string) (*APIToken, error) {
+	id, secret, ok := strings.Cut(strings.TrimPrefix(raw, TokenPrefix), ".")
+	if !ok || !strings.HasPrefix(raw, TokenPrefix) || id == "" || secret == "" {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(token.SecretHash), []byte(hashSecret(secret))) != 1 {
+		return nil, ErrInvalidToken
+	}
+	if token.Expired() {
+		return nil, ErrTokenExpired
+	}
+	return token, nil
+}
+
+func (s *Store) load(ctx context.Context) (map[string]*APIToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.syncedAt) >= s.syncInterval {
+		secret, err := s.clientset.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		switch {
+		case kubeerrors.IsNotFound(err):
+			s.tokens = make(map[string]*APIToken)
+		case err != nil:
+			return nil, errors.Wrap(err, "get API tokens")
+		default:
+			s.tokens = parseTokens(secret.Data)
+		}
+		s.syncedAt = time.Now()
+	}
+	return s.tokens, nil
+}
+
+func (s *Store) update(ctx context.Context, mutate func(tokens map[string]*APIToken) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var tokens map[string]*APIToken
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return kubeerrors.IsConflict(err) || kubeerrors.IsAlreadyExists(err)
+	}, func() error {
+		secret, err := s.clientset.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		notFound := kubeerrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		if notFound {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.name,
+					Namespace: s.namespace,
+				},
+			}
+		}
+		tokens = parseTokens(secret.Data)
+		if err := mutate(tokens); err != nil {
+			return err
+		}
+		secret.Data = make(map[string][]byte, len(tokens))
+		for id, token := range tokens {
+			raw, err := json.Marshal(token)
+			if err != nil {
+				return err
+			}
+			secret.Data[id] = raw
+		}
+		if notFound {
+			_, err = s.clientset.CoreV1().Secrets(s.namespace).Create(ctx, secret, metav1.CreateOptions{})
+		} else {
+			_, err = s.clientset.CoreV1().Secrets(s.namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.tokens = tokens
+	s.syncedAt = time.Now()
+	return nil
+}
+
+func parseTokens(data map[string][]byte) map[string]*APIToken {
+	tokens := make(map[string]*APIToken, len(data))
+	for id, raw := range data {
+		token := &APIToken{}
+		if err := json.Unmarshal(raw, token); err != nil || token.ID != id {
+			continue
+		}
+		tokens[id] = token
+	}
+	return tokens
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package apitoken
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+)
+
+var _ = Describe("Store", func() {
+	var clientset *fake.Clientset
+	var store *Store
+	scope := &rbac.RoleBinding{Role: rbac.RoleOperator, Namespaces: []string{"ns1"}}
+
+	BeforeEach(func() {
+		clientset = fake.NewSimpleClientset()
+		store = NewStore(clientset, "default", "api-tokens", 0)
+	})
+
+	It("Creates and authenticates tokens", func() {
+		token, raw, err := store.Create(context.TODO(), "alice", "ci", scope, 0)
+		Expect(err).To(BeNil())
+		Expect(strings.HasPrefix(raw, TokenPrefix)).To(BeTrue())
+		Expect(token.ExpiresAt).To(BeZero())
+
+		secret, err := clientset.CoreV1().Secrets("default").Get(context.TODO(), "api-tokens", metav1.GetOptions{})
+		Expect(err).To(BeNil())
+		Expect(secret.Data).To(HaveKey(token.ID))
+		Expect(string(secret.Data[token.ID])).NotTo(ContainSubstring(strings.Split(raw, ".")[1]))
+
+		authenticated, err := store.Authenticate(context.TODO(), raw)
+		Expect(err).To(BeNil())
+		Expect(authenticated.Owner).To(Equal("alice"))
+		Expect(authenticated.Scope.Role).To(Equal(rbac.RoleOperator))
+		Expect(authenticated.Scope.Granted("ns1")).To(BeTrue())
+		Expect(authenticated.Scope.Granted("ns2")).To(BeFalse())
+
+		_, err = store.Authenticate(context.TODO(), raw+"0")
+		Expect(err).To(Equal(ErrInvalidToken))
+		_, err = store.Authenticate(context.TODO(), strings.TrimPrefix(raw, TokenPrefix))
+		Expect(err).To(Equal(ErrInvalidToken))
+		_, err = store.Authenticate(context.TODO(), TokenPrefix+"unknown.secret")
+		Expect(err).To(Equal(ErrInvalidToken))
+	})
+
+	It("Rejects expired tokens", func() {
+		token, raw, err := store.Create(context.TODO(), "alice", "ci", scope, time.Hour)
+		Expect(err).To(BeNil())
+		Expect(token.ExpiresAt).To(BeNumerically(">", time.Now().Unix()))
+		_, err = store.Authenticate(context.TODO(), raw)
+		Expect(err).To(BeNil())
+
+		token.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+		Expect(store.update(context.TODO(), func(tokens map[string]*APIToken) error {
+			tokens[token.ID] = token
+			return nil
+		})).To(Succeed())
+		_, err = store.Authenticate(context.TODO(), raw)
+		Expect(err).To(Equal(ErrTokenExpired))
+	})
+
+	It("Lists and revokes tokens among replicas", func() {
+		replica := NewStore(clientset, "default", "api-tokens", 0)
+		_, raw, err := store.Create(context.TODO(), "alice", "ci", scope, 0)
+		Expect(err).To(BeNil())
+		_, _, err = replica.Create(context.TODO(), "bob", "backup", scope, 0)
+		Expect(err).To(BeNil())
+
+		tokens, err := replica.List(context.TODO(), "")
+		Expect(err).To(BeNil())
+		Expect(tokens).To(HaveLen(2))
+		tokens, err = replica.List(context.TODO(), "alice")
+		Expect(err).To(BeNil())
+		Expect(tokens).To(HaveLen(1))
+		Expect(tokens[0].Name).To(Equal("ci"))
+
+		authenticated, err := replica.Authenticate(context.TODO(), raw)
+		Expect(err).To(BeNil())
+		Expect(replica.Delete(context.TODO(), authenticated.ID)).To(Succeed())
+		_, err = store.Authenticate(context.TODO(), raw)
+		Expect(err).To(Equal(ErrInvalidToken))
+		Expect(store.Delete(context.TODO(), authenticated.ID)).To(Equal(ErrNotFound))
+	})
+})
+
+var _ = Describe("Owners", func() {
+	It("Tells whether owners still exist", func() {
+		clientset := fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user-credentials"},
+			Data:       map[string][]byte{"alice": []byte("password")},
+		})
+		owners := NewOwners(clientset, "default", "user-credentials", 0)
+		exist, err := owners.Exists(context.TODO(), "alice")
+		Expect(err).To(BeNil())
+		Expect(exist).To(BeTrue())
+
+		Expect(clientset.CoreV1().Secrets("default").Update(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user-credentials"},
+			Data:       map[string][]byte{"bob": []byte("password")},
+		}, metav1.UpdateOptions{})).Error().To(BeNil())
+		exist, err = owners.Exists(context.TODO(), "alice")
+		Expect(err).To(BeNil())
+		Expect(exist).To(BeFalse())
+	})
+
+	It("Rejects users logged in with SSO as owners", func() {
+		Expect(CheckOwner("alice")).To(Succeed())
+		Expect(CheckOwner("oidc:https://idp.local/0001")).To(Equal(ErrOwnerSSO))
+		_, err := ResolveScope(context.TODO(), &APIToken{Owner: "oidc:https://idp.local/0001"})
+		Expect(err).To(Equal(ErrOwnerSSO))
+	})
+})
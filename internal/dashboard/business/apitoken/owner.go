@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package apitoken
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/oidc"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+var (
+	ErrOwnerNotFound = errors.New("owner of API token no longer exists")
+	ErrOwnerSSO      = errors.New("API tokens are not available to users logged in with SSO, whose roles are only known at login")
+)
+
+// Owners tells whether owners of tokens, who are password users in the credentials secret, still exist.
+// Users are cached and synced at most once in syncInterval.
+type Owners struct {
+	clientset    kubernetes.Interface
+	namespace    string
+	name         string
+	syncInterval time.Duration
+
+	mu       sync.Mutex
+	users    map[string]struct{}
+	syncedAt time.Time
+}
+
+// NewOwners returns owners of tokens in the credentials secret
+func NewOwners(clientset kubernetes.Interface, namespace, name string, syncInterval time.Duration) *Owners {
+	return &Owners{
+		clientset:    clientset,
+		namespace:    namespace,
+		name:         name,
+		syncInterval: syncInterval,
+	}
+}
+
+var owners *Owners
+var ownersOnce sync.Once
+
+// GetOwners returns owners in the secret named by env USER_CREDENTIALS_SECRET in USER_NAMESPACE
+func GetOwners() (*Owners, error) {
+	ns := os.Getenv("USER_NAMESPACE")
+	name := os.Getenv("USER_CREDENTIALS_SECRET")
+	if ns == "" || name == "" {
+		return nil, errors.New("env USER_NAMESPACE and USER_CREDENTIALS_SECRET must be set")
+	}
+	ownersOnce.Do(func() {
+		owners = NewOwners(client.GetClient().ClientSet, ns, name, DefaultSyncInterval)
+	})
+	return owners, nil
+}
+
+// Exists tells whether the owner is still a user
+func (o *Owners) Exists(ctx context.Context, owner string) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.users == nil || time.Since(o.syncedAt) >= o.syncInterval {
+		secret, err := o.clientset.CoreV1().Secrets(o.namespace).Get(ctx, o.name, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.Wrap(err, "get user credentials")
+		}
+		o.users = make(map[string]struct{}, len(secret.Data))
+		for username := range secret.Data {
+			o.users[username] = struct{}{}
+		}
+		o.syncedAt = time.Now()
+	}
+	_, exist := o.users[owner]
+	return exist, nil
+}
+
+// CheckOwner checks that the user is able to own tokens, roles of whom are resolved every time tokens are used
+func CheckOwner(owner string) error {
+	if strings.HasPrefix(owner, oidc.UsernamePrefix) {
+		return ErrOwnerSSO
+	}
+	return nil
+}
+
+// ResolveScope returns permissions of the token at present, which are those in both scope of the token and current
+// role binding of its owner, so that tokens never outlive permissions of their owners. It returns nil if nothing is
+// permitted, and ErrOwnerNotFound if the owner no longer exists.
+func ResolveScope(ctx context.Context, token *APIToken) (*rbac.RoleBinding, error) {
+	if err := CheckOwner(token.Owner); err != nil {
+		return nil, err
+	}
+	o, err := GetOwners()
+	if err != nil {
+		return nil, err
+	}
+	exist, err := o.Exists(ctx, token.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, ErrOwnerNotFound
+	}
+	binding, err := rbac.GetRoleBinding(ctx, token.Owner)
+	if err != nil || binding == nil {
+		return nil, err
+	}
+	return binding.Intersect(&token.Scope), nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+)
+
+const (
+	DefaultFilename = "log/audit.log"
+	// Redacted replaces values of sensitive fields in request bodies
+	Redacted = "******"
+	// MaxBodySize is the max size of request bodies recorded, larger ones are replaced by their sizes
+	MaxBodySize = 64 * 1024
+	// scanChunkSize is the size of chunks in which audit files are read backward
+	scanChunkSize = 64 * 1024
+)
+
+// sensitiveKeys are substrings of lowercase field names whose values are redacted
+var sensitiveKeys = []string{"password", "secret", "token", "credential", "accessid", "accesskey", "privatekey"}
+
+// Redact redacts values of sensitive fields in the JSON body, non-JSON bodies are replaced by their size
+func Redact(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var data any
+	if len(body) > MaxBodySize || json.Unmarshal(body, &data) != nil {
+		raw, _ := json.Marshal(map[string]int{"unrecordedBytes": len(body)})
+		return raw
+	}
+	raw, err := json.Marshal(redact(data))
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func redact(data any) any {
+	switch data := data.(type) {
+	case map[string]any:
+		for key, value := range data {
+			if isSensitive(key) {
+				data[key] = Redacted
+			} else {
+				data[key] = redact(value)
+			}
+		}
+	case []any:
+		for i := range data {
+			data[i] = redact(data[i])
+		}
+	}
+	return data
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter of audit records, empty fields match all
+type Filter struct {
+	Username  string
+	Namespace string
+	// Visible tells whether the record is visible to the querying user
+	Visible func(record *response.AuditRecord) bool
+}
+
+func (f *Filter) match(record *response.AuditRecord) bool {
+	if f.Username != "" && record.Username != f.Username {
+		return false
+	}
+	if f.Namespace != "" && record.Namespace != f.Namespace {
+		return false
+	}
+	return f.Visible == nil || f.Visible(record)
+}
+
+// Logger writes audit records as JSON lines to a file rotated by size
+type Logger struct {
+	mu     sync.Mutex
+	writer *lumberjack.Logger
+}
+
+// NewLogger returns a logger writing to the file, at most maxBackups rotated files of maxSize megabytes are kept
+func NewLogger(filename string, maxSize, maxBackups int) *Logger {
+	return &Logger{
+		writer: &lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			LocalTime:  true,
+		},
+	}
+}
+
+var logger *Logger
+var loggerOnce sync.Once
+
+// GetLogger returns the logger writing to the file named by env AUDIT_LOG_FILE
+func GetLogger() *Logger {
+	loggerOnce.Do(func() {
+		filename := os.Getenv("AUDIT_LOG_FILE")
+		if filename == "" {
+			filename = DefaultFilename
+		}
+		logger = NewLogger(filename, 100, 10)
+	})
+	return logger
+}
+
+// Write appends the record to the file
+func (l *Logger) Write(record *response.AuditRecord) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.writer.Write(append(raw, '\n'))
+	return err
+}
+
+// Query returns records matching the filter in the page, which starts from 1, newest records come first.
+// Files are read backward until the page is filled, it also tells whether there are more records after the page.
+func (l *Logger) Query(filter *Filter, page, size int) ([]response.AuditRecord, bool, error) {
+	files, err := l.files()
+	if err != nil {
+		return nil, false, err
+	}
+	skip := (page - 1) * size
+	records := make([]response.AuditRecord, 0, size)
+	hasMore := false
+	collect := func(record *response.AuditRecord) bool {
+		switch {
+		case skip > 0:
+			skip--
+		case len(records) < size:
+			records = append(records, *record)
+		default:
+			hasMore = true
+			return false
+		}
+		return true
+	}
+	for _, file := range files {
+		err := readRecordsBackward(file, filter, collect)
+		if err != nil {
+			return nil, false, err
+		}
+		if hasMore {
+			break
+		}
+	}
+	return records, hasMore, nil
+}
+
+// files returns the current file and rotated files from newest to oldest
+func (l *Logger) files() ([]string, error) {
+	filename := l.writer.Filename
+	ext := filepath.Ext(filename)
+	backups, err := filepath.Glob(strings.TrimSuffix(filename, ext) + "-*" + ext)
+	if err != nil {
+		return nil, errors.Wrap(err, "list rotated audit files")
+	}
+	// names of rotated files end with timestamps of rotation
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return append([]string{filename}, backups...), nil
+}
+
+// readRecordsBackward calls collect with records matching the filter from the newest to the oldest in the file,
+// until collect returns false
+func readRecordsBackward(filename string, filter *Filter, collect func(record *response.AuditRecord) bool) error {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "open audit file")
+	}
+	defer f.Close()
+	err = scanLinesBackward(f, func(line []byte) bool {
+		record := response.AuditRecord{}
+		// skip lines being written or broken
+		if err := json.Unmarshal(line, &record); err != nil {
+			return true
+		}
+		if !filter.match(&record) {
+			return true
+		}
+		return collect(&record)
+	})
+	if err != nil {
+		return errors.Wrap(err, "read audit file")
+	}
+	return nil
+}
+
+// scanLinesBackward calls fn with non-empty lines of the file from the last to the first until fn returns false,
+// the file is read in chunks from its end
+func scanLinesBackward(f *os.File, fn func(line []byte) bool) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	chunk := make([]byte, scanChunkSize)
+	// beginning of the line whose start is not read yet
+	var rest []byte
+	for offset > 0 {
+		n := int64(len(chunk))
+		if offset < n {
+			n = offset
+		}
+		offset -= n
+		if _, err := f.ReadAt(chunk[:n], offset); err != nil && err != io.EOF {
+			return err
+		}
+		data := append(chunk[:n:n], rest...)
+		for i := bytes.LastIndexByte(data, '\n'); i >= 0; i = bytes.LastIndexByte(data, '\n') {
+			if line := data[i+1:]; len(line) > 0 && !fn(line) {
+				return nil
+			}
+			data = data[:i]
+		}
+		rest = append([]byte(nil), data...)
+	}
+	if len(rest) > 0 {
+		fn(rest)
+	}
+	return nil
+}
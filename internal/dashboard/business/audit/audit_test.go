@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+)
+
+var _ = Describe("Audit", func() {
+	It("Redacts sensitive fields in request body", func() {
+		raw := Redact([]byte(`{
+			"name": "t1",
+			"rootPassword": "pwd",
+			"connectWhite": ["%"],
+			"backup": {"ossAccessId": "id", "ossAccessKey": "key", "destType": "OSS"},
+			"users": [{"user": "u1", "password": "p1"}]
+		}`))
+		data := map[string]any{}
+		Expect(json.Unmarshal(raw, &data)).To(Succeed())
+		Expect(data["name"]).To(Equal("t1"))
+		Expect(data["rootPassword"]).To(Equal(Redacted))
+		Expect(data["backup"]).To(HaveKeyWithValue("ossAccessId", Redacted))
+		Expect(data["backup"]).To(HaveKeyWithValue("ossAccessKey", Redacted))
+		Expect(data["backup"]).To(HaveKeyWithValue("destType", "OSS"))
+		Expect(data["users"].([]any)[0]).To(HaveKeyWithValue("password", Redacted))
+		Expect(string(raw)).NotTo(ContainSubstring("pwd"))
+
+		Expect(Redact(nil)).To(BeNil())
+		Expect(string(Redact([]byte("password=pwd")))).To(Equal(`{"unrecordedBytes":12}`))
+	})
+
+	It("Queries records by page from rotated files", func() {
+		dir, err := os.MkdirTemp("", "audit")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		filename := filepath.Join(dir, "audit.log")
+		logger := NewLogger(filename, 1, 3)
+
+		// rotated files of lumberjack are named with timestamps of rotation
+		backup := []string{}
+		for i := 0; i < 3; i++ {
+			line, _ := json.Marshal(&response.AuditRecord{Time: int64(i), Username: "alice", Namespace: "ns1"})
+			backup = append(backup, string(line))
+		}
+		Expect(os.WriteFile(filepath.Join(dir, "audit-2024-01-01T00-00-00.000.log"), []byte(strings.Join(backup, "\n")+"\n"), 0644)).To(Succeed())
+		for i := 3; i < 6; i++ {
+			ns := "ns1"
+			if i%2 == 0 {
+				ns = "ns2"
+			}
+			Expect(logger.Write(&response.AuditRecord{Time: int64(i), Username: fmt.Sprintf("user%d", i), Namespace: ns})).To(Succeed())
+		}
+
+		records, hasMore, err := logger.Query(&Filter{}, 1, 4)
+		Expect(err).To(BeNil())
+		Expect(hasMore).To(BeTrue())
+		Expect(records).To(HaveLen(4))
+		Expect(records[0].Time).To(Equal(int64(5)))
+		Expect(records[3].Time).To(Equal(int64(2)))
+
+		records, hasMore, err = logger.Query(&Filter{}, 2, 4)
+		Expect(err).To(BeNil())
+		Expect(hasMore).To(BeFalse())
+		Expect(records).To(HaveLen(2))
+		Expect(records[1].Time).To(Equal(int64(0)))
+
+		records, hasMore, err = logger.Query(&Filter{}, 2, 3)
+		Expect(err).To(BeNil())
+		Expect(hasMore).To(BeFalse())
+		Expect(records).To(HaveLen(3))
+
+		records, hasMore, err = logger.Query(&Filter{Username: "alice"}, 1, 10)
+		Expect(err).To(BeNil())
+		Expect(hasMore).To(BeFalse())
+		Expect(records).To(HaveLen(3))
+
+		records, _, err = logger.Query(&Filter{
+			Visible: func(record *response.AuditRecord) bool { return record.Namespace == "ns2" },
+		}, 1, 10)
+		Expect(err).To(BeNil())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Time).To(Equal(int64(4)))
+	})
+
+	It("Reads records backward across chunks", func() {
+		dir, err := os.MkdirTemp("", "audit")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		logger := NewLogger(filepath.Join(dir, "audit.log"), 100, 3)
+		count := 3 * scanChunkSize / 100
+		for i := 0; i < count; i++ {
+			Expect(logger.Write(&response.AuditRecord{Time: int64(i), Username: "alice", Path: strings.Repeat("p", i%50)})).To(Succeed())
+		}
+		// line being written is skipped
+		f, err := os.OpenFile(filepath.Join(dir, "audit.log"), os.O_APPEND|os.O_WRONLY, 0644)
+		Expect(err).To(BeNil())
+		_, err = f.WriteString(`{"time":`)
+		Expect(err).To(BeNil())
+		Expect(f.Close()).To(Succeed())
+
+		records, hasMore, err := logger.Query(&Filter{}, 1, count)
+		Expect(err).To(BeNil())
+		Expect(hasMore).To(BeFalse())
+		Expect(records).To(HaveLen(count))
+		for i, record := range records {
+			Expect(record.Time).To(Equal(int64(count - 1 - i)))
+		}
+	})
+
+	It("Emits events of affected objects", func() {
+		record := &response.AuditRecord{
+			Username:   "alice",
+			Method:     "DELETE",
+			Path:       "/api/v1/obtenants/ns1/t1",
+			Kind:       "OBTenant",
+			StatusCode: 500,
+			Message:    "internal error",
+		}
+		object := &metav1.ObjectMeta{Namespace: "ns1", Name: "t1", UID: "uid"}
+		event := NewEvent(record, object, "oceanbase.oceanbase.com/v1alpha1")
+		Expect(event.Namespace).To(Equal("ns1"))
+		Expect(event.Type).To(Equal("Warning"))
+		Expect(event.InvolvedObject.Kind).To(Equal("OBTenant"))
+		Expect(string(event.InvolvedObject.UID)).To(Equal("uid"))
+		Expect(event.Message).To(ContainSubstring("alice"))
+		Expect(event.Message).To(ContainSubstring("internal error"))
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+)
+
+const (
+	EventReason    = "DashboardRequest"
+	EventComponent = "oceanbase-dashboard"
+)
+
+// EventsEnabled tells whether audit records are also emitted as events of affected objects
+func EventsEnabled() bool {
+	return os.Getenv("AUDIT_EMIT_EVENTS") == "true"
+}
+
+// NewEvent returns an event of the affected object recording the request
+func NewEvent(record *response.AuditRecord, object metav1.Object, apiVersion string) *corev1.Event {
+	eventType := corev1.EventTypeNormal
+	if !record.Successful {
+		eventType = corev1.EventTypeWarning
+	}
+	message := fmt.Sprintf("%s %s by user %s: %d", record.Method, record.Path, record.Username, record.StatusCode)
+	if record.APITokenID != "" {
+		message = fmt.Sprintf("%s %s by API token %s of user %s: %d", record.Method, record.Path, record.APITokenID, record.Username, record.StatusCode)
+	}
+	if record.Message != "" {
+		message += ", " + record.Message
+	}
+	now := metav1.NewTime(time.Now())
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", object.GetName(), time.Now().UnixNano()),
+			Namespace: object.GetNamespace(),
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      apiVersion,
+			Kind:            record.Kind,
+			Namespace:       object.GetNamespace(),
+			Name:            object.GetName(),
+			UID:             object.GetUID(),
+			ResourceVersion: object.GetResourceVersion(),
+		},
+		Reason:         EventReason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: EventComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
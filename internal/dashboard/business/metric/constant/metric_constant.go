@@ -25,6 +25,11 @@ const (
 	KeyGroupLabels = "@GBLABELS"
 )
 
+const (
+	// LabelNamespace is the label of namespace of scraped pods, attached by relabeling in prometheus config
+	LabelNamespace = "namespace"
+)
+
 const (
 	PrometheusAddress   = "http://127.0.0.1:9090"
 	MetricRangeQueryUrl = "/api/v1/query_range"
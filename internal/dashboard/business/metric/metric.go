@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -80,10 +81,36 @@ func ListMetricClasses(scope, language string) ([]response.MetricClass, error) {
 	return metricClasses, err
 }
 
-func replaceQueryVariables(exprTemplate string, labels []common.KVPair, groupLabels []string, step int64) string {
-	labelStrParts := make([]string, 0, len(labels))
+// labelNamePattern is the syntax of label names, which are put into expressions as they are
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateQuery checks names of labels in the query
+func ValidateQuery(queryParam *param.MetricQuery) error {
+	for _, label := range queryParam.Labels {
+		if !labelNamePattern.MatchString(label.Key) {
+			return errors.Errorf("invalid label name %q", label.Key)
+		}
+	}
+	for _, groupLabel := range queryParam.GroupLabels {
+		if !labelNamePattern.MatchString(groupLabel) {
+			return errors.Errorf("invalid group label name %q", groupLabel)
+		}
+	}
+	return nil
+}
+
+// replaceQueryVariables renders the expression template, series are limited to the namespaces unless they are nil
+func replaceQueryVariables(exprTemplate string, labels []common.KVPair, groupLabels []string, step int64, namespaces []string) string {
+	labelStrParts := make([]string, 0, len(labels)+1)
 	for _, label := range labels {
-		labelStrParts = append(labelStrParts, fmt.Sprintf("%s=\"%s\"", label.Key, label.Value))
+		labelStrParts = append(labelStrParts, fmt.Sprintf("%s=%s", label.Key, strconv.Quote(label.Value)))
+	}
+	if namespaces != nil {
+		quoted := make([]string, 0, len(namespaces))
+		for _, ns := range namespaces {
+			quoted = append(quoted, regexp.QuoteMeta(ns))
+		}
+		labelStrParts = append(labelStrParts, fmt.Sprintf("%s=~%s", metricconst.LabelNamespace, strconv.Quote(strings.Join(quoted, "|"))))
 	}
 	labelStr := strings.Join(labelStrParts, ",")
 	groupLabelStr := strings.Join(groupLabels, ",")
@@ -121,9 +148,13 @@ func extractMetricData(name string, resp *external.PrometheusQueryRangeResponse)
 	return metricDatas
 }
 
-func QueryMetricData(queryParam *param.MetricQuery) []response.MetricData {
+// QueryMetricData queries metrics of pods in the namespaces, nil namespaces stand for all namespaces
+func QueryMetricData(queryParam *param.MetricQuery, namespaces []string) []response.MetricData {
 	client := resty.New().SetTimeout(time.Duration(metricconst.DefaultMetricQueryTimeout * time.Second))
 	metricDatas := make([]response.MetricData, 0, len(queryParam.Metrics))
+	if namespaces != nil && len(namespaces) == 0 {
+		return metricDatas
+	}
 	wg := sync.WaitGroup{}
 	metricDataCh := make(chan []response.MetricData, len(queryParam.Metrics))
 	for _, metric := range queryParam.Metrics {
@@ -132,7 +163,7 @@ func QueryMetricData(queryParam *param.MetricQuery) []response.MetricData {
 			wg.Add(1)
 			go func(metric string, ch chan []response.MetricData) {
 				defer wg.Done()
-				expr := replaceQueryVariables(exprTemplate, queryParam.Labels, queryParam.GroupLabels, queryParam.QueryRange.Step)
+				expr := replaceQueryVariables(exprTemplate, queryParam.Labels, queryParam.GroupLabels, queryParam.QueryRange.Step, namespaces)
 				logger.Infof("query with expr: %s, range: %v", expr, queryParam.QueryRange)
 				queryRangeResp := &external.PrometheusQueryRangeResponse{}
 				resp, err := client.R().SetQueryParams(map[string]string{
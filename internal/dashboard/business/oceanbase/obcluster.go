@@ -587,7 +587,8 @@ func DeleteOBCluster(ctx context.Context, obclusterIdentity *param.K8sObjectIden
 	return oceanbase.DeleteOBCluster(ctx, obclusterIdentity.Namespace, obclusterIdentity.Name)
 }
 
-func GetOBClusterStatistic(ctx context.Context) ([]response.OBClusterStastistic, error) {
+// GetOBClusterStatistic counts obclusters in namespaces accepted by granted in each status
+func GetOBClusterStatistic(ctx context.Context, granted func(namespace string) bool) ([]response.OBClusterStastistic, error) {
 	statisticResult := make([]response.OBClusterStastistic, 0)
 	obclusterList, err := oceanbase.ListAllOBClusters(ctx)
 	if err != nil {
@@ -600,6 +601,9 @@ func GetOBClusterStatistic(ctx context.Context) ([]response.OBClusterStastistic,
 		failedCount    int
 	)
 	for _, obcluster := range obclusterList.Items {
+		if !granted(obcluster.Namespace) {
+			continue
+		}
 		switch getStatisticStatus(&obcluster) {
 		case StatusRunning:
 			runningCount++
@@ -504,9 +504,9 @@ func PatchTenant(ctx context.Context, nn types.NamespacedName, p *param.PatchTen
 	return buildDetailFromApiType(tenant), nil
 }
 
-// GetOBTenantStatistics returns the statistics of all tenants
+// GetOBTenantStatistics returns the statistics of tenants in namespaces accepted by granted
 // Including the number of tenants in four status: running, deleting, operating, failed
-func GetOBTenantStatistics(ctx context.Context) ([]response.OBTenantStatistic, error) {
+func GetOBTenantStatistics(ctx context.Context, granted func(namespace string) bool) ([]response.OBTenantStatistic, error) {
 	stats := []response.OBTenantStatistic{}
 	tenantList, err := oceanbase.ListAllOBTenants(ctx, v1.ListOptions{})
 	if err != nil {
@@ -514,6 +514,9 @@ func GetOBTenantStatistics(ctx context.Context) ([]response.OBTenantStatistic, e
 	}
 	var runningCount, deletingCount, operatingCount, failedCount int
 	for _, tenant := range tenantList.Items {
+		if !granted(tenant.Namespace) {
+			continue
+		}
 		switch tenant.Status.Status {
 		case tenantstatus.Running:
 			runningCount++
@@ -324,7 +324,7 @@ func CreateTenantBackupPolicy(ctx context.Context, nn types.NamespacedName, p *p
 		return nil, err
 	}
 
-	if p.DestType == "OSS" && p.OSSAccessID != "" && p.OSSAccessKey != "" {
+	if p.DestType != "NFS" && p.OSSAccessID != "" && p.OSSAccessKey != "" {
 		ossSecretName := nn.Name + "-backup-oss-secret-" + rand.String(6)
 		backupPolicy.Spec.LogArchive.Destination.OSSAccessSecret = ossSecretName
 		backupPolicy.Spec.DataBackup.Destination.OSSAccessSecret = ossSecretName
@@ -333,10 +333,7 @@ func CreateTenantBackupPolicy(ctx context.Context, nn types.NamespacedName, p *p
 				Name:      ossSecretName,
 				Namespace: nn.Namespace,
 			},
-			StringData: map[string]string{
-				"accessId":  p.OSSAccessID,
-				"accessKey": p.OSSAccessKey,
-			},
+			StringData: buildBackupAccessSecretData(p),
 		}
 		_, err := client.GetClient().ClientSet.CoreV1().Secrets(nn.Namespace).Create(ctx, secret, metav1.CreateOptions{})
 		if err != nil {
@@ -460,3 +457,23 @@ func ListBackupJobs(ctx context.Context, nn types.NamespacedName, jobType string
 	}
 	return res, nil
 }
+
+func buildBackupAccessSecretData(p *param.CreateBackupPolicy) map[string]string {
+	data := map[string]string{
+		"accessId":  p.OSSAccessID,
+		"accessKey": p.OSSAccessKey,
+	}
+	if p.Endpoint != "" {
+		data["endpoint"] = p.Endpoint
+	}
+	switch p.DestType {
+	case "COS":
+		data["appId"] = p.COSAppID
+	case "S3":
+		data["region"] = p.S3Region
+		if p.S3AddressingStyle != "" {
+			data["addressingStyle"] = p.S3AddressingStyle
+		}
+	}
+	return data
+}
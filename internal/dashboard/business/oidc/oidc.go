@@ -0,0 +1,332 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+const (
+	DefaultScopes        = "openid,profile,email"
+	DefaultUsernameClaim = "preferred_username"
+	DefaultGroupsClaim   = "groups"
+	DefaultSecretKey     = "clientSecret"
+	// UsernamePrefix prefixes usernames of users logged in with SSO, which never appears in names of password users
+	UsernamePrefix = "oidc:"
+)
+
+// Config of the OpenID Connect provider and the mapping from groups to dashboard roles
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// Claim of ID token used as display name, falls back to sub if absent
+	UsernameClaim string
+	// Claim of ID token listing groups of the user
+	GroupsClaim string
+	// Role bindings of groups, required unless AllowAllUsers is set
+	GroupRoles map[string]*rbac.RoleBinding
+	// Grant all users of the provider the admin role if no role binding of groups is configured
+	AllowAllUsers bool
+}
+
+// Identity of the user logged in with the provider
+type Identity struct {
+	// Username identifies the user in sessions, audit records and API tokens, see SSOUsername
+	Username string
+	// Name of the user in claim of UsernameClaim for display
+	Name   string
+	Groups []string
+}
+
+// SSOUsername namespaces the subject by its issuer, so that users of the provider are never taken as password users
+func SSOUsername(issuer, subject string) string {
+	return UsernamePrefix + issuer + "/" + subject
+}
+
+// Enabled tells whether SSO login is configured
+func Enabled() bool {
+	return os.Getenv("OIDC_ISSUER") != ""
+}
+
+// PasswordLoginDisabled tells whether users must login with SSO
+func PasswordLoginDisabled() bool {
+	return Enabled() && os.Getenv("OIDC_DISABLE_PASSWORD_LOGIN") == "true"
+}
+
+// LoadConfig loads config from environment variables, client secret is read from the secret named by
+// env OIDC_CLIENT_SECRET in USER_NAMESPACE
+func LoadConfig(ctx context.Context) (*Config, error) {
+	cfg := &Config{
+		Issuer:        os.Getenv("OIDC_ISSUER"),
+		ClientID:      os.Getenv("OIDC_CLIENT_ID"),
+		RedirectURL:   os.Getenv("OIDC_REDIRECT_URL"),
+		UsernameClaim: DefaultUsernameClaim,
+		GroupsClaim:   DefaultGroupsClaim,
+	}
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("env OIDC_ISSUER, OIDC_CLIENT_ID and OIDC_REDIRECT_URL must be set")
+	}
+	if claim := os.Getenv("OIDC_USERNAME_CLAIM"); claim != "" {
+		cfg.UsernameClaim = claim
+	}
+	if claim := os.Getenv("OIDC_GROUPS_CLAIM"); claim != "" {
+		cfg.GroupsClaim = claim
+	}
+	scopes := os.Getenv("OIDC_SCOPES")
+	if scopes == "" {
+		scopes = DefaultScopes
+	}
+	cfg.Scopes = ParseScopes(scopes)
+	groupRoles, err := ParseGroupRoles(os.Getenv("OIDC_GROUP_ROLES"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.AllowAllUsers = os.Getenv("OIDC_ALLOW_ALL_USERS") == "true"
+	if len(groupRoles) == 0 {
+		// users of the provider would be granted no role if roles of users are configured
+		if rbac.Enabled() {
+			return nil, errors.New("env OIDC_GROUP_ROLES must be set if roles of users are configured")
+		}
+		// every user of the provider would be an admin without role bindings of groups
+		if !cfg.AllowAllUsers {
+			return nil, errors.New("env OIDC_GROUP_ROLES must be set unless OIDC_ALLOW_ALL_USERS is true")
+		}
+	}
+	cfg.GroupRoles = groupRoles
+	if secretName := os.Getenv("OIDC_CLIENT_SECRET"); secretName != "" {
+		ns := os.Getenv("USER_NAMESPACE")
+		if ns == "" {
+			return nil, errors.New("env USER_NAMESPACE is not set")
+		}
+		secret, err := client.GetClient().ClientSet.CoreV1().Secrets(ns).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "get secret of OIDC client")
+		}
+		key := os.Getenv("OIDC_CLIENT_SECRET_KEY")
+		if key == "" {
+			key = DefaultSecretKey
+		}
+		raw, exist := secret.Data[key]
+		if !exist {
+			return nil, errors.Errorf("key %s not found in secret %s", key, secretName)
+		}
+		cfg.ClientSecret = string(raw)
+	}
+	return cfg, nil
+}
+
+// ParseScopes parses comma separated scopes, openid is always requested
+func ParseScopes(raw string) []string {
+	scopes := []string{"openid"}
+	for _, scope := range strings.Split(raw, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope != "" && scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// ParseGroupRoles parses role bindings of groups in JSON like {"dba": {"role": "admin", "namespaces": ["*"]}}
+func ParseGroupRoles(raw string) (map[string]*rbac.RoleBinding, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, errors.Wrap(err, "parse role bindings of groups")
+	}
+	groupRoles := make(map[string]*rbac.RoleBinding, len(data))
+	for group, rawBinding := range data {
+		binding, err := rbac.ParseRoleBinding(string(rawBinding))
+		if err != nil {
+			return nil, errors.Wrapf(err, "role binding of group %s", group)
+		}
+		groupRoles[group] = binding
+	}
+	return groupRoles, nil
+}
+
+// RoleBinding maps groups to a role binding. The highest role among bindings of the groups is granted
+// on namespaces of all bindings with that role. It returns nil if none of the groups is mapped.
+func (c *Config) RoleBinding(groups []string) *rbac.RoleBinding {
+	var merged *rbac.RoleBinding
+	for _, group := range groups {
+		binding, exist := c.GroupRoles[group]
+		if !exist {
+			continue
+		}
+		switch {
+		case merged == nil || binding.Role.Level() > merged.Role.Level():
+			merged = &rbac.RoleBinding{
+				Role:       binding.Role,
+				Namespaces: append([]string{}, binding.Namespaces...),
+			}
+		case binding.Role == merged.Role:
+			for _, ns := range binding.Namespaces {
+				if !containsString(merged.Namespaces, ns) {
+					merged.Namespaces = append(merged.Namespaces, ns)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// Provider logs users in with authorization code flow of an OpenID Connect provider
+type Provider struct {
+	config     *Config
+	oauth2     *oauth2.Config
+	verifier   *gooidc.IDTokenVerifier
+	httpClient *http.Client
+}
+
+// NewProvider discovers endpoints of the provider from its issuer, signing keys of the provider are fetched
+// and cached by the verifier of ID tokens
+func NewProvider(ctx context.Context, cfg *Config) (*Provider, error) {
+	p := &Provider{
+		config:     cfg,
+		httpClient: http.DefaultClient,
+	}
+	op, err := gooidc.NewProvider(gooidc.ClientContext(ctx, p.httpClient), cfg.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "discover OIDC provider")
+	}
+	p.verifier = op.Verifier(&gooidc.Config{ClientID: cfg.ClientID})
+	p.oauth2 = &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		Endpoint:     op.Endpoint(),
+	}
+	return p, nil
+}
+
+// Config returns config of the provider
+func (p *Provider) Config() *Config {
+	return p.config
+}
+
+// AuthCodeURL returns the URL of the provider to redirect users to
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth2.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce))
+}
+
+// Exchange exchanges the authorization code for ID token and returns identity of the user in it
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	ctx = gooidc.ClientContext(ctx, p.httpClient)
+	token, err := p.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, errors.Wrap(err, "exchange authorization code")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("no id_token in token response")
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "verify ID token")
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("nonce of ID token does not match")
+	}
+	if idToken.Subject == "" {
+		return nil, errors.New("no sub in ID token")
+	}
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.Wrap(err, "decode claims of ID token")
+	}
+	identity := &Identity{
+		Username: SSOUsername(p.config.Issuer, idToken.Subject),
+		Name:     idToken.Subject,
+	}
+	if name, ok := claims[p.config.UsernameClaim].(string); ok && name != "" {
+		identity.Name = name
+	}
+	switch groups := claims[p.config.GroupsClaim].(type) {
+	case string:
+		identity.Groups = []string{groups}
+	case []any:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, g)
+			}
+		}
+	}
+	return identity, nil
+}
+
+var (
+	provider   *Provider
+	providerMu sync.Mutex
+)
+
+// GetProvider returns the provider configured by environment variables, discovery is done only once on success
+func GetProvider(ctx context.Context) (*Provider, error) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if provider != nil {
+		return provider, nil
+	}
+	if !Enabled() {
+		return nil, errors.New("OIDC login is not enabled")
+	}
+	cfg, err := LoadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := NewProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	provider = p
+	return provider, nil
+}
+
+// NewState generates a random value used as state or nonce of authorization requests
+func NewState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
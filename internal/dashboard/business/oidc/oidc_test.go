@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+)
+
+// fakeProvider is a local stand-in of an OpenID Connect provider which issues ID tokens with claims set by tests
+type fakeProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// key signing issued tokens, key of the provider if nil
+	signingKey *rsa.PrivateKey
+	claims     map[string]any
+	// times keys of the provider are fetched
+	keysFetched int
+}
+
+func newFakeProvider() *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).To(BeNil())
+	p := &fakeProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		p.keysFetched++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.idToken(),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *fakeProvider) idToken() string {
+	signingKey := p.signingKey
+	if signingKey == nil {
+		signingKey = p.key
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, _ := json.Marshal(p.claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA256, digest[:])
+	Expect(err).To(BeNil())
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+var _ = Describe("OIDC", func() {
+	var fake *fakeProvider
+	var cfg *Config
+
+	BeforeEach(func() {
+		fake = newFakeProvider()
+		cfg = &Config{
+			Issuer:        fake.server.URL,
+			ClientID:      "dashboard",
+			ClientSecret:  "secret",
+			RedirectURL:   "http://dashboard.local/api/v1/login/oidc/callback",
+			Scopes:        ParseScopes(DefaultScopes),
+			UsernameClaim: DefaultUsernameClaim,
+			GroupsClaim:   DefaultGroupsClaim,
+		}
+		fake.claims = map[string]any{
+			"iss":                fake.server.URL,
+			"aud":                "dashboard",
+			"sub":                "0001",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"nonce":              "nonce",
+			"preferred_username": "alice",
+			"groups":             []string{"dev", "dba"},
+		}
+	})
+
+	AfterEach(func() {
+		fake.server.Close()
+	})
+
+	It("Discovers endpoints and builds authorization URL", func() {
+		p, err := NewProvider(context.TODO(), cfg)
+		Expect(err).To(BeNil())
+		authURL, err := url.Parse(p.AuthCodeURL("state", "nonce"))
+		Expect(err).To(BeNil())
+		Expect(authURL.Path).To(Equal("/authorize"))
+		Expect(authURL.Query().Get("state")).To(Equal("state"))
+		Expect(authURL.Query().Get("nonce")).To(Equal("nonce"))
+		Expect(authURL.Query().Get("client_id")).To(Equal("dashboard"))
+		Expect(authURL.Query().Get("scope")).To(Equal("openid profile email"))
+
+		cfg.Issuer = fake.server.URL + "/other"
+		_, err = NewProvider(context.TODO(), cfg)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("Exchanges authorization code for identity", func() {
+		p, err := NewProvider(context.TODO(), cfg)
+		Expect(err).To(BeNil())
+		identity, err := p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).To(BeNil())
+		Expect(identity.Username).To(Equal("oidc:" + fake.server.URL + "/0001"))
+		Expect(identity.Name).To(Equal("alice"))
+		Expect(identity.Groups).To(Equal([]string{"dev", "dba"}))
+
+		By("Keys of the provider are cached")
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).To(BeNil())
+		Expect(fake.keysFetched).To(Equal(1))
+
+		delete(fake.claims, "preferred_username")
+		identity, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).To(BeNil())
+		Expect(identity.Username).To(Equal("oidc:" + fake.server.URL + "/0001"))
+		Expect(identity.Name).To(Equal("0001"))
+
+		delete(fake.claims, "sub")
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+
+		_, err = p.Exchange(context.TODO(), "invalid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("Rejects invalid ID tokens", func() {
+		p, err := NewProvider(context.TODO(), cfg)
+		Expect(err).To(BeNil())
+
+		_, err = p.Exchange(context.TODO(), "valid-code", "other-nonce")
+		Expect(err).NotTo(BeNil())
+
+		fake.claims["aud"] = []string{"other-client"}
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+		fake.claims["aud"] = []string{"other-client", "dashboard"}
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).To(BeNil())
+
+		fake.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+		fake.claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+		fake.claims["iss"] = "https://other-issuer"
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+		fake.claims["iss"] = fake.server.URL
+
+		fake.signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+		_, err = p.Exchange(context.TODO(), "valid-code", "nonce")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("Maps groups to roles", func() {
+		groupRoles, err := ParseGroupRoles(`{
+			"dev": {"role": "viewer", "namespaces": ["*"]},
+			"dba": {"role": "operator", "namespaces": ["ns1"]},
+			"sre": {"role": "operator", "namespaces": ["ns2", "ns1"]}
+		}`)
+		Expect(err).To(BeNil())
+		cfg.GroupRoles = groupRoles
+
+		binding := cfg.RoleBinding([]string{"dev", "dba", "sre", "unknown"})
+		Expect(binding.Role).To(Equal(rbac.RoleOperator))
+		Expect(binding.Namespaces).To(Equal([]string{"ns1", "ns2"}))
+		Expect(cfg.GroupRoles["dba"].Namespaces).To(Equal([]string{"ns1"}))
+
+		binding = cfg.RoleBinding([]string{"dev"})
+		Expect(binding.Role).To(Equal(rbac.RoleViewer))
+		Expect(binding.Granted("any")).To(BeTrue())
+
+		Expect(cfg.RoleBinding([]string{"unknown"})).To(BeNil())
+
+		_, err = ParseGroupRoles(`{"dev": {"role": "root", "namespaces": ["*"]}}`)
+		Expect(err).NotTo(BeNil())
+		groupRoles, err = ParseGroupRoles("")
+		Expect(err).To(BeNil())
+		Expect(groupRoles).To(BeEmpty())
+	})
+
+	It("Always requests openid scope", func() {
+		Expect(ParseScopes("profile, groups")).To(Equal([]string{"openid", "profile", "groups"}))
+		Expect(ParseScopes("openid,email")).To(Equal([]string{"openid", "email"}))
+	})
+
+	It("Requires roles of groups unless all users are allowed", func() {
+		for key, value := range map[string]string{
+			"OIDC_ISSUER":          "https://idp.local",
+			"OIDC_CLIENT_ID":       "dashboard",
+			"OIDC_REDIRECT_URL":    "http://dashboard.local/api/v1/login/oidc/callback",
+			"USER_ROLES_CONFIGMAP": "user-roles",
+			"OIDC_GROUP_ROLES":     "",
+			"OIDC_ALLOW_ALL_USERS": "true",
+		} {
+			Expect(os.Setenv(key, value)).To(Succeed())
+			DeferCleanup(os.Unsetenv, key)
+		}
+		_, err := LoadConfig(context.TODO())
+		Expect(err).NotTo(BeNil())
+
+		Expect(os.Setenv("OIDC_GROUP_ROLES", `{"dba": {"role": "admin", "namespaces": ["*"]}}`)).To(Succeed())
+		cfg, err := LoadConfig(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(cfg.GroupRoles).To(HaveKey("dba"))
+
+		By("Roles of groups are required without roles of users unless all users are allowed")
+		Expect(os.Unsetenv("USER_ROLES_CONFIGMAP")).To(Succeed())
+		Expect(os.Unsetenv("OIDC_GROUP_ROLES")).To(Succeed())
+		cfg, err = LoadConfig(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(cfg.AllowAllUsers).To(BeTrue())
+
+		Expect(os.Unsetenv("OIDC_ALLOW_ALL_USERS")).To(Succeed())
+		_, err = LoadConfig(context.TODO())
+		Expect(err).NotTo(BeNil())
+	})
+})
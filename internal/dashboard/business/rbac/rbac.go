@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+type Role string
+
+const (
+	// Viewer can only read resources in granted namespaces
+	RoleViewer Role = "viewer"
+	// Operator can read and operate resources in granted namespaces
+	RoleOperator Role = "operator"
+	// Admin can do everything in granted namespaces, cluster scoped operations need all namespaces granted
+	RoleAdmin Role = "admin"
+)
+
+// Level of the role, a role includes permissions of roles at lower levels
+func (r Role) Level() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+type Action string
+
+const (
+	ActionRead  Action = "read"
+	ActionWrite Action = "write"
+	// Operations on cluster scoped resources, e.g. creating namespaces
+	ActionAdmin Action = "admin"
+)
+
+const (
+	// AllNamespaces in namespaces of a role binding grants every namespace
+	AllNamespaces = "*"
+	// ContextKeyRoleBinding is the key of role binding of the logged in user in request context
+	ContextKeyRoleBinding = "roleBinding"
+	// ContextKeyUsername is the key of name of the authenticated user in request context
+	ContextKeyUsername = "username"
+	// SessionKeyRoleBinding is the key of role binding in JSON stored in session of users logged in with SSO,
+	// which takes precedence over the configmap of user roles
+	SessionKeyRoleBinding = "roleBinding"
+)
+
+// RoleBinding grants a role on namespaces to a dashboard user
+type RoleBinding struct {
+	Role       Role     `json:"role"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// Permits tells whether the role of binding is allowed to take the action
+func (b *RoleBinding) Permits(action Action) bool {
+	switch action {
+	case ActionRead:
+		return b.Role == RoleViewer || b.Role == RoleOperator || b.Role == RoleAdmin
+	case ActionWrite:
+		return b.Role == RoleOperator || b.Role == RoleAdmin
+	case ActionAdmin:
+		return b.Role == RoleAdmin && b.Granted(AllNamespaces)
+	}
+	return false
+}
+
+// Granted tells whether resources in the namespace are accessible with the binding
+func (b *RoleBinding) Granted(namespace string) bool {
+	for _, ns := range b.Namespaces {
+		if ns == AllNamespaces || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers tells whether permissions of the other binding are all included in the binding
+func (b *RoleBinding) Covers(other *RoleBinding) bool {
+	if other.Role.Level() > b.Role.Level() {
+		return false
+	}
+	for _, ns := range other.Namespaces {
+		if !b.Granted(ns) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the binding of permissions included in both bindings, it returns nil if no namespace is left
+func (b *RoleBinding) Intersect(other *RoleBinding) *RoleBinding {
+	role := b.Role
+	if other.Role.Level() < role.Level() {
+		role = other.Role
+	}
+	var namespaces []string
+	switch {
+	case b.Granted(AllNamespaces):
+		namespaces = append(namespaces, other.Namespaces...)
+	case other.Granted(AllNamespaces):
+		namespaces = append(namespaces, b.Namespaces...)
+	default:
+		for _, ns := range b.Namespaces {
+			if other.Granted(ns) {
+				namespaces = append(namespaces, ns)
+			}
+		}
+	}
+	if len(namespaces) == 0 || role.Level() == 0 {
+		return nil
+	}
+	return &RoleBinding{Role: role, Namespaces: namespaces}
+}
+
+func (b *RoleBinding) validate() error {
+	switch b.Role {
+	case RoleViewer, RoleOperator, RoleAdmin:
+	default:
+		return errors.Errorf("unknown role %q", b.Role)
+	}
+	if len(b.Namespaces) == 0 {
+		return errors.New("no namespace is granted")
+	}
+	return nil
+}
+
+// Enabled tells whether roles of users are configured, every user is an admin of all namespaces otherwise
+func Enabled() bool {
+	return os.Getenv("USER_ROLES_CONFIGMAP") != ""
+}
+
+// ParseRoleBindings parses role bindings from data of the configmap, keys are usernames and values are bindings in JSON
+func ParseRoleBindings(data map[string]string) (map[string]*RoleBinding, error) {
+	bindings := make(map[string]*RoleBinding, len(data))
+	for username, raw := range data {
+		binding, err := ParseRoleBinding(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "role binding of user %s", username)
+		}
+		bindings[username] = binding
+	}
+	return bindings, nil
+}
+
+// ParseRoleBinding parses and validates a role binding in JSON
+func ParseRoleBinding(raw string) (*RoleBinding, error) {
+	binding := &RoleBinding{}
+	if err := json.Unmarshal([]byte(raw), binding); err != nil {
+		return nil, errors.Wrap(err, "parse role binding")
+	}
+	if err := binding.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid role binding")
+	}
+	return binding, nil
+}
+
+// DefaultRoleSyncInterval is the longest time for changes of user roles in the configmap to take effect
+const DefaultRoleSyncInterval = 5 * time.Second
+
+// roleBindingCache caches raw role bindings in the configmap, which is read at most once in syncInterval
+type roleBindingCache struct {
+	syncInterval time.Duration
+
+	mu       sync.Mutex
+	data     map[string]string
+	syncedAt time.Time
+}
+
+var roleBindings = &roleBindingCache{syncInterval: DefaultRoleSyncInterval}
+
+func (r *roleBindingCache) get(ctx context.Context, clientset kubernetes.Interface, namespace, name, username string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.data == nil || time.Since(r.syncedAt) >= r.syncInterval {
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", false, errors.Wrap(err, "get configmap of user roles")
+		}
+		r.data = cm.Data
+		if r.data == nil {
+			r.data = make(map[string]string)
+		}
+		r.syncedAt = time.Now()
+	}
+	raw, exist := r.data[username]
+	return raw, exist, nil
+}
+
+// GetRoleBinding returns role binding of the user from the configmap named by env USER_ROLES_CONFIGMAP in USER_NAMESPACE.
+// It returns nil if no role is granted to the user.
+func GetRoleBinding(ctx context.Context, username string) (*RoleBinding, error) {
+	if !Enabled() {
+		return &RoleBinding{Role: RoleAdmin, Namespaces: []string{AllNamespaces}}, nil
+	}
+	ns := os.Getenv("USER_NAMESPACE")
+	if ns == "" {
+		return nil, errors.New("env USER_NAMESPACE is not set")
+	}
+	raw, exist, err := roleBindings.get(ctx, client.GetClient().ClientSet, ns, os.Getenv("USER_ROLES_CONFIGMAP"), username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	return ParseRoleBinding(raw)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package rbac
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("RoleBinding", func() {
+	It("Permits actions by role", func() {
+		viewer := &RoleBinding{Role: RoleViewer, Namespaces: []string{"ns1"}}
+		Expect(viewer.Permits(ActionRead)).To(BeTrue())
+		Expect(viewer.Permits(ActionWrite)).To(BeFalse())
+		Expect(viewer.Permits(ActionAdmin)).To(BeFalse())
+
+		operator := &RoleBinding{Role: RoleOperator, Namespaces: []string{AllNamespaces}}
+		Expect(operator.Permits(ActionRead)).To(BeTrue())
+		Expect(operator.Permits(ActionWrite)).To(BeTrue())
+		Expect(operator.Permits(ActionAdmin)).To(BeFalse())
+
+		admin := &RoleBinding{Role: RoleAdmin, Namespaces: []string{"ns1"}}
+		Expect(admin.Permits(ActionWrite)).To(BeTrue())
+		Expect(admin.Permits(ActionAdmin)).To(BeFalse())
+		admin.Namespaces = []string{AllNamespaces}
+		Expect(admin.Permits(ActionAdmin)).To(BeTrue())
+
+		Expect((&RoleBinding{Role: "unknown"}).Permits(ActionRead)).To(BeFalse())
+	})
+
+	It("Grants namespaces", func() {
+		binding := &RoleBinding{Role: RoleViewer, Namespaces: []string{"ns1", "ns2"}}
+		Expect(binding.Granted("ns1")).To(BeTrue())
+		Expect(binding.Granted("ns3")).To(BeFalse())
+		binding.Namespaces = []string{AllNamespaces}
+		Expect(binding.Granted("ns3")).To(BeTrue())
+	})
+
+	It("Covers bindings with lower roles on granted namespaces", func() {
+		operator := &RoleBinding{Role: RoleOperator, Namespaces: []string{"ns1", "ns2"}}
+		Expect(operator.Covers(&RoleBinding{Role: RoleViewer, Namespaces: []string{"ns1"}})).To(BeTrue())
+		Expect(operator.Covers(&RoleBinding{Role: RoleOperator, Namespaces: []string{"ns1", "ns2"}})).To(BeTrue())
+		Expect(operator.Covers(&RoleBinding{Role: RoleAdmin, Namespaces: []string{"ns1"}})).To(BeFalse())
+		Expect(operator.Covers(&RoleBinding{Role: RoleViewer, Namespaces: []string{"ns3"}})).To(BeFalse())
+		Expect(operator.Covers(&RoleBinding{Role: RoleViewer, Namespaces: []string{AllNamespaces}})).To(BeFalse())
+
+		admin := &RoleBinding{Role: RoleAdmin, Namespaces: []string{AllNamespaces}}
+		Expect(admin.Covers(&RoleBinding{Role: RoleAdmin, Namespaces: []string{AllNamespaces}})).To(BeTrue())
+	})
+
+	It("Intersects bindings", func() {
+		admin := &RoleBinding{Role: RoleAdmin, Namespaces: []string{AllNamespaces}}
+		operator := &RoleBinding{Role: RoleOperator, Namespaces: []string{"ns1", "ns2"}}
+		viewer := &RoleBinding{Role: RoleViewer, Namespaces: []string{"ns2", "ns3"}}
+
+		Expect(admin.Intersect(operator)).To(Equal(operator))
+		Expect(operator.Intersect(admin)).To(Equal(operator))
+		Expect(operator.Intersect(viewer)).To(Equal(&RoleBinding{Role: RoleViewer, Namespaces: []string{"ns2"}}))
+		Expect(viewer.Intersect(operator)).To(Equal(&RoleBinding{Role: RoleViewer, Namespaces: []string{"ns2"}}))
+		Expect(operator.Intersect(&RoleBinding{Role: RoleAdmin, Namespaces: []string{"ns3"}})).To(BeNil())
+	})
+
+	It("Parses role bindings", func() {
+		bindings, err := ParseRoleBindings(map[string]string{
+			"alice": `{"role":"viewer","namespaces":["ns1"]}`,
+			"bob":   `{"role":"admin","namespaces":["*"]}`,
+		})
+		Expect(err).To(BeNil())
+		Expect(bindings).To(HaveLen(2))
+		Expect(bindings["alice"].Role).To(Equal(RoleViewer))
+		Expect(bindings["bob"].Permits(ActionAdmin)).To(BeTrue())
+
+		_, err = ParseRoleBindings(map[string]string{"alice": `{"role":"root","namespaces":["ns1"]}`})
+		Expect(err).NotTo(BeNil())
+		_, err = ParseRoleBindings(map[string]string{"alice": `{"role":"viewer"}`})
+		Expect(err).NotTo(BeNil())
+		_, err = ParseRoleBindings(map[string]string{"alice": `viewer`})
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("Grants admin of all namespaces if roles are not configured", func() {
+		Expect(os.Unsetenv("USER_ROLES_CONFIGMAP")).To(Succeed())
+		Expect(Enabled()).To(BeFalse())
+		binding, err := GetRoleBinding(context.TODO(), "anyone")
+		Expect(err).To(BeNil())
+		Expect(binding.Permits(ActionAdmin)).To(BeTrue())
+	})
+
+	It("Caches role bindings within sync interval", func() {
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user-roles"},
+			Data:       map[string]string{"alice": `{"role":"viewer","namespaces":["ns1"]}`},
+		})
+		cache := &roleBindingCache{syncInterval: time.Hour}
+		raw, exist, err := cache.get(context.TODO(), clientset, "default", "user-roles", "alice")
+		Expect(err).To(BeNil())
+		Expect(exist).To(BeTrue())
+		Expect(raw).To(ContainSubstring("viewer"))
+
+		Expect(clientset.CoreV1().ConfigMaps("default").Delete(context.TODO(), "user-roles", metav1.DeleteOptions{})).To(Succeed())
+		_, exist, err = cache.get(context.TODO(), clientset, "default", "user-roles", "alice")
+		Expect(err).To(BeNil())
+		Expect(exist).To(BeTrue())
+
+		cache.syncInterval = 0
+		_, _, err = cache.get(context.TODO(), clientset, "default", "user-roles", "alice")
+		Expect(err).NotTo(BeNil())
+	})
+})
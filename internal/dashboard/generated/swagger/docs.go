@@ -15,6 +15,97 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/audit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List audit records of mutating requests handled by this dashboard replica, newest first. Admins of all namespaces see all records, others see records in granted namespaces.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List audit records",
+                "operationId": "ListAuditRecords",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "namespace of affected object",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 20 by default and 100 at most",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.AuditRecordPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/cluster/events": {
             "get": {
                 "security": [
@@ -439,6 +530,94 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login/oidc": {
+            "get": {
+                "description": "Redirect user to the OpenID Connect provider to login.",
+                "tags": [
+                    "User"
+                ],
+                "summary": "SSO login",
+                "operationId": "OIDCLogin",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login/oidc/callback": {
+            "get": {
+                "description": "Exchange authorization code of the OpenID Connect provider, login the user and redirect to home page.",
+                "tags": [
+                    "User"
+                ],
+                "summary": "SSO login callback",
+                "operationId": "OIDCCallback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state of the authorization request",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2720,6 +2899,216 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/api/v1/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List API tokens of the user, admins of all namespaces list tokens of all users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIToken"
+                ],
+                "summary": "List API tokens",
+                "operationId": "ListAPITokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.APIToken"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an API token for automation, which is used as bearer token in Authorization header. The token is granted a role binding within that of the user and is only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIToken"
+                ],
+                "summary": "Create API token",
+                "operationId": "CreateAPIToken",
+                "parameters": [
+                    {
+                        "description": "API token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/param.CreateAPITokenParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.CreatedAPIToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke an API token of the user, admins of all namespaces can revoke tokens of all users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIToken"
+                ],
+                "summary": "Delete API token",
+                "operationId": "DeleteAPIToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -3183,6 +3572,39 @@ const docTemplate = `{
                 }
             }
         },
+        "param.CreateAPITokenParam": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expiresInDays": {
+                    "description": "Days before the token expires, the token never expires if it is 0",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ci-pipeline"
+                },
+                "namespaces": {
+                    "description": "Namespaces granted to the token, namespaces of the user by default. They must be granted to the user.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ns1"
+                    ]
+                },
+                "role": {
+                    "description": "Role of the token, role of the user by default. It can not be higher than that of the user.",
+                    "type": "string",
+                    "example": "operator"
+                }
+            }
+        },
         "param.CreateBackupPolicy": {
             "type": "object",
             "required": [
@@ -3201,11 +3623,21 @@ const docTemplate = `{
                     "type": "string",
                     "example": "encryptedPassword"
                 },
+                "cosAppId": {
+                    "description": "Required by COS destination",
+                    "type": "string",
+                    "example": "1250000000"
+                },
                 "destType": {
-                    "description": "Enum: NFS, OSS",
+                    "description": "Enum: NFS, OSS, COS, S3",
                     "type": "string",
                     "example": "NFS"
                 },
+                "endpoint": {
+                    "description": "Endpoint of object storage, used as host if archivePath and bakDataPath do not contain one",
+                    "type": "string",
+                    "example": "cos.ap-guangzhou.myqcloud.com"
+                },
                 "jobKeepDays": {
                     "type": "integer",
                     "example": 5
@@ -3226,6 +3658,16 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 3
                 },
+                "s3AddressingStyle": {
+                    "description": "Enum: virtual, path",
+                    "type": "string",
+                    "example": "path"
+                },
+                "s3Region": {
+                    "description": "Required by S3 destination",
+                    "type": "string",
+                    "example": "us-east-1"
+                },
                 "scheduleDates": {
                     "type": "array",
                     "items": {
@@ -3723,6 +4165,115 @@ const docTemplate = `{
                 }
             }
         },
+        "response.APIToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "0 means the token never expires",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditRecord": {
+            "type": "object",
+            "properties": {
+                "apiTokenId": {
+                    "type": "string"
+                },
+                "body": {
+                    "description": "Request body with values of sensitive fields redacted",
+                    "type": "object"
+                },
+                "generation": {
+                    "description": "Generation of the affected object after the request, 0 if it is absent",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Kind of the affected object, empty if the route does not target an object",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "type": "integer"
+                },
+                "successful": {
+                    "type": "boolean"
+                },
+                "time": {
+                    "description": "Unix time in milliseconds when the request is received",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Name of the user, which is the owner of API token if the request is authenticated with it",
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditRecordPage": {
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "description": "Whether there are records in following pages",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuditRecord"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
         "response.BackupJob": {
             "type": "object",
             "properties": {
@@ -3786,7 +4337,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "destType": {
-                    "description": "Enum: NFS, OSS",
+                    "description": "Enum: NFS, OSS, COS, S3",
                     "type": "string",
                     "example": "NFS"
                 },
@@ -3835,12 +4386,52 @@ const docTemplate = `{
                 }
             }
         },
+        "response.CreatedAPIToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "0 means the token never expires",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token to be used as bearer token in Authorization header, it is only shown once",
+                    "type": "string"
+                }
+            }
+        },
         "response.DashboardInfo": {
             "type": "object",
             "properties": {
                 "appName": {
                     "type": "string"
                 },
+                "oidcEnabled": {
+                    "type": "boolean"
+                },
                 "publicKey": {
                     "type": "string"
                 },
@@ -4713,6 +5304,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Cookie",
             "in": "header"
+        },
+        "BearerAuth": {
+            "description": "API token created by /api/v1/tokens, in the form of \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
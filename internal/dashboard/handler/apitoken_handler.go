@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/apitoken"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/param"
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+	httpErr "github.com/oceanbase/ob-operator/pkg/errors"
+)
+
+// @ID CreateAPIToken
+// @Summary Create API token
+// @Description Create an API token for automation, which is used as bearer token in Authorization header. The token is granted a role binding within that of the user and is only shown once.
+// @Tags APIToken
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.CreateAPITokenParam true "API token"
+// @Success 200 object response.APIResponse{data=response.CreatedAPIToken}
+// @Failure 400 object response.APIResponse
+// @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/tokens [POST]
+// @Security ApiKeyAuth
+func CreateAPIToken(c *gin.Context) (*response.CreatedAPIToken, error) {
+	store, binding, err := apiTokenContext(c)
+	if err != nil {
+		return nil, err
+	}
+	createParam := &param.CreateAPITokenParam{}
+	if err := c.BindJSON(createParam); err != nil {
+		return nil, httpErr.NewBadRequest(err.Error())
+	}
+	scope := &rbac.RoleBinding{
+		Role:       rbac.Role(createParam.Role),
+		Namespaces: createParam.Namespaces,
+	}
+	if scope.Role == "" {
+		scope.Role = binding.Role
+	}
+	if len(scope.Namespaces) == 0 {
+		scope.Namespaces = binding.Namespaces
+	}
+	if scope.Role.Level() == 0 {
+		return nil, httpErr.NewBadRequest("unknown role " + createParam.Role)
+	}
+	if !binding.Covers(scope) {
+		return nil, httpErr.NewForbidden("scope of API token exceeds role binding of user")
+	}
+	username := c.GetString(rbac.ContextKeyUsername)
+	if err := apitoken.CheckOwner(username); err != nil {
+		return nil, httpErr.NewForbidden(err.Error())
+	}
+	ttl := time.Duration(createParam.ExpiresInDays) * 24 * time.Hour
+	token, raw, err := store.Create(c, username, createParam.Name, scope, ttl)
+	if err != nil {
+		return nil, httpErr.NewInternal(err.Error())
+	}
+	return &response.CreatedAPIToken{
+		APIToken: *convertAPIToken(token),
+		Token:    raw,
+	}, nil
+}
+
+// @ID ListAPITokens
+// @Summary List API tokens
+// @Description List API tokens of the user, admins of all namespaces list tokens of all users.
+// @Tags APIToken
+// @Accept application/json
+// @Produce application/json
+// @Success 200 object response.APIResponse{data=[]response.APIToken}
+// @Failure 400 object response.APIResponse
+// @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/tokens [GET]
+// @Security ApiKeyAuth
+func ListAPITokens(c *gin.Context) ([]response.APIToken, error) {
+	store, binding, err := apiTokenContext(c)
+	if err != nil {
+		return nil, err
+	}
+	owner := c.GetString(rbac.ContextKeyUsername)
+	if binding.Permits(rbac.ActionAdmin) {
+		owner = ""
+	}
+	tokens, err := store.List(c, owner)
+	if err != nil {
+		return nil, httpErr.NewInternal(err.Error())
+	}
+	res := make([]response.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		res = append(res, *convertAPIToken(token))
+	}
+	return res, nil
+}
+
+// @ID DeleteAPIToken
+// @Summary Delete API token
+// @Description Revoke an API token of the user, admins of all namespaces can revoke tokens of all users.
+// @Tags APIToken
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "token id"
+// @Success 200 object response.APIResponse
+// @Failure 400 object response.APIResponse
+// @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
+// @Failure 404 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/tokens/{id} [DELETE]
+// @Security ApiKeyAuth
+func DeleteAPIToken(c *gin.Context) (any, error) {
+	store, binding, err := apiTokenContext(c)
+	if err != nil {
+		return nil, err
+	}
+	id := c.Param("id")
+	token, err := store.Get(c, id)
+	if err != nil {
+		if errors.Is(err, apitoken.ErrNotFound) {
+			return nil, httpErr.NewNotFound(err.Error())
+		}
+		return nil, httpErr.NewInternal(err.Error())
+	}
+	if token.Owner != c.GetString(rbac.ContextKeyUsername) && !binding.Permits(rbac.ActionAdmin) {
+		return nil, httpErr.NewForbidden("API token of other users can not be deleted")
+	}
+	if err := store.Delete(c, id); err != nil {
+		if errors.Is(err, apitoken.ErrNotFound) {
+			return nil, httpErr.NewNotFound(err.Error())
+		}
+		return nil, httpErr.NewInternal(err.Error())
+	}
+	return nil, nil
+}
+
+// apiTokenContext returns the token store and role binding of the user, tokens are managed only by users logged in
+func apiTokenContext(c *gin.Context) (*apitoken.Store, *rbac.RoleBinding, error) {
+	if !apitoken.Enabled() {
+		return nil, nil, httpErr.NewNotFound("API tokens are not enabled")
+	}
+	if _, exist := c.Get(apitoken.ContextKeyAPIToken); exist {
+		return nil, nil, httpErr.NewForbidden("API tokens can not be managed with API token")
+	}
+	binding, exist := c.Get(rbac.ContextKeyRoleBinding)
+	if !exist {
+		return nil, nil, httpErr.NewForbidden("no role is granted to user")
+	}
+	store, err := apitoken.GetStore()
+	if err != nil {
+		return nil, nil, httpErr.NewInternal(err.Error())
+	}
+	return store, binding.(*rbac.RoleBinding), nil
+}
+
+func convertAPIToken(token *apitoken.APIToken) *response.APIToken {
+	return &response.APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Owner:      token.Owner,
+		Role:       string(token.Scope.Role),
+		Namespaces: token.Scope.Namespaces,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		Expired:    token.Expired(),
+	}
+}
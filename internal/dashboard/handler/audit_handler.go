@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/audit"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/param"
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+	httpErr "github.com/oceanbase/ob-operator/pkg/errors"
+)
+
+const defaultAuditPageSize = 20
+
+// @ID ListAuditRecords
+// @Summary List audit records
+// @Description List audit records of mutating requests handled by this dashboard replica, newest first. Admins of all namespaces see all records, others see records in granted namespaces.
+// @Tags Audit
+// @Accept application/json
+// @Produce application/json
+// @Param username query string false "username"
+// @Param namespace query string false "namespace of affected object"
+// @Param page query int false "page starting from 1"
+// @Param size query int false "page size, 20 by default and 100 at most"
+// @Success 200 object response.APIResponse{data=response.AuditRecordPage}
+// @Failure 400 object response.APIResponse
+// @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/audit [GET]
+// @Security ApiKeyAuth
+func ListAuditRecords(c *gin.Context) (*response.AuditRecordPage, error) {
+	queryParam := &param.QueryAuditParam{}
+	if err := c.BindQuery(queryParam); err != nil {
+		return nil, httpErr.NewBadRequest(err.Error())
+	}
+	if queryParam.Page == 0 {
+		queryParam.Page = 1
+	}
+	if queryParam.Size == 0 {
+		queryParam.Size = defaultAuditPageSize
+	}
+	filter := &audit.Filter{
+		Username:  queryParam.Username,
+		Namespace: queryParam.Namespace,
+	}
+	if binding, exist := c.Get(rbac.ContextKeyRoleBinding); !exist || !binding.(*rbac.RoleBinding).Permits(rbac.ActionAdmin) {
+		filter.Visible = func(record *response.AuditRecord) bool {
+			return record.Namespace != "" && namespaceGranted(c, record.Namespace)
+		}
+	}
+	records, hasMore, err := audit.GetLogger().Query(filter, queryParam.Page, queryParam.Size)
+	if err != nil {
+		return nil, httpErr.NewInternal(err.Error())
+	}
+	return &response.AuditRecordPage{
+		Items:   records,
+		HasMore: hasMore,
+		Page:    queryParam.Page,
+		Size:    queryParam.Size,
+	}, nil
+}
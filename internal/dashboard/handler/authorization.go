@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	httpErr "github.com/oceanbase/ob-operator/pkg/errors"
+)
+
+// namespaceGranted tells whether the namespace is granted to the logged in user by the role binding in context
+func namespaceGranted(c *gin.Context, namespace string) bool {
+	binding, exist := c.Get(rbac.ContextKeyRoleBinding)
+	if !exist {
+		return false
+	}
+	return binding.(*rbac.RoleBinding).Granted(namespace)
+}
+
+// namespaceFilter returns a function telling whether namespaces are granted to the logged in user
+func namespaceFilter(c *gin.Context) func(namespace string) bool {
+	return func(namespace string) bool {
+		return namespaceGranted(c, namespace)
+	}
+}
+
+// grantedNamespaces returns namespaces granted to the logged in user, it returns nil if all namespaces are granted
+func grantedNamespaces(c *gin.Context) []string {
+	binding, exist := c.Get(rbac.ContextKeyRoleBinding)
+	if !exist {
+		return []string{}
+	}
+	if binding.(*rbac.RoleBinding).Granted(rbac.AllNamespaces) {
+		return nil
+	}
+	return binding.(*rbac.RoleBinding).Namespaces
+}
+
+// checkNamespaceGranted checks namespace carried in request body, which is invisible to the authorization middleware
+func checkNamespaceGranted(c *gin.Context, namespace string) error {
+	if !namespaceGranted(c, namespace) {
+		return httpErr.NewForbidden(fmt.Sprintf("namespace %s is not granted to user", namespace))
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/oidc"
 	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
 	"github.com/oceanbase/ob-operator/internal/oceanbase"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
@@ -56,6 +57,7 @@ func GetProcessInfo(_ *gin.Context) (*response.DashboardInfo, error) {
 		Version:          strings.Join([]string{Version, CommitHash, BuildTime}, "-"),
 		PublicKey:        string(pubBytes),
 		ReportStatistics: os.Getenv("DISABLE_REPORT_STATISTICS") != "true",
+		OIDCEnabled:      oidc.Enabled(),
 	}, nil
 }
 
@@ -80,6 +82,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.Clusters = make([]models.OBCluster, 0, len(clusterList.Items))
 	for i := range clusterList.Items {
+		if !namespaceGranted(c, clusterList.Items[i].Namespace) {
+			continue
+		}
 		modelCluster := telemetry.TransformReportOBCluster(&clusterList.Items[i])
 		reportData.Clusters = append(reportData.Clusters, *modelCluster)
 	}
@@ -91,6 +96,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.Zones = make([]models.OBZone, 0, len(zoneList.Items))
 	for i := range zoneList.Items {
+		if !namespaceGranted(c, zoneList.Items[i].Namespace) {
+			continue
+		}
 		modelZone := telemetry.TransformReportOBZone(&zoneList.Items[i])
 		reportData.Zones = append(reportData.Zones, *modelZone)
 	}
@@ -102,6 +110,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.Servers = make([]models.OBServer, 0, len(serverList.Items))
 	for i := range serverList.Items {
+		if !namespaceGranted(c, serverList.Items[i].Namespace) {
+			continue
+		}
 		modelServer := telemetry.TransformReportOBServer(&serverList.Items[i])
 		reportData.Servers = append(reportData.Servers, *modelServer)
 	}
@@ -113,6 +124,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.Tenants = make([]models.OBTenant, 0, len(tenantList.Items))
 	for i := range tenantList.Items {
+		if !namespaceGranted(c, tenantList.Items[i].Namespace) {
+			continue
+		}
 		modelTenant := telemetry.TransformReportOBTenant(&tenantList.Items[i])
 		reportData.Tenants = append(reportData.Tenants, *modelTenant)
 	}
@@ -124,6 +138,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.BackupPolicies = make([]models.OBBackupPolicy, 0, len(backupPolicyList.Items))
 	for i := range backupPolicyList.Items {
+		if !namespaceGranted(c, backupPolicyList.Items[i].Namespace) {
+			continue
+		}
 		modelBackupPolicy := telemetry.TransformReportOBBackupPolicy(&backupPolicyList.Items[i])
 		reportData.BackupPolicies = append(reportData.BackupPolicies, *modelBackupPolicy)
 	}
@@ -135,6 +152,9 @@ func GetStatistics(c *gin.Context) (*response.StatisticDataResponse, error) {
 	}
 	reportData.WarningEvents = make([]models.K8sEvent, 0, len(eventList.Items))
 	for i := range eventList.Items {
+		if !namespaceGranted(c, eventList.Items[i].Namespace) {
+			continue
+		}
 		modelEvent := &models.K8sEvent{
 			Reason:         eventList.Items[i].Reason,
 			Message:        eventList.Items[i].Message,
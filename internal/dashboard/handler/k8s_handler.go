@@ -48,7 +48,13 @@ func ListK8sEvents(c *gin.Context) ([]response.K8sEvent, error) {
 	if err != nil {
 		return nil, err
 	}
-	return events, nil
+	granted := make([]response.K8sEvent, 0, len(events))
+	for _, event := range events {
+		if namespaceGranted(c, event.Namespace) {
+			granted = append(granted, event)
+		}
+	}
+	return granted, nil
 }
 
 // @ID ListK8sNodes
@@ -83,12 +89,18 @@ func ListK8sNodes(_ *gin.Context) ([]response.K8sNode, error) {
 // @Failure 500 object response.APIResponse
 // @Router /api/v1/cluster/namespaces [GET]
 // @Security ApiKeyAuth
-func ListK8sNamespaces(_ *gin.Context) ([]response.Namespace, error) {
+func ListK8sNamespaces(c *gin.Context) ([]response.Namespace, error) {
 	namespaces, err := k8s.ListNamespaces()
 	if err != nil {
 		return nil, err
 	}
-	return namespaces, nil
+	granted := make([]response.Namespace, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespaceGranted(c, namespace.Namespace) {
+			granted = append(granted, namespace)
+		}
+	}
+	return granted, nil
 }
 
 // @ID ListK8sStorageClasses
@@ -71,6 +71,10 @@ func QueryMetrics(c *gin.Context) ([]response.MetricData, error) {
 	if err != nil {
 		return nil, httpErr.NewBadRequest(err.Error())
 	}
-	metricDatas := metric.QueryMetricData(queryParam)
+	err = metric.ValidateQuery(queryParam)
+	if err != nil {
+		return nil, httpErr.NewBadRequest(err.Error())
+	}
+	metricDatas := metric.QueryMetricData(queryParam, grantedNamespaces(c))
 	return metricDatas, nil
 }
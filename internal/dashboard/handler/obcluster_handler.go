@@ -36,7 +36,7 @@ import (
 // @Router /api/v1/obclusters/statistic [GET]
 func GetOBClusterStatistic(c *gin.Context) ([]response.OBClusterStastistic, error) {
 	// return mock data
-	obclusterStastics, err := oceanbase.GetOBClusterStatistic(c)
+	obclusterStastics, err := oceanbase.GetOBClusterStatistic(c, namespaceFilter(c))
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,13 @@ func ListOBClusters(c *gin.Context) ([]response.OBClusterOverview, error) {
 	if err != nil {
 		return nil, err
 	}
-	return obclusters, nil
+	granted := make([]response.OBClusterOverview, 0, len(obclusters))
+	for _, obcluster := range obclusters {
+		if namespaceGranted(c, obcluster.Namespace) {
+			granted = append(granted, obcluster)
+		}
+	}
+	return granted, nil
 }
 
 // @ID GetOBCluster
@@ -109,6 +115,9 @@ func CreateOBCluster(c *gin.Context) (any, error) {
 	if err != nil {
 		return nil, httpErr.NewBadRequest(err.Error())
 	}
+	if err := checkNamespaceGranted(c, param.Namespace); err != nil {
+		return nil, err
+	}
 	param.RootPassword, err = crypto.DecryptWithPrivateKey(param.RootPassword)
 	if err != nil {
 		return nil, httpErr.NewBadRequest(err.Error())
@@ -66,7 +66,13 @@ func ListAllTenants(c *gin.Context) ([]*response.OBTenantOverview, error) {
 			}
 		}
 	}
-	return tenants, nil
+	granted := make([]*response.OBTenantOverview, 0, len(tenants))
+	for _, tenant := range tenants {
+		if namespaceGranted(c, tenant.Namespace) {
+			granted = append(granted, tenant)
+		}
+	}
+	return granted, nil
 }
 
 // @ID GetTenant
@@ -121,6 +127,9 @@ func CreateTenant(c *gin.Context) (*response.OBTenantDetail, error) {
 	if err != nil {
 		return nil, httpErr.NewBadRequest(err.Error())
 	}
+	if err := checkNamespaceGranted(c, tenantParam.Namespace); err != nil {
+		return nil, err
+	}
 	logger.Infof("Create obtenant: %+v", tenantParam)
 	tenantParam.RootPassword, err = crypto.DecryptWithPrivateKey(tenantParam.RootPassword)
 	if err != nil {
@@ -400,7 +409,7 @@ func CreateBackupPolicy(c *gin.Context) (*response.BackupPolicy, error) {
 	if err != nil {
 		return nil, httpErr.NewBadRequest(err.Error())
 	}
-	if createPolicyParam.DestType == "OSS" {
+	if createPolicyParam.DestType != "NFS" {
 		createPolicyParam.OSSAccessID, err = crypto.DecryptWithPrivateKey(createPolicyParam.OSSAccessID)
 		if err != nil {
 			return nil, httpErr.NewBadRequest(err.Error())
@@ -580,7 +589,7 @@ func ListBackupJobs(c *gin.Context) ([]*response.BackupJob, error) {
 // @Router /api/v1/obtenants/statistic [GET]
 // @Security ApiKeyAuth
 func GetOBTenantStatistic(c *gin.Context) ([]response.OBTenantStatistic, error) {
-	tenants, err := oceanbase.GetOBTenantStatistics(c)
+	tenants, err := oceanbase.GetOBTenantStatistics(c, namespaceFilter(c))
 	if err != nil {
 		return nil, err
 	}
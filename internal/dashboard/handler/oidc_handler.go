@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/oidc"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	httpErr "github.com/oceanbase/ob-operator/pkg/errors"
+)
+
+const (
+	sessionKeyOIDCState = "oidcState"
+	sessionKeyOIDCNonce = "oidcNonce"
+)
+
+// @ID OIDCLogin
+// @Summary SSO login
+// @Description Redirect user to the OpenID Connect provider to login.
+// @Tags User
+// @Success 302
+// @Failure 404 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/login/oidc [GET]
+func OIDCLogin(c *gin.Context) {
+	if !oidc.Enabled() {
+		abortWithError(c, httpErr.NewNotFound("SSO login is not enabled"))
+		return
+	}
+	provider, err := oidc.GetProvider(c)
+	if err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	state, err := oidc.NewState()
+	if err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	nonce, err := oidc.NewState()
+	if err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	sess := sessions.Default(c)
+	sess.Set(sessionKeyOIDCState, state)
+	sess.Set(sessionKeyOIDCNonce, nonce)
+	if err := sess.Save(); err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	c.Redirect(http.StatusFound, provider.AuthCodeURL(state, nonce))
+}
+
+// @ID OIDCCallback
+// @Summary SSO login callback
+// @Description Exchange authorization code of the OpenID Connect provider, login the user and redirect to home page.
+// @Tags User
+// @Param code query string true "authorization code"
+// @Param state query string true "state of the authorization request"
+// @Success 302
+// @Failure 400 object response.APIResponse
+// @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
+// @Failure 500 object response.APIResponse
+// @Router /api/v1/login/oidc/callback [GET]
+func OIDCCallback(c *gin.Context) {
+	if !oidc.Enabled() {
+		abortWithError(c, httpErr.NewNotFound("SSO login is not enabled"))
+		return
+	}
+	if errCode := c.Query("error"); errCode != "" {
+		abortWithError(c, httpErr.NewUnauthorized(errCode+": "+c.Query("error_description")))
+		return
+	}
+	sess := sessions.Default(c)
+	state, _ := sess.Get(sessionKeyOIDCState).(string)
+	nonce, _ := sess.Get(sessionKeyOIDCNonce).(string)
+	if state == "" || c.Query("state") != state {
+		abortWithError(c, httpErr.NewBadRequest("state of SSO login does not match"))
+		return
+	}
+	code := c.Query("code")
+	if code == "" {
+		abortWithError(c, httpErr.NewBadRequest("authorization code is required"))
+		return
+	}
+	provider, err := oidc.GetProvider(c)
+	if err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	identity, err := provider.Exchange(c, code, nonce)
+	if err != nil {
+		abortWithError(c, httpErr.NewUnauthorized(err.Error()))
+		return
+	}
+	sess.Delete(sessionKeyOIDCState)
+	sess.Delete(sessionKeyOIDCNonce)
+	sess.Delete(rbac.SessionKeyRoleBinding)
+	if len(provider.Config().GroupRoles) > 0 {
+		binding := provider.Config().RoleBinding(identity.Groups)
+		if binding == nil {
+			abortWithError(c, httpErr.NewForbidden("no role is granted to groups of user "+identity.Name))
+			return
+		}
+		rawBinding, err := json.Marshal(binding)
+		if err != nil {
+			abortWithError(c, httpErr.NewInternal(err.Error()))
+			return
+		}
+		sess.Set(rbac.SessionKeyRoleBinding, string(rawBinding))
+	}
+	if err := saveLoginSession(sess, identity.Username); err != nil {
+		abortWithError(c, httpErr.NewInternal(err.Error()))
+		return
+	}
+	c.Redirect(http.StatusFound, "/")
+}
@@ -24,6 +24,8 @@ import (
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/oidc"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
 	"github.com/oceanbase/ob-operator/internal/dashboard/model/param"
 	"github.com/oceanbase/ob-operator/internal/dashboard/server/constant"
 	"github.com/oceanbase/ob-operator/internal/store"
@@ -42,9 +44,13 @@ import (
 // @Success 200 object response.APIResponse
 // @Failure 400 object response.APIResponse
 // @Failure 401 object response.APIResponse
+// @Failure 403 object response.APIResponse
 // @Failure 500 object response.APIResponse
 // @Router /api/v1/login [POST]
 func Login(c *gin.Context) (string, error) {
+	if oidc.PasswordLoginDisabled() {
+		return "", httpErr.NewForbidden("password login is disabled, please login with SSO")
+	}
 	loginParams := &param.LoginParam{}
 	if err := c.BindJSON(loginParams); err != nil {
 		return "", httpErr.NewBadRequest(err.Error())
@@ -69,12 +75,10 @@ func Login(c *gin.Context) (string, error) {
 		return "", httpErr.NewBadRequest("username or password is incorrect")
 	}
 	sess := sessions.Default(c)
-	sess.Set("username", loginParams.Username)
-	sess.Set("expiration", time.Now().Add(constant.DefaultSessionExpiration*time.Second).Unix())
-	if err := sess.Save(); err != nil {
+	sess.Delete(rbac.SessionKeyRoleBinding)
+	if err := saveLoginSession(sess, loginParams.Username); err != nil {
 		return "", httpErr.NewInternal(err.Error())
 	}
-	store.GetCache().Store(loginParams.Username, struct{}{})
 	return "login successfully", nil
 }
 
@@ -91,18 +95,43 @@ func Login(c *gin.Context) (string, error) {
 // @Security ApiKeyAuth
 func Logout(c *gin.Context) (string, error) {
 	sess := sessions.Default(c)
+	if sessionID, ok := sess.Get("sessionId").(string); ok && sessionID != "" {
+		expiration := time.Now().Add(constant.DefaultSessionExpiration * time.Second)
+		if err := store.GetSessionStore().Revoke(c, sessionID, expiration); err != nil {
+			return "", httpErr.NewInternal(err.Error())
+		}
+	}
 	usernameEntry := sess.Get("username")
 	sess.Clear()
 	sess.Options(sessions.Options{Path: "/", MaxAge: -1}) // this sets the cookie with a MaxAge of 0
 	if err := sess.Save(); err != nil {
 		return "", httpErr.NewInternal(err.Error())
 	}
-	if usernameEntry != nil {
+	if usernameEntry != nil && !store.SessionsShared() {
 		store.GetCache().Delete(usernameEntry.(string))
 	}
 	return "logout successfully", nil
 }
 
+// saveLoginSession saves a new session of the logged in user, which is validated by middleware LoginRequired
+func saveLoginSession(sess sessions.Session, username string) error {
+	sessionID, err := store.NewSessionID()
+	if err != nil {
+		return err
+	}
+	sess.Set("username", username)
+	sess.Set("sessionId", sessionID)
+	sess.Set("expiration", time.Now().Add(constant.DefaultSessionExpiration*time.Second).Unix())
+	if err := sess.Save(); err != nil {
+		return err
+	}
+	if !store.SessionsShared() {
+		// sessions are only accepted by this process, users logged in are remembered in its cache
+		store.GetCache().Store(username, struct{}{})
+	}
+	return nil
+}
+
 func getDashboardUserCredentials(c context.Context) (*v1.Secret, error) {
 	credentialSecret, exist := os.LookupEnv("USER_CREDENTIALS_SECRET")
 	if !exist || credentialSecret == "" {
@@ -40,11 +40,7 @@ func Wrap[T any](h Handler[T]) gin.HandlerFunc {
 		statusCode := http.StatusOK
 		var errMsg string
 		if err != nil {
-			if obe, ok := err.(errors.ObError); ok && obe != nil {
-				statusCode = obe.Status()
-			} else {
-				statusCode = http.StatusInternalServerError
-			}
+			statusCode = errorStatus(err)
 			errMsg = err.Error()
 			logHandlerError(c, err)
 			// ensure that the response is nil
@@ -57,3 +53,19 @@ func Wrap[T any](h Handler[T]) gin.HandlerFunc {
 		})
 	}
 }
+
+func errorStatus(err error) int {
+	if obe, ok := err.(errors.ObError); ok && obe != nil {
+		return obe.Status()
+	}
+	return http.StatusInternalServerError
+}
+
+// abortWithError responds the error in the same form as wrapped handlers, for handlers that redirect on success
+func abortWithError(c *gin.Context, err error) {
+	logHandlerError(c, err)
+	c.AbortWithStatusJSON(errorStatus(err), &response.APIResponse{
+		Message:    err.Error(),
+		Successful: false,
+	})
+}
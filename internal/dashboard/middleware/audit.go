@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/apitoken"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/audit"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+	"github.com/oceanbase/ob-operator/internal/dashboard/model/response"
+	"github.com/oceanbase/ob-operator/internal/oceanbase"
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+// MaxRequestBodySize limits bodies of mutating requests, which are read into memory for audit
+const MaxRequestBodySize = 1024 * 1024
+
+type auditTarget struct {
+	kind string
+	get  func(ctx context.Context, namespace, name string) (metav1.Object, error)
+}
+
+// auditTargets maps prefixes of routes to kinds of objects affected by them
+var auditTargets = map[string]auditTarget{
+	"/api/v1/obclusters": {
+		kind: "OBCluster",
+		get: func(ctx context.Context, namespace, name string) (metav1.Object, error) {
+			obj, err := oceanbase.ClusterClient.Get(ctx, namespace, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return obj, nil
+		},
+	},
+	"/api/v1/obtenants": {
+		kind: "OBTenant",
+		get: func(ctx context.Context, namespace, name string) (metav1.Object, error) {
+			obj, err := oceanbase.TenantClient.Get(ctx, namespace, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return obj, nil
+		},
+	},
+}
+
+// auditResponseWriter keeps the beginning of response body to find out the error message
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if remaining := audit.MaxBodySize - w.body.Len(); remaining > 0 {
+		if len(data) > remaining {
+			w.body.Write(data[:remaining])
+		} else {
+			w.body.Write(data)
+		}
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *auditResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Audit records requests other than GET to the audit log, including those rejected by authentication
+func Audit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		startTime := time.Now()
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		var body []byte
+		tooLarge := false
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(writer, c.Request.Body, MaxRequestBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				tooLarge = true
+			} else if err != nil {
+				log.Errorf("failed to read request body for audit: %v", err)
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		if tooLarge {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"message": "request body is too large",
+			})
+		} else {
+			c.Next()
+		}
+
+		record := &response.AuditRecord{
+			RequestID:  requestid.Get(c),
+			Time:       startTime.UnixMilli(),
+			Username:   c.GetString(rbac.ContextKeyUsername),
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Path:       c.Request.URL.Path,
+			Namespace:  c.Param("namespace"),
+			Name:       c.Param("name"),
+			Body:       audit.Redact(body),
+			StatusCode: writer.Status(),
+			Successful: writer.Status() < http.StatusBadRequest,
+		}
+		if record.Username == "" {
+			// user who has just logged in, or whose session is rejected by authentication
+			record.Username, _ = sessions.Default(c).Get("username").(string)
+		}
+		if token, exist := c.Get(apitoken.ContextKeyAPIToken); exist {
+			record.APITokenID = token.(*apitoken.APIToken).ID
+		}
+		if record.Namespace == "" && record.Name == "" {
+			// objects created with namespace and name in request body
+			target := struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			}{}
+			_ = json.Unmarshal(body, &target)
+			record.Namespace, record.Name = target.Namespace, target.Name
+		}
+		if !record.Successful {
+			// both handlers and middlewares respond errors in field message
+			res := &response.APIResponse{}
+			_ = json.Unmarshal(writer.body.Bytes(), res)
+			record.Message = res.Message
+		}
+		var object metav1.Object
+		for prefix, target := range auditTargets {
+			if !strings.HasPrefix(record.Route, prefix) {
+				continue
+			}
+			record.Kind = target.kind
+			if record.Namespace == "" || record.Name == "" {
+				break
+			}
+			obj, err := target.get(c, record.Namespace, record.Name)
+			if err == nil {
+				object = obj
+				record.Generation = obj.GetGeneration()
+			} else if !kubeerrors.IsNotFound(err) {
+				log.Errorf("failed to get %s %s/%s for audit: %v", target.kind, record.Namespace, record.Name, err)
+			}
+			break
+		}
+		if err := audit.GetLogger().Write(record); err != nil {
+			log.Errorf("failed to write audit record: %v", err)
+		}
+		if object != nil && audit.EventsEnabled() {
+			event := audit.NewEvent(record, object, v1alpha1.GroupVersion.String())
+			_, err := client.GetClient().ClientSet.CoreV1().Events(event.Namespace).Create(c, event, metav1.CreateOptions{})
+			if err != nil {
+				log.Errorf("failed to emit audit event: %v", err)
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package middleware
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -20,29 +21,138 @@ import (
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/apitoken"
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
 	"github.com/oceanbase/ob-operator/internal/dashboard/server/constant"
 	"github.com/oceanbase/ob-operator/internal/store"
 )
 
 // authentication
 
+// loginFreePaths are routes for login and public info, which require no login
+var loginFreePaths = map[string]struct{}{
+	"/api/v1/login": {},
+	"/api/v1/info":  {},
+	// SSO login and its callback carrying authorization code in query
+	"/api/v1/login/oidc":          {},
+	"/api/v1/login/oidc/callback": {},
+}
+
+// loginFree tells whether the request is routed to login or public info, which requires no login
+func loginFree(c *gin.Context) bool {
+	_, exist := loginFreePaths[c.FullPath()]
+	return exist
+}
+
+// bearerToken returns the token in Authorization header of the request
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")), true
+}
+
+// authenticateAPIToken authenticates requests of automation with API tokens, scope of the token narrowed by current
+// role binding of its owner is checked as role binding by the authorization middleware
+func authenticateAPIToken(c *gin.Context, raw string) {
+	if !apitoken.Enabled() {
+		c.AbortWithStatusJSON(401, gin.H{
+			"message": "API tokens are not enabled",
+		})
+		return
+	}
+	tokenStore, err := apitoken.GetStore()
+	if err != nil {
+		log.Errorf("failed to get store of API tokens: %v", err)
+		c.AbortWithStatusJSON(500, gin.H{
+			"message": "failed to authenticate API token",
+		})
+		return
+	}
+	token, err := tokenStore.Authenticate(c, raw)
+	if errors.Is(err, apitoken.ErrInvalidToken) || errors.Is(err, apitoken.ErrTokenExpired) {
+		c.AbortWithStatusJSON(401, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Errorf("failed to authenticate API token: %v", err)
+		c.AbortWithStatusJSON(500, gin.H{
+			"message": "failed to authenticate API token",
+		})
+		return
+	}
+	scope, err := apitoken.ResolveScope(c, token)
+	if errors.Is(err, apitoken.ErrOwnerNotFound) || errors.Is(err, apitoken.ErrOwnerSSO) {
+		c.AbortWithStatusJSON(401, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Errorf("failed to resolve scope of API token: %v", err)
+		c.AbortWithStatusJSON(500, gin.H{
+			"message": "failed to authenticate API token",
+		})
+		return
+	}
+	if scope == nil {
+		c.AbortWithStatusJSON(403, gin.H{
+			"message": "no role is granted to owner of API token",
+		})
+		return
+	}
+	c.Set(apitoken.ContextKeyAPIToken, token)
+	c.Set(rbac.ContextKeyUsername, token.Owner)
+	c.Set(rbac.ContextKeyRoleBinding, scope)
+	c.Next()
+}
+
 func LoginRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if strings.HasSuffix(c.Request.RequestURI, "login") || strings.HasSuffix(c.Request.RequestURI, "info") {
+		if loginFree(c) {
 			c.Next()
 			return
 		}
+		if raw, ok := bearerToken(c); ok {
+			authenticateAPIToken(c, raw)
+			return
+		}
 		session := sessions.Default(c)
-		if session.Get("username") == nil {
+		username, _ := session.Get("username").(string)
+		if username == "" {
 			c.AbortWithStatusJSON(401, gin.H{
 				"message": "login required",
 			})
 			return
 		}
+		if !store.SessionsShared() {
+			if _, exist := store.GetCache().Load(username); !exist {
+				c.AbortWithStatusJSON(401, gin.H{
+					"message": "login required",
+				})
+				return
+			}
+		}
 
-		username := session.Get("username").(string)
-		_, exist := store.GetCache().Load(username)
-		if !exist {
+		sessionID, _ := session.Get("sessionId").(string)
+		if sessionID == "" {
+			c.AbortWithStatusJSON(401, gin.H{
+				"message": "login required",
+			})
+			return
+		}
+		revoked, err := store.GetSessionStore().IsRevoked(c, sessionID)
+		if err != nil {
+			log.Errorf("failed to check revocation of session: %v", err)
+			c.AbortWithStatusJSON(500, gin.H{
+				"message": "failed to check session",
+			})
+			return
+		}
+		if revoked {
 			c.AbortWithStatusJSON(401, gin.H{
 				"message": "login required",
 			})
@@ -67,19 +177,23 @@ func LoginRequired() gin.HandlerFunc {
 					"message": "failed to save session",
 				})
 			}
-			store.GetCache().Delete(username)
+			if !store.SessionsShared() {
+				store.GetCache().Delete(username)
+			}
 			c.AbortWithStatusJSON(401, gin.H{
 				"message": "login expired, please login again",
 			})
 			return
 		}
+		c.Set(rbac.ContextKeyUsername, username)
 		c.Next()
 	}
 }
 
 func RefreshExpiration() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if strings.HasSuffix(c.Request.RequestURI, "login") || strings.HasSuffix(c.Request.RequestURI, "info") {
+		// requests authenticated with API tokens carry no session
+		if _, exist := c.Get(apitoken.ContextKeyAPIToken); loginFree(c) || exist {
 			c.Next()
 			return
 		}
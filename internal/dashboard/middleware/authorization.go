@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/ob-operator/internal/dashboard/business/rbac"
+)
+
+// RouteGroup identifies a group of routes under /api/v1
+type RouteGroup string
+
+const (
+	RouteGroupInfo      RouteGroup = "info"
+	RouteGroupK8s       RouteGroup = "k8s"
+	RouteGroupMetric    RouteGroup = "metric"
+	RouteGroupOBCluster RouteGroup = "obcluster"
+	RouteGroupOBTenant  RouteGroup = "obtenant"
+	RouteGroupAPIToken  RouteGroup = "apitoken"
+	RouteGroupAudit     RouteGroup = "audit"
+)
+
+// writeActions declares actions required by requests other than GET in route groups,
+// groups absent here only accept GET requests
+var writeActions = map[RouteGroup]rbac.Action{
+	RouteGroupK8s:       rbac.ActionAdmin,
+	RouteGroupOBCluster: rbac.ActionWrite,
+	RouteGroupOBTenant:  rbac.ActionWrite,
+	// metrics are queried with POST, which reads only
+	RouteGroupMetric: rbac.ActionRead,
+	// users of every role manage their own tokens within their role bindings
+	RouteGroupAPIToken: rbac.ActionRead,
+}
+
+// authorization
+
+// Authorization checks role of the logged in user for routes of the group, GET requests require read permission
+// and others require the action declared for the group. Namespace in path or query must be granted to the user.
+func Authorization(group RouteGroup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == "/api/v1/info" {
+			c.Next()
+			return
+		}
+		session := sessions.Default(c)
+		username := c.GetString(rbac.ContextKeyUsername)
+		var binding *rbac.RoleBinding
+		var err error
+		if scope, exist := c.Get(rbac.ContextKeyRoleBinding); exist {
+			// requests authenticated with API tokens are granted scopes of the tokens
+			binding = scope.(*rbac.RoleBinding)
+		} else if raw, ok := session.Get(rbac.SessionKeyRoleBinding).(string); ok {
+			// users logged in with SSO are granted roles mapped from their groups
+			binding, err = rbac.ParseRoleBinding(raw)
+		} else {
+			binding, err = rbac.GetRoleBinding(c, username)
+		}
+		if err != nil {
+			log.Errorf("failed to get role binding of user %s: %v", username, err)
+			c.AbortWithStatusJSON(500, gin.H{
+				"message": "failed to get role of user",
+			})
+			return
+		}
+		if binding == nil {
+			c.AbortWithStatusJSON(403, gin.H{
+				"message": "no role is granted to user",
+			})
+			return
+		}
+		action := rbac.ActionRead
+		if c.Request.Method != http.MethodGet {
+			var declared bool
+			action, declared = writeActions[group]
+			if !declared {
+				c.AbortWithStatusJSON(403, gin.H{
+					"message": "permission denied",
+				})
+				return
+			}
+		}
+		if !binding.Permits(action) {
+			c.AbortWithStatusJSON(403, gin.H{
+				"message": fmt.Sprintf("role %s is not permitted to %s", binding.Role, action),
+			})
+			return
+		}
+		namespace := c.Param("namespace")
+		if namespace == "" {
+			namespace = c.Query("namespace")
+		}
+		if namespace != "" && !binding.Granted(namespace) {
+			c.AbortWithStatusJSON(403, gin.H{
+				"message": fmt.Sprintf("namespace %s is not granted to user", namespace),
+			})
+			return
+		}
+		c.Set(rbac.ContextKeyRoleBinding, binding)
+		c.Next()
+	}
+}
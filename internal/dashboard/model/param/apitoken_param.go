@@ -0,0 +1,23 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package param
+
+type CreateAPITokenParam struct {
+	Name string `json:"name" binding:"required" example:"ci-pipeline"`
+	// Role of the token, role of the user by default. It can not be higher than that of the user.
+	Role string `json:"role" example:"operator"`
+	// Namespaces granted to the token, namespaces of the user by default. They must be granted to the user.
+	Namespaces []string `json:"namespaces" example:"ns1"`
+	// Days before the token expires, the token never expires if it is 0
+	ExpiresInDays int `json:"expiresInDays" binding:"min=0" example:"90"`
+}
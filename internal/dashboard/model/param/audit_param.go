@@ -0,0 +1,21 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package param
+
+type QueryAuditParam struct {
+	Username  string `json:"username" form:"username" binding:"omitempty"`
+	Namespace string `json:"namespace" form:"namespace" binding:"omitempty"`
+	// Page starts from 1
+	Page int `json:"page" form:"page" binding:"omitempty,min=1"`
+	Size int `json:"size" form:"size" binding:"omitempty,min=1,max=100"`
+}
@@ -28,7 +28,7 @@ type DaysFieldBase struct {
 }
 
 type BackupPolicyBase struct {
-	// Enum: NFS, OSS
+	// Enum: NFS, OSS, COS, S3
 	DestType    BackupDestType `json:"destType" binding:"required" example:"NFS"`
 	ArchivePath string         `json:"archivePath" binding:"required"`
 	BakDataPath string         `json:"bakDataPath" binding:"required"`
@@ -42,6 +42,15 @@ type CreateBackupPolicy struct {
 	OSSAccessID           string `json:"ossAccessId,omitempty" example:"encryptedPassword"`
 	OSSAccessKey          string `json:"ossAccessKey,omitempty" example:"encryptedPassword"`
 	BakEncryptionPassword string `json:"bakEncryptionPassword,omitempty" example:"encryptedPassword"`
+
+	// Endpoint of object storage, used as host if archivePath and bakDataPath do not contain one
+	Endpoint string `json:"endpoint,omitempty" example:"cos.ap-guangzhou.myqcloud.com"`
+	// Required by COS destination
+	COSAppID string `json:"cosAppId,omitempty" example:"1250000000"`
+	// Required by S3 destination
+	S3Region string `json:"s3Region,omitempty" example:"us-east-1"`
+	// Enum: virtual, path
+	S3AddressingStyle string `json:"s3AddressingStyle,omitempty" example:"path"`
 }
 
 type ScheduleDate struct {
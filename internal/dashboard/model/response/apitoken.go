@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package response
+
+type APIToken struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Owner      string   `json:"owner"`
+	Role       string   `json:"role"`
+	Namespaces []string `json:"namespaces"`
+	CreatedAt  int64    `json:"createdAt"`
+	// 0 means the token never expires
+	ExpiresAt int64 `json:"expiresAt"`
+	Expired   bool  `json:"expired"`
+}
+
+type CreatedAPIToken struct {
+	APIToken `json:",inline"`
+	// Token to be used as bearer token in Authorization header, it is only shown once
+	Token string `json:"token"`
+}
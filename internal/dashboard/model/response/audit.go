@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package response
+
+import "encoding/json"
+
+// AuditRecord of a mutating request to the dashboard
+type AuditRecord struct {
+	RequestID string `json:"requestId"`
+	// Unix time in milliseconds when the request is received
+	Time int64 `json:"time"`
+	// Name of the user, which is the owner of API token if the request is authenticated with it
+	Username   string `json:"username"`
+	APITokenID string `json:"apiTokenId,omitempty"`
+	Method     string `json:"method"`
+	Route      string `json:"route"`
+	Path       string `json:"path"`
+	// Kind of the affected object, empty if the route does not target an object
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Request body with values of sensitive fields redacted
+	Body       json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+	StatusCode int             `json:"statusCode"`
+	Successful bool            `json:"successful"`
+	Message    string          `json:"message,omitempty"`
+	// Generation of the affected object after the request, 0 if it is absent
+	Generation int64 `json:"generation,omitempty"`
+}
+
+type AuditRecordPage struct {
+	Items []AuditRecord `json:"items"`
+	// Whether there are records in following pages
+	HasMore bool `json:"hasMore"`
+	Page    int  `json:"page"`
+	Size    int  `json:"size"`
+}
@@ -17,4 +17,5 @@ type DashboardInfo struct {
 	Version          string `json:"version"`
 	PublicKey        string `json:"publicKey"`
 	ReportStatistics bool   `json:"reportStatistics"`
+	OIDCEnabled      bool   `json:"oidcEnabled"`
 }
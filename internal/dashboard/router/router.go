@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package router
 
 import (
+	"context"
 	"os"
 
 	"github.com/gin-contrib/gzip"
@@ -21,6 +22,7 @@ import (
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -28,14 +30,18 @@ import (
 	"github.com/oceanbase/ob-operator/internal/dashboard/middleware"
 	v1 "github.com/oceanbase/ob-operator/internal/dashboard/router/v1"
 	"github.com/oceanbase/ob-operator/internal/dashboard/server/constant"
+	obstore "github.com/oceanbase/ob-operator/internal/store"
 )
 
-func InitRoutes(router *gin.Engine) {
-	sessionSecret := "secret"
-	if secretEnv := os.Getenv("SESSION_SECRET"); secretEnv != "" {
-		sessionSecret = secretEnv
+func InitRoutes(router *gin.Engine) error {
+	sessionSecret, err := obstore.GetSessionSecret(context.Background())
+	if err != nil {
+		return err
 	}
-	store := cookie.NewStore([]byte(sessionSecret))
+	if !obstore.SessionsShared() {
+		log.Warn("Session secret is not shared, sessions are only accepted by this replica")
+	}
+	store := cookie.NewStore(sessionSecret)
 	store.Options(sessions.Options{
 		HttpOnly: true,
 		MaxAge:   constant.DefaultSessionExpiration,
@@ -63,17 +69,21 @@ func InitRoutes(router *gin.Engine) {
 		router.Use(static.Serve("/api-gen", static.LocalFile("internal/dashboard/generated/swagger", false)))
 	}
 
-	// login api does not require login
+	// login api does not require login, requests rejected by authentication are audited as well
 	v1Group := router.Group("/api/v1",
+		middleware.Audit(),
 		middleware.LoginRequired(),
 		middleware.RefreshExpiration(),
 	)
 
-	// init all routes under /api/v1
-	v1.InitInfoRoutes(v1Group)
-	v1.InitK8sRoutes(v1Group)
-	v1.InitMetricRoutes(v1Group)
-	v1.InitOBClusterRoutes(v1Group)
+	// init all routes under /api/v1, roles of users are checked in groups except for user routes
+	v1.InitInfoRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupInfo)))
+	v1.InitK8sRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupK8s)))
+	v1.InitMetricRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupMetric)))
+	v1.InitOBClusterRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupOBCluster)))
 	v1.InitUserRoutes(v1Group)
-	v1.InitOBTenantRoutes(v1Group)
+	v1.InitOBTenantRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupOBTenant)))
+	v1.InitAPITokenRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupAPIToken)))
+	v1.InitAuditRoutes(v1Group.Group("", middleware.Authorization(middleware.RouteGroupAudit)))
+	return nil
 }
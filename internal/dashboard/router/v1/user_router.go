@@ -20,5 +20,7 @@ import (
 
 func InitUserRoutes(g *gin.RouterGroup) {
 	g.POST("/login", h.Wrap(h.Login))
+	g.GET("/login/oidc", h.OIDCLogin)
+	g.GET("/login/oidc/callback", h.OIDCCallback)
 	g.POST("/logout", h.Wrap(h.Logout))
 }
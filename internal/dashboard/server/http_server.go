@@ -57,8 +57,7 @@ func (s *HTTPServer) Run() error {
 }
 
 func (s *HTTPServer) RegisterRouter() error {
-	router.InitRoutes(s.Router)
-	return nil
+	return router.InitRoutes(s.Router)
 }
 
 func NewHTTPServer() *HTTPServer {
@@ -14,11 +14,13 @@ package _template
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	opresource "github.com/oceanbase/ob-operator/pkg/coordinator"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
@@ -59,6 +61,8 @@ func (m *ObResourceManager[T]) SetOperationContext(*tasktypes.OperationContext)
 
 }
 
+func (m *ObResourceManager[T]) AddFlowRecord(*tasktypes.FlowRecord) {}
+
 func (m *ObResourceManager[T]) ClearTaskInfo() {}
 
 func (m *ObResourceManager[T]) HandleFailure() {}
@@ -69,6 +73,10 @@ func (m *ObResourceManager[T]) UpdateStatus() error {
 	return m.Client.Status().Update(m.Ctx, m.Resource)
 }
 
+func (m *ObResourceManager[T]) GetTaskTimeout(tasktypes.TaskName) time.Duration {
+	return 0
+}
+
 func (m *ObResourceManager[T]) GetTaskFunc(tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	return nil, nil
 }
@@ -83,3 +91,11 @@ func (m *ObResourceManager[T]) PrintErrEvent(err error) {
 
 func (m *ObResourceManager[T]) ArchiveResource() {
 }
+
+func (m *ObResourceManager[T]) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.Resource)
+}
+
+func (m *ObResourceManager[T]) SetPaused(bool) bool {
+	return false
+}
@@ -29,4 +29,5 @@ func init() {
 	task.GetRegistry().Register(fScaleUpOBZones, ScaleUpOBZones)
 	task.GetRegistry().Register(fExpandPVC, ResizePVC)
 	task.GetRegistry().Register(fMountBackupVolume, MountBackupVolume)
+	task.GetRegistry().Register(fRollingRestartOBCluster, RollingRestartOBCluster)
 }
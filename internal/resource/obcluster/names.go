@@ -13,6 +13,9 @@ See the Mulan PSL v2 for more details.
 package obcluster
 
 import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -30,6 +33,7 @@ const (
 	fScaleUpOBZones                  ttypes.FlowName = "scale up obzones"
 	fExpandPVC                       ttypes.FlowName = "expand pvc for obcluster"
 	fMountBackupVolume               ttypes.FlowName = "mount backup volume for obcluster"
+	fRollingRestartOBCluster         ttypes.FlowName = "rolling restart obcluster"
 )
 
 // obcluster tasks
@@ -63,4 +67,26 @@ const (
 	tScaleUpOBZones             ttypes.TaskName = "scale up obzones"
 	tExpandPVC                  ttypes.TaskName = "expand pvc"
 	tMountBackupVolume          ttypes.TaskName = "mount backup volume"
+	tPrepareRollingRestart      ttypes.TaskName = "prepare rolling restart"
+	tFinishRollingRestart       ttypes.TaskName = "finish rolling restart"
+	tCheckResourceForScaling    ttypes.TaskName = "check resource for scaling"
 )
+
+// timeouts of obcluster tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	tCheckImageReady:        3 * time.Hour,
+	tBootstrap:              time.Hour,
+	tModifySysTenantReplica: oceanbaseconst.LocalityChangeTimeoutSeconds * time.Second,
+	tWaitOBZoneDeleted:      oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tBeginUpgrade:           oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+	tRollingUpgradeByZone:   oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tFinishUpgrade:          oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+	tScaleUpOBZones:         oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+	tMountBackupVolume:      oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+}
+
+// tRollingRestartOBZonePrefix prefixes names of rolling restart tasks, one task is added for every zone
+const tRollingRestartOBZonePrefix = "rolling restart obzone "
+
+// rollingRestartOBZoneTimeout limits restart of all observers in one zone
+const rollingRestartOBZoneTimeout = oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package obcluster
 
 import (
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
 	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
@@ -111,8 +112,12 @@ func ScaleUpOBZones() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
 			Name:         fScaleUpOBZones,
-			Tasks:        []tasktypes.TaskName{tScaleUpOBZones, tWaitOBZoneRunning},
+			Tasks:        []tasktypes.TaskName{tCheckResourceForScaling, tScaleUpOBZones, tWaitOBZoneRunning},
 			TargetStatus: clusterstatus.Running,
+			OnFailure: tasktypes.FailureRule{
+				Strategy: strategy.RetryFromCurrent,
+				MaxRetry: oceanbaseconst.ScaleResourceMaxRetryTimes,
+			},
 		},
 	}
 }
@@ -136,3 +141,17 @@ func MountBackupVolume() *tasktypes.TaskFlow {
 		},
 	}
 }
+
+func RollingRestartOBCluster() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fRollingRestartOBCluster,
+			// one task restarting each zone is inserted after preparation, see addRollingRestartOBZoneTasks
+			Tasks:        []tasktypes.TaskName{tPrepareRollingRestart, tFinishRollingRestart, tWaitOBZoneRunning},
+			TargetStatus: clusterstatus.Running,
+			OnFailure: tasktypes.FailureRule{
+				Strategy: strategy.RetryFromCurrent,
+			},
+		},
+	}
+}
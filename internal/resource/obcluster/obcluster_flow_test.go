@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obcluster
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
+	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+var _ = Describe("OBCluster task flows", func() {
+	newManager := func(status string) *OBClusterManager {
+		logger := logr.Discard()
+		obcluster := &v1alpha1.OBCluster{}
+		obcluster.Spec.Topology = []apitypes.OBZoneTopology{{Zone: "z1"}, {Zone: "z2"}, {Zone: "z3"}}
+		obcluster.Status.Status = status
+		return &OBClusterManager{OBCluster: obcluster, Logger: &logger}
+	}
+
+	It("Restarts zones one by one in tasks of their own", func() {
+		m := newManager(clusterstatus.RollingRestart)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Tasks).To(Equal([]tasktypes.TaskName{
+			tPrepareRollingRestart,
+			"rolling restart obzone z1",
+			"rolling restart obzone z2",
+			"rolling restart obzone z3",
+			tFinishRollingRestart,
+			tWaitOBZoneRunning,
+		}))
+		for _, name := range flow.OperationContext.Tasks {
+			taskFunc, err := m.GetTaskFunc(name)
+			Expect(err).To(BeNil())
+			Expect(taskFunc).NotTo(BeNil())
+		}
+		Expect(m.GetTaskTimeout("rolling restart obzone z2")).To(Equal(rollingRestartOBZoneTimeout))
+	})
+
+	It("Resumes the flow from the zone being restarted", func() {
+		m := newManager(clusterstatus.RollingRestart)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		flow.NextTask()
+		flow.NextTask()
+		flow.NextTask()
+		m.OBCluster.Status.OperationContext = flow.OperationContext
+		// topology changes while the flow is running should not change the persisted flow
+		m.OBCluster.Spec.Topology = m.OBCluster.Spec.Topology[:1]
+		resumed, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(resumed.OperationContext.Task).To(Equal(tasktypes.TaskName("rolling restart obzone z2")))
+		Expect(resumed.OperationContext.Tasks).To(HaveLen(6))
+	})
+
+	It("Parses zones of rolling restart tasks", func() {
+		zone, ok := rollingRestartOBZoneOfTask("rolling restart obzone zone-a")
+		Expect(ok).To(BeTrue())
+		Expect(zone).To(Equal("zone-a"))
+		_, ok = rollingRestartOBZoneOfTask(tPrepareRollingRestart)
+		Expect(ok).To(BeFalse())
+		_, ok = rollingRestartOBZoneOfTask(tRollingRestartOBZonePrefix)
+		Expect(ok).To(BeFalse())
+		_, err := newManager(clusterstatus.Running).GetTaskFunc("rolling restart obzone")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("Gives up scaling resources after limited retries", func() {
+		flow := ScaleUpOBZones()
+		Expect(flow.OperationContext.Tasks[0]).To(Equal(tCheckResourceForScaling))
+		Expect(flow.OperationContext.OnFailure.Strategy).To(BeEquivalentTo(strategy.RetryFromCurrent))
+		Expect(flow.OperationContext.OnFailure.MaxRetry).To(Equal(oceanbaseconst.ScaleResourceMaxRetryTimes))
+	})
+})
@@ -14,6 +14,7 @@ package obcluster
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -71,6 +72,22 @@ func (m *OBClusterManager) SetOperationContext(c *tasktypes.OperationContext) {
 	m.OBCluster.Status.OperationContext = c
 }
 
+func (m *OBClusterManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.OBCluster.Status.FlowHistory = tasktypes.AppendFlowRecord(m.OBCluster.Status.FlowHistory, record)
+}
+
+func (m *OBClusterManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.OBCluster)
+}
+
+func (m *OBClusterManager) SetPaused(paused bool) bool {
+	if m.OBCluster.Status.Paused == paused {
+		return false
+	}
+	m.OBCluster.Status.Paused = paused
+	return true
+}
+
 func (m *OBClusterManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 	// exists unfinished task flow, return the last task flow
 	if m.OBCluster.Status.OperationContext != nil {
@@ -108,6 +125,11 @@ func (m *OBClusterManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 		taskFlow, err = task.GetRegistry().Get(fExpandPVC)
 	case clusterstatus.MountBackupVolume:
 		taskFlow, err = task.GetRegistry().Get(fMountBackupVolume)
+	case clusterstatus.RollingRestart:
+		taskFlow, err = task.GetRegistry().Get(fRollingRestartOBCluster)
+		if err == nil {
+			addRollingRestartOBZoneTasks(taskFlow, m.OBCluster.Spec.Topology)
+		}
 	default:
 		m.Logger.V(oceanbaseconst.LogLevelTrace).Info("No need to run anything for obcluster", "obcluster", m.OBCluster.Name)
 		return nil, nil
@@ -140,6 +162,9 @@ func (m *OBClusterManager) CheckAndUpdateFinalizers() error {
 }
 
 func (m *OBClusterManager) UpdateStatus() error {
+	if m.OBCluster.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	// update obzone status
 	obzoneList, err := m.listOBZones()
 	if err != nil {
@@ -180,68 +205,12 @@ func (m *OBClusterManager) UpdateStatus() error {
 			m.OBCluster.Status.Image = m.OBCluster.Spec.OBServerTemplate.Image
 		}
 
-		if len(m.OBCluster.Spec.Topology) > len(obzoneList.Items) {
-			m.Logger.Info("Compare topology need add zone")
-			m.OBCluster.Status.Status = clusterstatus.AddOBZone
-		} else if len(m.OBCluster.Spec.Topology) < len(obzoneList.Items) {
-			m.Logger.Info("Compare topology need delete zone")
-			m.OBCluster.Status.Status = clusterstatus.DeleteOBZone
-		} else {
-			modeAnnoVal, modeAnnoExist := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsMode)
-		outer:
-			for _, obzone := range obzoneList.Items {
-				if modeAnnoExist && modeAnnoVal == oceanbaseconst.ModeStandalone && m.checkIfCalcResourceChange(&obzone) {
-					m.OBCluster.Status.Status = clusterstatus.ScaleUp
-					break outer
-				}
-				if m.checkIfStorageSizeExpand(&obzone) {
-					m.OBCluster.Status.Status = clusterstatus.ExpandPVC
-					break outer
-				}
-				if m.checkIfBackupVolumeAdded(&obzone) {
-					m.OBCluster.Status.Status = clusterstatus.MountBackupVolume
-					break outer
-				}
-				for _, zone := range m.OBCluster.Spec.Topology {
-					if zone.Zone == obzone.Spec.Topology.Zone {
-						if zone.Replica != len(obzone.Status.OBServerStatus) {
-							m.OBCluster.Status.Status = clusterstatus.ModifyOBZoneReplica
-							break outer
-						}
-					}
-				}
-			}
-		}
+		m.updateMajorCompactionStatus()
 
-		// check for upgrade
-		if m.OBCluster.Status.Status == clusterstatus.Running {
-			if m.OBCluster.Spec.OBServerTemplate.Image != m.OBCluster.Status.Image {
-				m.Logger.Info("Check obcluster image not match, need upgrade")
-				m.OBCluster.Status.Status = clusterstatus.Upgrade
-			}
-		}
+		m.updatePlan(obzoneList)
 
-		// only do this when obzone matched, thus obcluster status is running after obzone check
-		if m.OBCluster.Status.Status == clusterstatus.Running {
-			parameterMap := make(map[string]apitypes.Parameter)
-			for _, parameter := range m.OBCluster.Status.Parameters {
-				m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Build parameter map", "parameter", parameter.Name)
-				parameterMap[parameter.Name] = parameter
-			}
-			for _, parameter := range m.OBCluster.Spec.Parameters {
-				parameterStatus, parameterExists := parameterMap[parameter.Name]
-				// need create or update parameter
-				if !parameterExists || parameterStatus.Value != parameter.Value {
-					m.OBCluster.Status.Status = clusterstatus.ModifyOBParameter
-					break
-				}
-				delete(parameterMap, parameter.Name)
-			}
-
-			// need delete parameter
-			if len(parameterMap) > 0 {
-				m.OBCluster.Status.Status = clusterstatus.ModifyOBParameter
-			}
+		if statuses := m.pendingStatuses(obzoneList); len(statuses) > 0 {
+			m.OBCluster.Status.Status = statuses[0]
 		}
 	}
 	m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Update obcluster status", "status", m.OBCluster.Status)
@@ -282,6 +251,16 @@ func (m *OBClusterManager) HandleFailure() {
 	}
 }
 
+func (m *OBClusterManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	if timeout, ok := taskTimeouts[name]; ok {
+		return timeout
+	}
+	if _, ok := rollingRestartOBZoneOfTask(name); ok {
+		return rollingRestartOBZoneTimeout
+	}
+	return 0
+}
+
 func (m *OBClusterManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tCheckMigration:
@@ -332,13 +311,22 @@ func (m *OBClusterManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskF
 		return m.CreateServiceForMonitor, nil
 	case tModifySysTenantReplica:
 		return m.ModifySysTenantReplica, nil
+	case tCheckResourceForScaling:
+		return m.CheckResourceForScaling, nil
 	case tScaleUpOBZones:
-		return m.modifyOBZonesAndCheckStatus(m.changeZonesWhenScaling, zonestatus.ScaleUp, oceanbaseconst.DefaultStateWaitTimeout), nil
+		return m.ScaleUpOBZones, nil
 	case tExpandPVC:
 		return m.modifyOBZonesAndCheckStatus(m.changeZonesWhenExpandingPVC, zonestatus.ExpandPVC, oceanbaseconst.DefaultStateWaitTimeout), nil
 	case tMountBackupVolume:
 		return m.rollingUpdateZones(m.changeZonesWhenMountingBackupVolume, zonestatus.MountBackupVolume, zonestatus.Running, oceanbaseconst.DefaultStateWaitTimeout), nil
+	case tPrepareRollingRestart:
+		return m.PrepareRollingRestart, nil
+	case tFinishRollingRestart:
+		return m.FinishRollingRestart, nil
 	default:
+		if zone, ok := rollingRestartOBZoneOfTask(name); ok {
+			return m.RollingRestartOBZone(zone), nil
+		}
 		return nil, errors.New("Can not find a function for task")
 	}
 }
@@ -13,8 +13,10 @@ See the Mulan PSL v2 for more details.
 package obcluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,12 +45,12 @@ import (
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
-func (m *OBClusterManager) WaitOBZoneTopologyMatch() tasktypes.TaskError {
+func (m *OBClusterManager) WaitOBZoneTopologyMatch(ctx context.Context) tasktypes.TaskError {
 	// TODO
 	return nil
 }
 
-func (m *OBClusterManager) WaitOBZoneDeleted() tasktypes.TaskError {
+func (m *OBClusterManager) WaitOBZoneDeleted(ctx context.Context) tasktypes.TaskError {
 	waitSuccess := false
 	for i := 1; i < oceanbaseconst.ServerDeleteTimeoutSeconds; i++ {
 		obcluster, err := m.getOBCluster()
@@ -75,7 +77,9 @@ func (m *OBClusterManager) WaitOBZoneDeleted() tasktypes.TaskError {
 			waitSuccess = true
 			break
 		}
-		time.Sleep(time.Second * 1)
+		if err := resourceutils.SleepWithContext(ctx, time.Second*1); err != nil {
+			return err
+		}
 	}
 	if waitSuccess {
 		return nil
@@ -83,8 +87,8 @@ func (m *OBClusterManager) WaitOBZoneDeleted() tasktypes.TaskError {
 	return errors.Errorf("OBCluster %s zone still not deleted when timeout", m.OBCluster.Name)
 }
 
-func (m *OBClusterManager) generateWaitOBZoneStatusFunc(status string, timeoutSeconds int) func() tasktypes.TaskError {
-	f := func() tasktypes.TaskError {
+func (m *OBClusterManager) generateWaitOBZoneStatusFunc(status string, timeoutSeconds int) func(ctx context.Context) tasktypes.TaskError {
+	f := func(ctx context.Context) tasktypes.TaskError {
 		for i := 1; i < timeoutSeconds; i++ {
 			obcluster, err := m.getOBCluster()
 			if err != nil {
@@ -101,14 +105,16 @@ func (m *OBClusterManager) generateWaitOBZoneStatusFunc(status string, timeoutSe
 			if allMatched {
 				return nil
 			}
-			time.Sleep(time.Second)
+			if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+				return err
+			}
 		}
 		return errors.New("Zone status still not matched when timeout")
 	}
 	return f
 }
 
-func (m *OBClusterManager) ModifyOBZoneReplica() tasktypes.TaskError {
+func (m *OBClusterManager) ModifyOBZoneReplica(ctx context.Context) tasktypes.TaskError {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		obzoneList, err := m.listOBZones()
 		if err != nil {
@@ -120,7 +126,7 @@ func (m *OBClusterManager) ModifyOBZoneReplica() tasktypes.TaskError {
 				if zone.Zone == obzone.Spec.Topology.Zone && zone.Replica != obzone.Spec.Topology.Replica {
 					m.Logger.Info("Modify obzone replica", "obzone", zone.Zone)
 					obzone.Spec.Topology.Replica = zone.Replica
-					err = m.Client.Update(m.Ctx, &obzone)
+					err = m.Client.Update(ctx, &obzone)
 					if err != nil {
 						return errors.Wrapf(err, "Modify obzone %s replica failed", zone.Zone)
 					}
@@ -131,13 +137,13 @@ func (m *OBClusterManager) ModifyOBZoneReplica() tasktypes.TaskError {
 	})
 }
 
-func (m *OBClusterManager) DeleteOBZone() tasktypes.TaskError {
+func (m *OBClusterManager) DeleteOBZone(ctx context.Context) tasktypes.TaskError {
 	zonesToDelete, err := m.getZonesToDelete()
 	if err != nil {
 		return errors.Wrap(err, "Failed to get obzones to delete")
 	}
 	for _, zone := range zonesToDelete {
-		err = m.Client.Delete(m.Ctx, &zone)
+		err = m.Client.Delete(ctx, &zone)
 		if err != nil {
 			return errors.Wrapf(err, "Delete obzone %s", zone.Name)
 		}
@@ -146,7 +152,7 @@ func (m *OBClusterManager) DeleteOBZone() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) CreateOBZone() tasktypes.TaskError {
+func (m *OBClusterManager) CreateOBZone(ctx context.Context) tasktypes.TaskError {
 	m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Create obzones")
 	blockOwnerDeletion := true
 	ownerReferenceList := make([]metav1.OwnerReference, 0)
@@ -212,7 +218,7 @@ func (m *OBClusterManager) CreateOBZone() tasktypes.TaskError {
 			obzone.ObjectMeta.Annotations[oceanbaseconst.AnnotationsSourceClusterAddress] = migrateAnnoVal
 		}
 		m.Logger.Info("Create obzone", "zone", zoneName)
-		err := m.Client.Create(m.Ctx, obzone)
+		err := m.Client.Create(ctx, obzone)
 		if err != nil {
 			m.Logger.Error(err, "create obzone failed", "zone", zone.Zone)
 			return errors.Wrap(err, "create obzone")
@@ -222,7 +228,7 @@ func (m *OBClusterManager) CreateOBZone() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) Bootstrap() tasktypes.TaskError {
+func (m *OBClusterManager) Bootstrap(ctx context.Context) tasktypes.TaskError {
 	obzoneList, err := m.listOBZones()
 	if err != nil {
 		m.Logger.Error(err, "list obzones failed")
@@ -237,7 +243,9 @@ func (m *OBClusterManager) Bootstrap() tasktypes.TaskError {
 		manager, err = m.getOceanbaseOperationManager()
 		if err != nil || manager == nil {
 			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Get oceanbase operation manager failed")
-			time.Sleep(time.Second * oceanbaseconst.CheckConnectionInterval)
+			if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CheckConnectionInterval); err != nil {
+				return err
+			}
 		} else {
 			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Successfully got oceanbase operation manager")
 			break
@@ -291,7 +299,7 @@ func (m *OBClusterManager) Bootstrap() tasktypes.TaskError {
 }
 
 // Use Or for compatibility
-func (m *OBClusterManager) CreateUsers() tasktypes.TaskError {
+func (m *OBClusterManager) CreateUsers(ctx context.Context) tasktypes.TaskError {
 	err := m.createUser(oceanbaseconst.RootUser, m.OBCluster.Spec.UserSecrets.Root, oceanbaseconst.AllPrivilege)
 	if err != nil {
 		return errors.Wrap(err, "Create root user")
@@ -311,7 +319,7 @@ func (m *OBClusterManager) CreateUsers() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) MaintainOBParameter() tasktypes.TaskError {
+func (m *OBClusterManager) MaintainOBParameter(ctx context.Context) tasktypes.TaskError {
 	parameterMap := make(map[string]apitypes.Parameter)
 	for _, parameter := range m.OBCluster.Status.Parameters {
 		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Build parameter map", "parameter", parameter.Name)
@@ -421,7 +429,7 @@ func (m *OBClusterManager) DeleteOBParameter(parameter *apitypes.Parameter) erro
 	return nil
 }
 
-func (m *OBClusterManager) ValidateUpgradeInfo() tasktypes.TaskError {
+func (m *OBClusterManager) ValidateUpgradeInfo(ctx context.Context) tasktypes.TaskError {
 	// Get current obcluster version
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
@@ -459,14 +467,16 @@ func (m *OBClusterManager) ValidateUpgradeInfo() tasktypes.TaskError {
 	}
 
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Create validate upgrade job", "job", jobName)
-	err = m.Client.Create(m.Ctx, &job)
+	err = m.Client.Create(ctx, &job)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to create validate job for obcluster %s", m.OBCluster.Name)
 	}
 
 	var jobObject *batchv1.Job
 	for {
-		time.Sleep(time.Second * oceanbaseconst.CheckJobInterval)
+		if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CheckJobInterval); err != nil {
+			return err
+		}
 		jobObject, err = resourceutils.GetJob(m.Client, m.OBCluster.Namespace, jobName)
 		if err != nil {
 			m.Logger.Error(err, "Failed to get job")
@@ -488,11 +498,11 @@ func (m *OBClusterManager) ValidateUpgradeInfo() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) UpgradeCheck() tasktypes.TaskError {
+func (m *OBClusterManager) UpgradeCheck(ctx context.Context) tasktypes.TaskError {
 	return resourceutils.ExecuteUpgradeScript(m.Client, m.Logger, m.OBCluster, oceanbaseconst.UpgradeCheckerScriptPath, "")
 }
 
-func (m *OBClusterManager) BackupEssentialParameters() tasktypes.TaskError {
+func (m *OBClusterManager) BackupEssentialParameters(ctx context.Context) tasktypes.TaskError {
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get operation manager of obcluster %s", m.OBCluster.Name)
@@ -521,19 +531,19 @@ func (m *OBClusterManager) BackupEssentialParameters() tasktypes.TaskError {
 		Type:       "Opaque",
 		StringData: contextMap,
 	}
-	err = m.Client.Create(m.Ctx, contextSecret)
+	err = m.Client.Create(ctx, contextSecret)
 	if err != nil {
 		return errors.Wrap(err, "Create context secret object")
 	}
 	return nil
 }
 
-func (m *OBClusterManager) BeginUpgrade() tasktypes.TaskError {
+func (m *OBClusterManager) BeginUpgrade(ctx context.Context) tasktypes.TaskError {
 	return resourceutils.ExecuteUpgradeScript(m.Client, m.Logger, m.OBCluster, oceanbaseconst.UpgradePreScriptPath, "")
 }
 
 // TODO: add timeout
-func (m *OBClusterManager) WaitOBZoneUpgradeFinished(zoneName string) error {
+func (m *OBClusterManager) WaitOBZoneUpgradeFinished(ctx context.Context, zoneName string) error {
 	upgradeFinished := false
 	for {
 		zones, err := m.listOBZones()
@@ -554,13 +564,15 @@ func (m *OBClusterManager) WaitOBZoneUpgradeFinished(zoneName string) error {
 			m.Logger.Info("OBZone upgrade finished", "obzone", zoneName)
 			break
 		}
-		time.Sleep(time.Second * oceanbaseconst.CommonCheckInterval)
+		if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CommonCheckInterval); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // TODO: add timeout
-func (m *OBClusterManager) RollingUpgradeByZone() tasktypes.TaskError {
+func (m *OBClusterManager) RollingUpgradeByZone(ctx context.Context) tasktypes.TaskError {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		zones, err := m.listOBZones()
 		if err != nil {
@@ -569,11 +581,11 @@ func (m *OBClusterManager) RollingUpgradeByZone() tasktypes.TaskError {
 		for _, zone := range zones.Items {
 			// update image and tag
 			zone.Spec.OBServerTemplate.Image = m.OBCluster.Spec.OBServerTemplate.Image
-			err = m.Client.Update(m.Ctx, &zone)
+			err = m.Client.Update(ctx, &zone)
 			if err != nil {
 				return errors.Wrap(err, "Failed to update obzone image")
 			}
-			err = m.WaitOBZoneUpgradeFinished(zone.Name)
+			err = m.WaitOBZoneUpgradeFinished(ctx, zone.Name)
 			if err != nil {
 				return errors.Wrapf(err, "Wait obzone %s upgrade finish failed", zone.Name)
 			}
@@ -582,11 +594,11 @@ func (m *OBClusterManager) RollingUpgradeByZone() tasktypes.TaskError {
 	})
 }
 
-func (m *OBClusterManager) FinishUpgrade() tasktypes.TaskError {
+func (m *OBClusterManager) FinishUpgrade(ctx context.Context) tasktypes.TaskError {
 	return resourceutils.ExecuteUpgradeScript(m.Client, m.Logger, m.OBCluster, oceanbaseconst.UpgradePostScriptPath, "")
 }
 
-func (m *OBClusterManager) ModifySysTenantReplica() tasktypes.TaskError {
+func (m *OBClusterManager) ModifySysTenantReplica(ctx context.Context) tasktypes.TaskError {
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get operation manager of obcluster %s", m.OBCluster.Name)
@@ -704,7 +716,7 @@ func (m *OBClusterManager) ModifySysTenantReplica() tasktypes.TaskError {
 	})
 }
 
-func (m *OBClusterManager) CreateServiceForMonitor() tasktypes.TaskError {
+func (m *OBClusterManager) CreateServiceForMonitor(ctx context.Context) tasktypes.TaskError {
 	ownerReferenceList := make([]metav1.OwnerReference, 0)
 	ownerReference := metav1.OwnerReference{
 		APIVersion: m.OBCluster.APIVersion,
@@ -734,7 +746,7 @@ func (m *OBClusterManager) CreateServiceForMonitor() tasktypes.TaskError {
 			Type:     corev1.ServiceTypeClusterIP,
 		},
 	}
-	err := m.Client.Create(m.Ctx, &monitorService)
+	err := m.Client.Create(ctx, &monitorService)
 	if err != nil {
 		return errors.Wrap(err, "Create monitor service")
 	}
@@ -742,7 +754,7 @@ func (m *OBClusterManager) CreateServiceForMonitor() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) RestoreEssentialParameters() tasktypes.TaskError {
+func (m *OBClusterManager) RestoreEssentialParameters(ctx context.Context) tasktypes.TaskError {
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get operation manager of obcluster %s", m.OBCluster.Name)
@@ -751,7 +763,7 @@ func (m *OBClusterManager) RestoreEssentialParameters() tasktypes.TaskError {
 
 	contextObjectName := fmt.Sprintf("%s-%d-%s", m.OBCluster.Spec.ClusterName, m.OBCluster.Spec.ClusterId, oceanbaseconst.EssentialParametersKey)
 	contextSecret := &corev1.Secret{}
-	err = m.Client.Get(m.Ctx, types.NamespacedName{
+	err = m.Client.Get(ctx, types.NamespacedName{
 		Namespace: m.OBCluster.Namespace,
 		Name:      contextObjectName,
 	}, contextSecret)
@@ -778,12 +790,12 @@ func (m *OBClusterManager) RestoreEssentialParameters() tasktypes.TaskError {
 			return errors.Wrapf(err, "Failed to set parameter %s to %s:%d", parameter.Name, parameter.SvrIp, parameter.SvrPort)
 		}
 	}
-	_ = m.Client.Delete(m.Ctx, contextSecret)
+	_ = m.Client.Delete(ctx, contextSecret)
 	m.Recorder.Event(m.OBCluster, "Upgrade", "", "Restore essential parameters successfully")
 	return nil
 }
 
-func (m *OBClusterManager) CheckAndCreateUserSecrets() tasktypes.TaskError {
+func (m *OBClusterManager) CheckAndCreateUserSecrets(ctx context.Context) tasktypes.TaskError {
 	secretList := []string{
 		m.OBCluster.Spec.UserSecrets.Operator,
 		m.OBCluster.Spec.UserSecrets.Monitor,
@@ -791,13 +803,13 @@ func (m *OBClusterManager) CheckAndCreateUserSecrets() tasktypes.TaskError {
 	}
 	for _, secret := range secretList {
 		fetchedSec := &corev1.Secret{}
-		err := m.Client.Get(m.Ctx, types.NamespacedName{
+		err := m.Client.Get(ctx, types.NamespacedName{
 			Namespace: m.OBCluster.Namespace,
 			Name:      secret,
 		}, fetchedSec)
 		if err != nil {
 			if kubeerrors.IsNotFound(err) {
-				err := m.Client.Create(m.Ctx, &corev1.Secret{
+				err := m.Client.Create(ctx, &corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      secret,
 						Namespace: m.OBCluster.Namespace,
@@ -815,10 +827,10 @@ func (m *OBClusterManager) CheckAndCreateUserSecrets() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) CreateServices() tasktypes.TaskError {
+func (m *OBClusterManager) CreateServices(ctx context.Context) tasktypes.TaskError {
 	modeAnnoVal, modeAnnoExist := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsMode)
 	if modeAnnoExist && modeAnnoVal == oceanbaseconst.ModeStandalone {
-		err := m.Client.Create(m.Ctx, &corev1.Service{
+		err := m.Client.Create(ctx, &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      m.OBCluster.GetName() + "-standalone-svc",
 				Namespace: m.OBCluster.GetNamespace(),
@@ -852,7 +864,7 @@ func (m *OBClusterManager) CreateServices() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) CheckImageReady() tasktypes.TaskError {
+func (m *OBClusterManager) CheckImageReady(ctx context.Context) tasktypes.TaskError {
 	jobName := "image-pull-ready-" + rand.String(8)
 	var ttl int32 = 120
 	var backoffLimit int32 = 32
@@ -883,7 +895,7 @@ func (m *OBClusterManager) CheckImageReady() tasktypes.TaskError {
 			BackoffLimit:            &backoffLimit,
 		},
 	}
-	err := m.Client.Create(m.Ctx, checkImagePullJob)
+	err := m.Client.Create(ctx, checkImagePullJob)
 	if err != nil {
 		return errors.Wrap(err, "Create check image pull job")
 	}
@@ -899,7 +911,7 @@ func (m *OBClusterManager) CheckImageReady() tasktypes.TaskError {
 outerLoop:
 	for i := 0; i < checkImagePullReadyMaxTimes; i++ {
 		podList := &corev1.PodList{}
-		err = m.Client.List(m.Ctx, podList, &client.ListOptions{
+		err = m.Client.List(ctx, podList, &client.ListOptions{
 			LabelSelector: selector,
 		})
 		if err != nil {
@@ -907,7 +919,9 @@ outerLoop:
 		}
 		if len(podList.Items) == 0 {
 			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("No pod found for check image pull job")
-			time.Sleep(time.Second * oceanbaseconst.CheckJobInterval)
+			if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CheckJobInterval); err != nil {
+				return err
+			}
 			continue
 		}
 		pod := podList.Items[0]
@@ -929,7 +943,9 @@ outerLoop:
 					default:
 						m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Container is waiting", "reason", containerStatus.State.Waiting.Reason, "message", containerStatus.State.Waiting.Message)
 					}
-					time.Sleep(time.Second * oceanbaseconst.CheckJobInterval)
+					if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CheckJobInterval); err != nil {
+						return err
+					}
 					continue outerLoop
 				} else if containerStatus.State.Running != nil || containerStatus.State.Terminated != nil {
 					m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Container is running or terminated")
@@ -945,7 +961,7 @@ outerLoop:
 	return nil
 }
 
-func (m *OBClusterManager) CheckClusterMode() tasktypes.TaskError {
+func (m *OBClusterManager) CheckClusterMode(ctx context.Context) tasktypes.TaskError {
 	var err error
 	modeAnnoVal, modeAnnoExist := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsMode)
 	if modeAnnoExist && modeAnnoVal == oceanbaseconst.ModeStandalone {
@@ -980,7 +996,7 @@ func (m *OBClusterManager) CheckClusterMode() tasktypes.TaskError {
 		}
 		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Create check version job", "job", jobName)
 
-		err = m.Client.Create(m.Ctx, standaloneValidateJob)
+		err = m.Client.Create(ctx, standaloneValidateJob)
 		if err != nil {
 			return errors.Wrap(err, "Create check version job")
 		}
@@ -988,7 +1004,9 @@ func (m *OBClusterManager) CheckClusterMode() tasktypes.TaskError {
 		var jobObject *batchv1.Job
 		var maxCheckTimes = 600
 		for i := 0; i < maxCheckTimes; i++ {
-			time.Sleep(time.Second * oceanbaseconst.CheckJobInterval)
+			if err := resourceutils.SleepWithContext(ctx, time.Second*oceanbaseconst.CheckJobInterval); err != nil {
+				return err
+			}
 			jobObject, err = resourceutils.GetJob(m.Client, m.OBCluster.Namespace, jobName)
 			if err != nil {
 				m.Logger.Error(err, "Failed to get job")
@@ -1011,7 +1029,7 @@ func (m *OBClusterManager) CheckClusterMode() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBClusterManager) CheckMigration() tasktypes.TaskError {
+func (m *OBClusterManager) CheckMigration(ctx context.Context) tasktypes.TaskError {
 	m.Logger.Info("Check before migration")
 	manager, err := m.getOceanbaseOperationManager()
 	if err != nil {
@@ -1083,3 +1101,196 @@ func (m *OBClusterManager) CheckMigration() tasktypes.TaskError {
 	}
 	return nil
 }
+
+// PrepareRollingRestart resets progress of the former rolling restart if a new one is requested,
+// and refuses to restart observers which could not keep their ip addresses
+func (m *OBClusterManager) PrepareRollingRestart(ctx context.Context) tasktypes.TaskError {
+	for _, zone := range m.OBCluster.Spec.Topology {
+		observerList, err := m.listOBServersOfZone(ctx, zone.Zone)
+		if err != nil {
+			return errors.Wrapf(err, "List observers of zone %s", zone.Zone)
+		}
+		for _, observer := range observerList.Items {
+			if !observer.SupportStaticIP() {
+				return errors.Errorf("OBServer %s can not keep its ip address after restart", observer.Name)
+			}
+		}
+	}
+	restartAt, _ := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsRestartAt)
+	_, err := m.updateRollingRestartStatus(ctx, func(s *apitypes.RollingRestartStatus) {
+		// a new restart request resets the progress of the previous one
+		if s.RestartAt != restartAt {
+			*s = apitypes.RollingRestartStatus{RestartAt: restartAt}
+		}
+		s.Finished = false
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to init rolling restart status")
+	}
+	return nil
+}
+
+// RollingRestartOBZone returns the task restarting observers of the zone, zones already restarted are skipped
+func (m *OBClusterManager) RollingRestartOBZone(zoneName string) tasktypes.TaskFunc {
+	return func(ctx context.Context) tasktypes.TaskError {
+		restartStatus, err := m.updateRollingRestartStatus(ctx, func(s *apitypes.RollingRestartStatus) {
+			for _, restartedZone := range s.RestartedZones {
+				if restartedZone == zoneName {
+					return
+				}
+			}
+			// the time is kept when the task is retried on the same zone
+			if s.CurrentZone != zoneName || s.CurrentZoneRestartAt == "" {
+				s.CurrentZone = zoneName
+				s.CurrentZoneRestartAt = metav1.Now().Format(time.RFC3339)
+			}
+		})
+		if err != nil {
+			return errors.Wrap(err, "Failed to update rolling restart status")
+		}
+		if restartStatus.CurrentZone != zoneName {
+			m.Logger.Info("OBZone already restarted, skip it", "zone", zoneName)
+			return nil
+		}
+		restartAt, err := time.Parse(time.RFC3339, restartStatus.CurrentZoneRestartAt)
+		if err != nil {
+			return errors.Wrapf(err, "Parse restart time of zone %s", zoneName)
+		}
+		oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get operation manager of obcluster %s", m.OBCluster.Name)
+		}
+		m.Recorder.Event(m.OBCluster, "Normal", "RollingRestartOBZone", "Rolling restart obzone "+zoneName)
+		err = m.restartOBZone(ctx, oceanbaseOperationManager, zoneName, &metav1.Time{Time: restartAt})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to restart obzone %s", zoneName)
+		}
+		_, err = m.updateRollingRestartStatus(ctx, func(s *apitypes.RollingRestartStatus) {
+			s.CurrentZone = ""
+			s.CurrentZoneRestartAt = ""
+			s.RestartedZones = append(s.RestartedZones, zoneName)
+		})
+		if err != nil {
+			return errors.Wrap(err, "Failed to update rolling restart status")
+		}
+		return nil
+	}
+}
+
+func (m *OBClusterManager) FinishRollingRestart(ctx context.Context) tasktypes.TaskError {
+	_, err := m.updateRollingRestartStatus(ctx, func(s *apitypes.RollingRestartStatus) {
+		s.Finished = true
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to update rolling restart status")
+	}
+	m.Recorder.Event(m.OBCluster, "Normal", "RollingRestartFinished", "Rolling restart of obcluster finished")
+	return nil
+}
+
+// CheckResourceForScaling checks that units hosted on every observer still fit after cpu or memory of observers changes,
+// no pod would be touched if any observer is not able to hold its units with the new resource
+func (m *OBClusterManager) CheckResourceForScaling(ctx context.Context) tasktypes.TaskError {
+	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get operation manager of obcluster %s", m.OBCluster.Name)
+	}
+	memoryLimits, err := oceanbaseOperationManager.GetParameter("memory_limit", nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get parameter memory_limit")
+	}
+	memoryLimitPercentages, err := oceanbaseOperationManager.GetParameter("memory_limit_percentage", nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get parameter memory_limit_percentage")
+	}
+	systemMemories, err := oceanbaseOperationManager.GetParameter("system_memory", nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get parameter system_memory")
+	}
+
+	newResource := m.OBCluster.Spec.OBServerTemplate.Resource
+	cpuCount := resourceutils.GetCpuCount(newResource.Cpu)
+	newMemory := newResource.Memory.Value()
+	newMemoryLimit := newMemory * oceanbaseconst.DefaultMemoryLimitPercent / 100
+	if value, exist := m.getSpecParameter("memory_limit"); exist {
+		if newMemoryLimit, err = obutil.ParseCapacity(value); err != nil {
+			return errors.Wrap(err, "Failed to parse memory_limit")
+		}
+	}
+	if newMemoryLimit > newMemory {
+		return errors.Errorf("memory_limit %d exceeds memory %d of observer, memory_limit in parameters should be decreased as well", newMemoryLimit, newMemory)
+	}
+
+	obzoneList, err := m.listOBZones()
+	if err != nil {
+		return errors.Wrap(err, "list obzones")
+	}
+	for _, obzone := range obzoneList.Items {
+		if !m.checkIfCalcResourceChange(&obzone) {
+			continue
+		}
+		zoneName := obzone.Spec.Topology.Zone
+		observerList, err := m.listOBServersOfZone(ctx, zoneName)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to list observers of obzone %s", zoneName)
+		}
+		if len(observerList.Items) == 0 {
+			continue
+		}
+		resourceTotal, err := oceanbaseOperationManager.GetResourceTotal(zoneName)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get resource of obzone %s", zoneName)
+		}
+		for _, observer := range observerList.Items {
+			serverIP := observer.Status.GetConnectAddr()
+			capacity := obutil.ServerCapacity{
+				CpuCount:    cpuCount,
+				MemoryLimit: newMemoryLimit,
+			}
+			if newMemoryLimit == 0 {
+				percentage := int64(oceanbaseconst.DefaultMemoryLimitPercent)
+				if value := getParameterValueOfServer(memoryLimitPercentages, serverIP); value != "" {
+					percentage, err = strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return errors.Wrap(err, "Failed to parse memory_limit_percentage")
+					}
+				}
+				capacity.MemoryLimit = newMemory * percentage / 100
+			}
+			capacity.SystemMemory, err = obutil.ParseCapacity(getParameterValueOfServer(systemMemories, serverIP))
+			if err != nil {
+				return errors.Wrap(err, "Failed to parse system_memory")
+			}
+			if capacity.SystemMemory == 0 {
+				// system memory is calculated by observer itself, take the reserved part of current memory
+				currentMemoryLimit, err := obutil.ParseCapacity(getParameterValueOfServer(memoryLimits, serverIP))
+				if err != nil {
+					return errors.Wrap(err, "Failed to parse memory_limit")
+				}
+				if currentMemoryLimit == 0 {
+					currentMemoryLimit = obzone.Spec.OBServerTemplate.Resource.Memory.Value() * oceanbaseconst.DefaultMemoryLimitPercent / 100
+				}
+				capacity.SystemMemory = currentMemoryLimit - resourceTotal.MemTotal/int64(len(observerList.Items))
+			}
+			units, err := oceanbaseOperationManager.ListUnitsWithServerIP(serverIP)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to list units of observer %s", observer.Name)
+			}
+			err = obutil.CheckUnitsFitServer(units, capacity)
+			if err != nil {
+				m.Recorder.Event(m.OBCluster, corev1.EventTypeWarning, "ResourceNotEnough", fmt.Sprintf("Units on observer %s do not fit new resource: %s", observer.Name, err.Error()))
+				return errors.Wrapf(err, "Units on observer %s do not fit new resource", observer.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OBClusterManager) ScaleUpOBZones(ctx context.Context) tasktypes.TaskError {
+	mode, modeExist := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsMode)
+	if modeExist && mode == oceanbaseconst.ModeStandalone {
+		return m.modifyOBZonesAndCheckStatus(m.changeZonesWhenScaling, zonestatus.ScaleUp, oceanbaseconst.DefaultStateWaitTimeout)(ctx)
+	}
+	// observers of the other zones keep serving while one zone is being scaled
+	return m.rollingUpdateZones(m.changeZonesWhenScaling, zonestatus.ScaleUp, zonestatus.Running, oceanbaseconst.DefaultStateWaitTimeout)(ctx)
+}
@@ -13,18 +13,27 @@ See the Mulan PSL v2 for more details.
 package obcluster
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	observerstatus "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/server"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
@@ -50,7 +59,10 @@ func (m *OBClusterManager) retryUpdateStatus() error {
 		if err != nil {
 			return client.IgnoreNotFound(err)
 		}
+		restartStatus := obcluster.Status.RollingRestart
 		obcluster.Status = *m.OBCluster.Status.DeepCopy()
+		// rolling restart progress is maintained by the restart task, keep the persisted one
+		obcluster.Status.RollingRestart = restartStatus
 		return m.Client.Status().Update(m.Ctx, obcluster)
 	})
 }
@@ -178,14 +190,14 @@ func (m *OBClusterManager) changeZonesWhenMountingBackupVolume(obzone *v1alpha1.
 }
 
 func (m *OBClusterManager) modifyOBZonesAndCheckStatus(changer obzoneChanger, status string, timeoutSeconds int) tasktypes.TaskFunc {
-	return func() tasktypes.TaskError {
+	return func(ctx context.Context) tasktypes.TaskError {
 		obzoneList, err := m.listOBZones()
 		if err != nil {
 			return errors.Wrap(err, "list obzones")
 		}
 		for _, obzone := range obzoneList.Items {
 			changer(&obzone)
-			err = m.Client.Update(m.Ctx, &obzone)
+			err = m.Client.Update(ctx, &obzone)
 			if err != nil {
 				return errors.Wrap(err, "update obzone")
 			}
@@ -195,7 +207,9 @@ func (m *OBClusterManager) modifyOBZonesAndCheckStatus(changer obzoneChanger, st
 		matched := true
 	outer:
 		for i := 0; i < timeoutSeconds; i++ {
-			time.Sleep(time.Second)
+			if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+				return err
+			}
 			obzoneList, err = m.listOBZones()
 			if err != nil {
 				return errors.Wrap(err, "list obzones")
@@ -218,7 +232,7 @@ func (m *OBClusterManager) modifyOBZonesAndCheckStatus(changer obzoneChanger, st
 }
 
 func (m *OBClusterManager) rollingUpdateZones(changer obzoneChanger, workingStatus, targetStatus string, timeoutSeconds int) tasktypes.TaskFunc {
-	return func() tasktypes.TaskError {
+	return func(ctx context.Context) tasktypes.TaskError {
 		tk := time.NewTicker(time.Duration(timeoutSeconds*2) * time.Second)
 		defer tk.Stop()
 		obzoneList, err := m.listOBZones()
@@ -229,7 +243,7 @@ func (m *OBClusterManager) rollingUpdateZones(changer obzoneChanger, workingStat
 			m.Recorder.Event(m.OBCluster, "Normal", "RollingUpdateOBZone", "Rolling update OBZone "+obzone.Name)
 			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 				changer(&obzone)
-				return m.Client.Update(m.Ctx, &obzone)
+				return m.Client.Update(ctx, &obzone)
 			})
 			if err != nil {
 				return errors.Wrap(err, "update obzone")
@@ -240,9 +254,11 @@ func (m *OBClusterManager) rollingUpdateZones(changer obzoneChanger, workingStat
 					return errors.New("task timeout")
 				default:
 				}
-				time.Sleep(time.Second)
+				if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+					return err
+				}
 				updatedOBZone := &v1alpha1.OBZone{}
-				err := m.Client.Get(m.Ctx, types.NamespacedName{
+				err := m.Client.Get(ctx, types.NamespacedName{
 					Namespace: obzone.Namespace,
 					Name:      obzone.Name,
 				}, updatedOBZone)
@@ -259,9 +275,11 @@ func (m *OBClusterManager) rollingUpdateZones(changer obzoneChanger, workingStat
 					return errors.New("task timeout")
 				default:
 				}
-				time.Sleep(time.Second)
+				if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+					return err
+				}
 				updatedOBZone := &v1alpha1.OBZone{}
-				err := m.Client.Get(m.Ctx, types.NamespacedName{
+				err := m.Client.Get(ctx, types.NamespacedName{
 					Namespace: obzone.Namespace,
 					Name:      obzone.Name,
 				}, updatedOBZone)
@@ -276,3 +294,292 @@ func (m *OBClusterManager) rollingUpdateZones(changer obzoneChanger, workingStat
 		return nil
 	}
 }
+
+func (m *OBClusterManager) needRollingRestart() bool {
+	restartAt, exist := resourceutils.GetAnnotationField(m.OBCluster, oceanbaseconst.AnnotationsRestartAt)
+	if !exist || restartAt == "" {
+		return false
+	}
+	restartStatus := m.OBCluster.Status.RollingRestart
+	return restartStatus == nil || restartStatus.RestartAt != restartAt || !restartStatus.Finished
+}
+
+// addRollingRestartOBZoneTasks inserts a task for each zone after preparation of rolling restart,
+// zones are restarted one by one in order of topology and a retry only restarts the zone it failed on
+func addRollingRestartOBZoneTasks(f *tasktypes.TaskFlow, topology []apitypes.OBZoneTopology) {
+	tasks := make([]tasktypes.TaskName, 0, len(f.OperationContext.Tasks)+len(topology))
+	for _, t := range f.OperationContext.Tasks {
+		tasks = append(tasks, t)
+		if t != tPrepareRollingRestart {
+			continue
+		}
+		for _, zone := range topology {
+			tasks = append(tasks, tasktypes.TaskName(tRollingRestartOBZonePrefix+zone.Zone))
+		}
+	}
+	f.OperationContext.Tasks = tasks
+}
+
+// rollingRestartOBZoneOfTask returns the zone restarted by the task, ok is false if it is not a rolling restart task
+func rollingRestartOBZoneOfTask(name tasktypes.TaskName) (zone string, ok bool) {
+	zone = strings.TrimPrefix(string(name), tRollingRestartOBZonePrefix)
+	return zone, zone != "" && zone != string(name)
+}
+
+// updateRollingRestartStatus applies the updater on the latest rolling restart status and persists it,
+// progress of rolling restart is only maintained by the restart task
+func (m *OBClusterManager) updateRollingRestartStatus(ctx context.Context, updater func(*apitypes.RollingRestartStatus)) (*apitypes.RollingRestartStatus, error) {
+	var restartStatus *apitypes.RollingRestartStatus
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obcluster := &v1alpha1.OBCluster{}
+		err := m.Client.Get(ctx, types.NamespacedName{
+			Namespace: m.OBCluster.Namespace,
+			Name:      m.OBCluster.Name,
+		}, obcluster)
+		if err != nil {
+			return errors.Wrap(err, "get obcluster")
+		}
+		if obcluster.Status.RollingRestart == nil {
+			obcluster.Status.RollingRestart = &apitypes.RollingRestartStatus{}
+		}
+		updater(obcluster.Status.RollingRestart)
+		restartStatus = obcluster.Status.RollingRestart.DeepCopy()
+		return m.Client.Status().Update(ctx, obcluster)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restartStatus, nil
+}
+
+func (m *OBClusterManager) listOBServersOfZone(ctx context.Context, zoneName string) (*v1alpha1.OBServerList, error) {
+	observerList := &v1alpha1.OBServerList{}
+	err := m.Client.List(ctx, observerList, client.MatchingLabels{
+		oceanbaseconst.LabelRefOBZone: m.generateZoneName(zoneName),
+	}, client.InNamespace(m.OBCluster.Namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "get observer list")
+	}
+	return observerList, nil
+}
+
+// restartOBZone stops the zone in oceanbase, recreates pods of observers created before restartAt and starts the zone again
+// after all observers become active and logs are in sync. Pods recreated by a former try are not restarted again.
+func (m *OBClusterManager) restartOBZone(ctx context.Context, operationManager *operation.OceanbaseOperationManager, zoneName string, restartAt *metav1.Time) error {
+	observerList, err := m.listOBServersOfZone(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+	for _, observer := range observerList.Items {
+		if !observer.SupportStaticIP() {
+			return errors.Errorf("OBServer %s can not keep its ip address after restart", observer.Name)
+		}
+	}
+	err = operationManager.StopZone(zoneName)
+	if err != nil {
+		return errors.Wrapf(err, "Stop zone %s", zoneName)
+	}
+	for _, observer := range observerList.Items {
+		pod := &corev1.Pod{}
+		err = m.Client.Get(ctx, types.NamespacedName{
+			Namespace: observer.Namespace,
+			Name:      observer.Name,
+		}, pod)
+		if err != nil {
+			if kubeerrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "Get pod of observer %s", observer.Name)
+		}
+		if !pod.CreationTimestamp.Before(restartAt) {
+			m.Logger.Info("Pod of observer already restarted", "observer", observer.Name)
+			continue
+		}
+		m.Logger.Info("Delete pod of observer to restart it", "observer", observer.Name)
+		err = m.Client.Delete(ctx, pod)
+		if err != nil && !kubeerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Delete pod of observer %s", observer.Name)
+		}
+	}
+	err = m.waitOBServersRestarted(ctx, operationManager, zoneName, restartAt, oceanbaseconst.TimeConsumingStateWaitTimeout)
+	if err != nil {
+		return err
+	}
+	err = operationManager.StartZone(zoneName)
+	if err != nil {
+		return errors.Wrapf(err, "Start zone %s", zoneName)
+	}
+	return m.waitLogInSync(ctx, operationManager, oceanbaseconst.TimeConsumingStateWaitTimeout)
+}
+
+func (m *OBClusterManager) waitOBServersRestarted(ctx context.Context, operationManager *operation.OceanbaseOperationManager, zoneName string, deletedAt *metav1.Time, timeoutSeconds int) error {
+	for i := 0; i < timeoutSeconds; i++ {
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+		observerList, err := m.listOBServersOfZone(ctx, zoneName)
+		if err != nil {
+			return err
+		}
+		allRestarted := true
+		for _, observer := range observerList.Items {
+			pod := &corev1.Pod{}
+			err = m.Client.Get(ctx, types.NamespacedName{
+				Namespace: observer.Namespace,
+				Name:      observer.Name,
+			}, pod)
+			if err != nil || pod.CreationTimestamp.Before(deletedAt) || !observer.Status.Ready || observer.Status.Status != serverstatus.Running {
+				m.Logger.V(oceanbaseconst.LogLevelTrace).Info("OBServer still not restarted", "observer", observer.Name)
+				allRestarted = false
+				break
+			}
+		}
+		if !allRestarted {
+			continue
+		}
+		servers, err := operationManager.ListServersOfZone(zoneName)
+		if err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("List servers failed, check next time", "zone", zoneName)
+			continue
+		}
+		allActive := len(servers) > 0
+		for _, server := range servers {
+			if server.Status != observerstatus.Active || server.StartServiceTime <= 0 {
+				allActive = false
+				break
+			}
+		}
+		if allActive {
+			m.Logger.Info("All observers of zone restarted and active", "zone", zoneName)
+			return nil
+		}
+	}
+	return errors.Errorf("Wait observers of zone %s restarted timeout", zoneName)
+}
+
+func (m *OBClusterManager) waitLogInSync(ctx context.Context, operationManager *operation.OceanbaseOperationManager, timeoutSeconds int) error {
+	for i := 0; i < timeoutSeconds; i++ {
+		count, err := operationManager.CountLogStatNotInSync()
+		if err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Query log stat failed, check next time")
+		} else if count == 0 {
+			m.Logger.Info("All logs are in sync")
+			return nil
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.New("Wait log in sync timeout")
+}
+
+// updateMajorCompactionStatus refreshes major compaction status of sys tenant periodically, failure of which should not block reconciling
+func (m *OBClusterManager) updateMajorCompactionStatus() {
+	if !resourceutils.NeedRefreshMajorCompactionStatus(m.OBCluster.Status.MajorCompaction) {
+		return
+	}
+	con, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Failed to get operation manager, refresh major compaction status next time", "error", err.Error())
+		return
+	}
+	compactionStatus, err := resourceutils.GetMajorCompactionStatus(con, oceanbaseconst.SysTenantID)
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Failed to get major compaction status of sys tenant, refresh it next time", "error", err.Error())
+		return
+	}
+	m.OBCluster.Status.MajorCompaction = compactionStatus
+}
+
+func (m *OBClusterManager) getSpecParameter(name string) (string, bool) {
+	for _, parameter := range m.OBCluster.Spec.Parameters {
+		if parameter.Name == name {
+			return parameter.Value, true
+		}
+	}
+	return "", false
+}
+
+// getParameterValueOfServer returns the value of parameter on the server, or the first value if not found
+func getParameterValueOfServer(parameters []model.Parameter, serverIP string) string {
+	for _, parameter := range parameters {
+		if parameter.SvrIp == serverIP {
+			return parameter.Value
+		}
+	}
+	if len(parameters) > 0 {
+		return parameters[0].Value
+	}
+	return ""
+}
+
+// pendingStatuses compares spec of obcluster with the observed obzones and status, and returns statuses to handle in order.
+// The first one is what the obcluster turns into, the order of checks matters
+func (m *OBClusterManager) pendingStatuses(obzoneList *v1alpha1.OBZoneList) []string {
+	statuses := make([]string, 0)
+	addStatus := func(status string) {
+		for _, s := range statuses {
+			if s == status {
+				return
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	if len(m.OBCluster.Spec.Topology) > len(obzoneList.Items) {
+		m.Logger.Info("Compare topology need add zone")
+		addStatus(clusterstatus.AddOBZone)
+	} else if len(m.OBCluster.Spec.Topology) < len(obzoneList.Items) {
+		m.Logger.Info("Compare topology need delete zone")
+		addStatus(clusterstatus.DeleteOBZone)
+	} else {
+		for _, obzone := range obzoneList.Items {
+			if m.checkIfCalcResourceChange(&obzone) {
+				addStatus(clusterstatus.ScaleUp)
+			}
+			if m.checkIfStorageSizeExpand(&obzone) {
+				addStatus(clusterstatus.ExpandPVC)
+			}
+			if m.checkIfBackupVolumeAdded(&obzone) {
+				addStatus(clusterstatus.MountBackupVolume)
+			}
+			for _, zone := range m.OBCluster.Spec.Topology {
+				if zone.Zone == obzone.Spec.Topology.Zone && zone.Replica != len(obzone.Status.OBServerStatus) {
+					addStatus(clusterstatus.ModifyOBZoneReplica)
+				}
+			}
+		}
+	}
+
+	// check for upgrade
+	if m.OBCluster.Spec.OBServerTemplate.Image != m.OBCluster.Status.Image {
+		m.Logger.Info("Check obcluster image not match, need upgrade")
+		addStatus(clusterstatus.Upgrade)
+	}
+
+	// check for rolling restart
+	if m.needRollingRestart() {
+		m.Logger.Info("Rolling restart of obcluster is requested")
+		addStatus(clusterstatus.RollingRestart)
+	}
+
+	parameterMap := make(map[string]apitypes.Parameter)
+	for _, parameter := range m.OBCluster.Status.Parameters {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Build parameter map", "parameter", parameter.Name)
+		parameterMap[parameter.Name] = parameter
+	}
+	for _, parameter := range m.OBCluster.Spec.Parameters {
+		parameterStatus, parameterExists := parameterMap[parameter.Name]
+		// need create or update parameter
+		if !parameterExists || parameterStatus.Value != parameter.Value {
+			addStatus(clusterstatus.ModifyOBParameter)
+			break
+		}
+		delete(parameterMap, parameter.Name)
+	}
+	// need delete parameter
+	if len(parameterMap) > 0 {
+		addStatus(clusterstatus.ModifyOBParameter)
+	}
+	return statuses
+}
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obclusteroperation
+
+import (
+	"github.com/oceanbase/ob-operator/pkg/task"
+)
+
+func init() {
+	// cluster operation
+	task.GetRegistry().Register(fOpRestartOBCluster, RestartOBCluster)
+	task.GetRegistry().Register(fOpStopZone, StopZone)
+	task.GetRegistry().Register(fOpStartZone, StartZone)
+	task.GetRegistry().Register(fOpDeleteOBServer, DeleteOBServer)
+	task.GetRegistry().Register(fOpMajorFreeze, MajorFreeze)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obclusteroperation
+
+import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+// cluster operation flows
+const (
+	fOpRestartOBCluster ttypes.FlowName = "restart obcluster"
+	fOpStopZone         ttypes.FlowName = "stop zone"
+	fOpStartZone        ttypes.FlowName = "start zone"
+	fOpDeleteOBServer   ttypes.FlowName = "delete observer of cluster"
+	fOpMajorFreeze      ttypes.FlowName = "major freeze"
+)
+
+const (
+	tOpTriggerRollingRestart      ttypes.TaskName = "trigger rolling restart"
+	tOpWaitRollingRestartFinished ttypes.TaskName = "wait rolling restart finished"
+	tOpStopZone                   ttypes.TaskName = "stop zone"
+	tOpStartZone                  ttypes.TaskName = "start zone"
+	tOpDeleteOBServer             ttypes.TaskName = "delete observer"
+	tOpWaitOBServerDeleted        ttypes.TaskName = "wait observer deleted"
+	tOpMajorFreeze                ttypes.TaskName = "major freeze"
+	tOpWaitMajorCompactionDone    ttypes.TaskName = "wait major compaction done"
+)
+
+// timeouts of cluster operation tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	// rolling restart waits for every zone, each of which takes at most oceanbaseconst.TimeConsumingStateWaitTimeout
+	tOpWaitRollingRestartFinished: oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tOpWaitOBServerDeleted:        oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tOpWaitMajorCompactionDone:    oceanbaseconst.MajorCompactionTimeoutSeconds * time.Second,
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obclusteroperation
+
+import (
+	"github.com/oceanbase/ob-operator/api/constants"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+func RestartOBCluster() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fOpRestartOBCluster,
+			Tasks: []tasktypes.TaskName{
+				tOpTriggerRollingRestart,
+				tOpWaitRollingRestartFinished,
+			},
+			TargetStatus: string(constants.ClusterOpSuccessful),
+			OnFailure: tasktypes.FailureRule{
+				NextTryStatus: string(constants.ClusterOpFailed),
+			},
+		},
+	}
+}
+
+func StopZone() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fOpStopZone,
+			Tasks: []tasktypes.TaskName{
+				tOpStopZone,
+			},
+			TargetStatus: string(constants.ClusterOpSuccessful),
+			OnFailure: tasktypes.FailureRule{
+				NextTryStatus: string(constants.ClusterOpFailed),
+			},
+		},
+	}
+}
+
+func StartZone() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fOpStartZone,
+			Tasks: []tasktypes.TaskName{
+				tOpStartZone,
+			},
+			TargetStatus: string(constants.ClusterOpSuccessful),
+			OnFailure: tasktypes.FailureRule{
+				NextTryStatus: string(constants.ClusterOpFailed),
+			},
+		},
+	}
+}
+
+func DeleteOBServer() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fOpDeleteOBServer,
+			Tasks: []tasktypes.TaskName{
+				tOpDeleteOBServer,
+				tOpWaitOBServerDeleted,
+			},
+			TargetStatus: string(constants.ClusterOpSuccessful),
+			OnFailure: tasktypes.FailureRule{
+				NextTryStatus: string(constants.ClusterOpFailed),
+			},
+		},
+	}
+}
+
+func MajorFreeze() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name: fOpMajorFreeze,
+			Tasks: []tasktypes.TaskName{
+				tOpMajorFreeze,
+				tOpWaitMajorCompactionDone,
+			},
+			TargetStatus: string(constants.ClusterOpSuccessful),
+			OnFailure: tasktypes.FailureRule{
+				NextTryStatus: string(constants.ClusterOpFailed),
+			},
+		},
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obclusteroperation
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	"github.com/oceanbase/ob-operator/internal/telemetry"
+	opresource "github.com/oceanbase/ob-operator/pkg/coordinator"
+	"github.com/oceanbase/ob-operator/pkg/task"
+	taskstatus "github.com/oceanbase/ob-operator/pkg/task/const/status"
+	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+type ObClusterOperationManager struct {
+	opresource.ResourceManager
+
+	Ctx      context.Context
+	Resource *v1alpha1.OBClusterOperation
+	Client   client.Client
+	Recorder telemetry.Recorder
+	Logger   *logr.Logger
+}
+
+func (m *ObClusterOperationManager) IsNewResource() bool {
+	return m.Resource.Status.Status == ""
+}
+
+func (m *ObClusterOperationManager) GetStatus() string {
+	return string(m.Resource.Status.Status)
+}
+
+func (m *ObClusterOperationManager) IsDeleting() bool {
+	return m.Resource.GetDeletionTimestamp() != nil
+}
+
+func (m *ObClusterOperationManager) CheckAndUpdateFinalizers() error {
+	return nil
+}
+
+func (m *ObClusterOperationManager) InitStatus() {
+	now := metav1.Now()
+	m.Resource.Status.StartTime = &now
+	_, err := m.getOBCluster(m.Ctx)
+	if err != nil {
+		m.Logger.Error(err, "Failed to find obcluster")
+		m.PrintErrEvent(err)
+		m.Resource.Status.Status = constants.ClusterOpFailed
+		m.Resource.Status.Message = err.Error()
+		m.Resource.Status.FinishTime = &now
+		return
+	}
+	switch m.Resource.Spec.Type {
+	case constants.ClusterOpRestart,
+		constants.ClusterOpStopZone,
+		constants.ClusterOpStartZone,
+		constants.ClusterOpDeleteOBServer,
+		constants.ClusterOpMajorFreeze:
+		m.Resource.Status.Status = constants.ClusterOpRunning
+	default:
+		err = errors.New("unknown cluster operation type")
+		m.Logger.Error(err, "InitStatus")
+		m.PrintErrEvent(err)
+		m.Resource.Status.Status = constants.ClusterOpFailed
+		m.Resource.Status.Message = err.Error()
+		m.Resource.Status.FinishTime = &now
+	}
+}
+
+func (m *ObClusterOperationManager) SetOperationContext(c *tasktypes.OperationContext) {
+	m.Resource.Status.OperationContext = c
+}
+
+func (m *ObClusterOperationManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.Resource.Status.FlowHistory = tasktypes.AppendFlowRecord(m.Resource.Status.FlowHistory, record)
+}
+
+func (m *ObClusterOperationManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.Resource)
+}
+
+func (m *ObClusterOperationManager) SetPaused(paused bool) bool {
+	if m.Resource.Status.Paused == paused {
+		return false
+	}
+	m.Resource.Status.Paused = paused
+	return true
+}
+
+func (m *ObClusterOperationManager) ClearTaskInfo() {
+	m.Resource.Status.Status = constants.ClusterOpRunning
+	m.Resource.Status.OperationContext = nil
+}
+
+func (m *ObClusterOperationManager) HandleFailure() {
+	if m.IsDeleting() {
+		m.Resource.Status.OperationContext = nil
+	} else {
+		operationContext := m.Resource.Status.OperationContext
+		failureRule := operationContext.OnFailure
+		switch failureRule.Strategy {
+		case strategy.StartOver:
+			if m.Resource.Status.Status != apitypes.ClusterOperationStatus(failureRule.NextTryStatus) {
+				m.Resource.Status.Status = apitypes.ClusterOperationStatus(failureRule.NextTryStatus)
+				m.Resource.Status.OperationContext = nil
+			} else {
+				m.Resource.Status.OperationContext.Idx = 0
+				m.Resource.Status.OperationContext.TaskStatus = ""
+				m.Resource.Status.OperationContext.TaskId = ""
+				m.Resource.Status.OperationContext.Task = ""
+			}
+		case strategy.RetryFromCurrent:
+			operationContext.TaskStatus = taskstatus.Pending
+		case strategy.Pause:
+		default:
+			m.Resource.Status.OperationContext = nil
+			if failureRule.NextTryStatus == "" {
+				m.Resource.Status.Status = constants.ClusterOpFailed
+			} else {
+				m.Resource.Status.Status = apitypes.ClusterOperationStatus(failureRule.NextTryStatus)
+			}
+			if m.Resource.Status.Status == constants.ClusterOpFailed {
+				now := metav1.Now()
+				m.Resource.Status.FinishTime = &now
+			}
+		}
+	}
+}
+
+func (m *ObClusterOperationManager) FinishTask() {
+	now := metav1.Now()
+	m.Resource.Status.Status = apitypes.ClusterOperationStatus(m.Resource.Status.OperationContext.TargetStatus)
+	m.Resource.Status.OperationContext = nil
+	m.Resource.Status.FinishTime = &now
+}
+
+func (m *ObClusterOperationManager) UpdateStatus() error {
+	return m.retryUpdateStatus()
+}
+
+func (m *ObClusterOperationManager) ArchiveResource() {
+	m.Logger.Info("Archive obcluster operation", "obcluster operation", m.Resource.Name)
+	m.Recorder.Event(m.Resource, "Archive", "", "archive obcluster operation")
+	now := metav1.Now()
+	m.Resource.Status.Status = constants.ClusterOpFailed
+	m.Resource.Status.OperationContext = nil
+	m.Resource.Status.FinishTime = &now
+}
+
+func (m *ObClusterOperationManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	if timeout, ok := taskTimeouts[name]; ok {
+		return timeout
+	}
+	return 0
+}
+
+func (m *ObClusterOperationManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
+	switch name {
+	case tOpTriggerRollingRestart:
+		return m.TriggerRollingRestart, nil
+	case tOpWaitRollingRestartFinished:
+		return m.WaitRollingRestartFinished, nil
+	case tOpStopZone:
+		return m.StopZone, nil
+	case tOpStartZone:
+		return m.StartZone, nil
+	case tOpDeleteOBServer:
+		return m.DeleteOBServer, nil
+	case tOpWaitOBServerDeleted:
+		return m.WaitOBServerDeleted, nil
+	case tOpMajorFreeze:
+		return m.MajorFreeze, nil
+	case tOpWaitMajorCompactionDone:
+		return m.WaitMajorCompactionDone, nil
+	default:
+		return nil, errors.New("Task name not registered")
+	}
+}
+
+func (m *ObClusterOperationManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
+	if m.Resource.Status.OperationContext != nil {
+		return tasktypes.NewTaskFlow(m.Resource.Status.OperationContext), nil
+	}
+	var taskFlow *tasktypes.TaskFlow
+	var err error
+	switch m.Resource.Status.Status {
+	case constants.ClusterOpRunning:
+		switch m.Resource.Spec.Type {
+		case constants.ClusterOpRestart:
+			taskFlow, err = task.GetRegistry().Get(fOpRestartOBCluster)
+		case constants.ClusterOpStopZone:
+			taskFlow, err = task.GetRegistry().Get(fOpStopZone)
+		case constants.ClusterOpStartZone:
+			taskFlow, err = task.GetRegistry().Get(fOpStartZone)
+		case constants.ClusterOpDeleteOBServer:
+			taskFlow, err = task.GetRegistry().Get(fOpDeleteOBServer)
+		case constants.ClusterOpMajorFreeze:
+			taskFlow, err = task.GetRegistry().Get(fOpMajorFreeze)
+		default:
+			err = errors.New("unsupported operation type")
+		}
+	case constants.ClusterOpSuccessful:
+		fallthrough
+	case constants.ClusterOpFailed:
+		fallthrough
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if taskFlow.OperationContext.OnFailure.NextTryStatus == "" {
+		taskFlow.OperationContext.OnFailure.NextTryStatus = string(constants.ClusterOpFailed)
+	}
+	return taskFlow, nil
+}
+
+func (m *ObClusterOperationManager) PrintErrEvent(err error) {
+	m.Recorder.Event(m.Resource, corev1.EventTypeWarning, "Task failed", err.Error())
+	m.Resource.Status.Message = err.Error()
+}
+
+func (m *ObClusterOperationManager) retryUpdateStatus() error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		resource := &v1alpha1.OBClusterOperation{}
+		err := m.Client.Get(m.Ctx, types.NamespacedName{
+			Namespace: m.Resource.GetNamespace(),
+			Name:      m.Resource.GetName(),
+		}, resource)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		resource.Status = *m.Resource.Status.DeepCopy()
+		return m.Client.Status().Update(m.Ctx, resource)
+	})
+}
+
+func (m *ObClusterOperationManager) getOBCluster(ctx context.Context) (*v1alpha1.OBCluster, error) {
+	obcluster := &v1alpha1.OBCluster{}
+	err := m.Client.Get(ctx, types.NamespacedName{
+		Namespace: m.Resource.Namespace,
+		Name:      m.Resource.Spec.OBCluster,
+	}, obcluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "get obcluster")
+	}
+	return obcluster, nil
+}
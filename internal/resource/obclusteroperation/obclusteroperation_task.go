@@ -0,0 +1,305 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obclusteroperation
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+func (m *ObClusterOperationManager) TriggerRollingRestart(ctx context.Context) tasktypes.TaskError {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obcluster, err := m.getOBCluster(ctx)
+		if err != nil {
+			return err
+		}
+		annotations := obcluster.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[oceanbaseconst.AnnotationsRestartAt] = m.getRestartAt()
+		obcluster.SetAnnotations(annotations)
+		return m.Client.Update(ctx, obcluster)
+	})
+}
+
+func (m *ObClusterOperationManager) WaitRollingRestartFinished(ctx context.Context) tasktypes.TaskError {
+	restartAt := m.getRestartAt()
+	obcluster, err := m.getOBCluster(ctx)
+	if err != nil {
+		return err
+	}
+	timeoutSeconds := oceanbaseconst.TimeConsumingStateWaitTimeout * len(obcluster.Spec.Topology)
+	for i := 0; i < timeoutSeconds; i++ {
+		obcluster, err = m.getOBCluster(ctx)
+		if err != nil {
+			return err
+		}
+		restartStatus := obcluster.Status.RollingRestart
+		if restartStatus != nil && restartStatus.RestartAt == restartAt && restartStatus.Finished {
+			m.Logger.Info("Rolling restart of obcluster finished", "obcluster", obcluster.Name)
+			return nil
+		}
+		if obcluster.Status.Status == clusterstatus.Failed {
+			return errors.Errorf("OBCluster %s failed during rolling restart", obcluster.Name)
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.New("Wait rolling restart finished timeout")
+}
+
+func (m *ObClusterOperationManager) StopZone(ctx context.Context) tasktypes.TaskError {
+	con, err := m.getClusterSysClient(ctx)
+	if err != nil {
+		return err
+	}
+	return con.StopZone(m.Resource.Spec.StopZone.Zone)
+}
+
+func (m *ObClusterOperationManager) StartZone(ctx context.Context) tasktypes.TaskError {
+	con, err := m.getClusterSysClient(ctx)
+	if err != nil {
+		return err
+	}
+	return con.StartZone(m.Resource.Spec.StartZone.Zone)
+}
+
+// DeleteOBServer removes the observer by scaling in its zone by one. The observer is marked as the one to remove,
+// so that obzone deletes it from oceanbase after its units migrate away instead of creating a replacement of it.
+func (m *ObClusterOperationManager) DeleteOBServer(ctx context.Context) tasktypes.TaskError {
+	observer := &v1alpha1.OBServer{}
+	err := m.Client.Get(ctx, m.getOBServerNamespacedName(), observer)
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			m.Logger.Info("OBServer already deleted", "observer", m.Resource.Spec.DeleteOBServer.OBServer)
+			return nil
+		}
+		return errors.Wrap(err, "get observer")
+	}
+	if observer.Labels[oceanbaseconst.LabelRefOBCluster] != m.Resource.Spec.OBCluster {
+		return errors.Errorf("OBServer %s does not belong to obcluster %s", observer.Name, m.Resource.Spec.OBCluster)
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := m.Client.Get(ctx, m.getOBServerNamespacedName(), observer)
+		if err != nil {
+			return err
+		}
+		annotations := observer.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[oceanbaseconst.AnnotationsScaleInCandidate] = "true"
+		observer.SetAnnotations(annotations)
+		return m.Client.Update(ctx, observer)
+	})
+	if err != nil {
+		return errors.Wrap(err, "mark observer to delete")
+	}
+	observerList := &v1alpha1.OBServerList{}
+	err = m.Client.List(ctx, observerList, client.InNamespace(m.Resource.Namespace), client.MatchingLabels{
+		oceanbaseconst.LabelRefOBCluster: m.Resource.Spec.OBCluster,
+	})
+	if err != nil {
+		return errors.Wrap(err, "list observers")
+	}
+	observersInZone := 0
+	for _, item := range observerList.Items {
+		if item.Spec.Zone == observer.Spec.Zone && item.GetDeletionTimestamp() == nil {
+			observersInZone++
+		}
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obcluster, err := m.getOBCluster(ctx)
+		if err != nil {
+			return err
+		}
+		for i, zone := range obcluster.Spec.Topology {
+			if zone.Zone != observer.Spec.Zone {
+				continue
+			}
+			if zone.Replica < observersInZone {
+				m.Logger.Info("Zone is already scaling in", "zone", zone.Zone, "replica", zone.Replica)
+				return nil
+			}
+			if zone.Replica <= 1 {
+				return errors.Errorf("OBServer %s is the last one of zone %s, delete the zone instead", observer.Name, zone.Zone)
+			}
+			obcluster.Spec.Topology[i].Replica--
+			return m.Client.Update(ctx, obcluster)
+		}
+		return errors.Errorf("Zone %s of observer %s not found in topology of obcluster", observer.Spec.Zone, observer.Name)
+	})
+}
+
+func (m *ObClusterOperationManager) WaitOBServerDeleted(ctx context.Context) tasktypes.TaskError {
+	for i := 0; i < oceanbaseconst.ServerDeleteTimeoutSeconds; i++ {
+		err := m.Client.Get(ctx, m.getOBServerNamespacedName(), &v1alpha1.OBServer{})
+		if err != nil && kubeerrors.IsNotFound(err) {
+			m.Logger.Info("OBServer deleted", "observer", m.Resource.Spec.DeleteOBServer.OBServer)
+			return nil
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.New("Wait observer deleted timeout")
+}
+
+func (m *ObClusterOperationManager) MajorFreeze(ctx context.Context) tasktypes.TaskError {
+	con, err := m.getClusterSysClient(ctx)
+	if err != nil {
+		return err
+	}
+	tenants := make([]string, 0)
+	if m.Resource.Spec.MajorFreeze != nil {
+		tenants = m.Resource.Spec.MajorFreeze.Tenants
+	}
+	// frozen scns are recorded only once, a retry after freeze is triggered must not take the new ones
+	if len(m.Resource.Status.FrozenScnsBeforeFreeze) == 0 {
+		tenantIDs, err := m.getMajorFreezeTenantIDs(con)
+		if err != nil {
+			return err
+		}
+		compactions, err := con.ListMajorCompactions()
+		if err != nil {
+			return err
+		}
+		frozenScns := make([]apitypes.TenantFrozenScn, 0, len(compactions))
+		for _, compaction := range compactions {
+			if len(tenantIDs) > 0 && !tenantIDs[compaction.TenantID] {
+				continue
+			}
+			frozenScns = append(frozenScns, apitypes.TenantFrozenScn{
+				TenantID:  compaction.TenantID,
+				FrozenScn: compaction.FrozenScn,
+			})
+		}
+		if err := m.recordFrozenScns(ctx, frozenScns); err != nil {
+			return errors.Wrap(err, "record frozen scns before major freeze")
+		}
+	}
+	return con.MajorFreezeTenants(tenants...)
+}
+
+func (m *ObClusterOperationManager) WaitMajorCompactionDone(ctx context.Context) tasktypes.TaskError {
+	con, err := m.getClusterSysClient(ctx)
+	if err != nil {
+		return err
+	}
+	if len(m.Resource.Status.FrozenScnsBeforeFreeze) == 0 {
+		return errors.New("Frozen scns before major freeze are not recorded")
+	}
+	// freeze triggered by this operation must advance frozen scn of every tenant recorded before it
+	frozenScnsBefore := make(map[int64]int64, len(m.Resource.Status.FrozenScnsBeforeFreeze))
+	for _, frozenScn := range m.Resource.Status.FrozenScnsBeforeFreeze {
+		frozenScnsBefore[frozenScn.TenantID] = frozenScn.FrozenScn
+	}
+	for i := 0; i < oceanbaseconst.MajorCompactionTimeoutSeconds/oceanbaseconst.MajorCompactionCheckGapSeconds; i++ {
+		compactions, err := con.ListMajorCompactions()
+		if err != nil {
+			return err
+		}
+		finished := true
+		for _, compaction := range compactions {
+			frozenScnBefore, recorded := frozenScnsBefore[compaction.TenantID]
+			if !recorded {
+				continue
+			}
+			if compaction.IsError == "YES" {
+				return errors.Errorf("Major compaction of tenant %d failed: %s", compaction.TenantID, compaction.Info)
+			}
+			if compaction.FrozenScn <= frozenScnBefore || compaction.LastScn != compaction.FrozenScn || compaction.Status != "IDLE" {
+				finished = false
+			}
+		}
+		if finished {
+			m.Logger.Info("Major compaction finished", "obcluster", m.Resource.Spec.OBCluster)
+			return nil
+		}
+		if err := resourceutils.SleepWithContext(ctx, oceanbaseconst.MajorCompactionCheckGapSeconds*time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.New("Wait major compaction done timeout")
+}
+
+func (m *ObClusterOperationManager) getMajorFreezeTenantIDs(con *operation.OceanbaseOperationManager) (map[int64]bool, error) {
+	tenantIDs := make(map[int64]bool)
+	if m.Resource.Spec.MajorFreeze != nil {
+		for _, tenantName := range m.Resource.Spec.MajorFreeze.Tenants {
+			tenant, err := con.GetTenantByName(tenantName)
+			if err != nil {
+				return nil, err
+			}
+			tenantIDs[tenant.TenantID] = true
+		}
+	}
+	return tenantIDs, nil
+}
+
+func (m *ObClusterOperationManager) recordFrozenScns(ctx context.Context, frozenScns []apitypes.TenantFrozenScn) error {
+	m.Resource.Status.FrozenScnsBeforeFreeze = frozenScns
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		resource := &v1alpha1.OBClusterOperation{}
+		err := m.Client.Get(ctx, types.NamespacedName{
+			Namespace: m.Resource.GetNamespace(),
+			Name:      m.Resource.GetName(),
+		}, resource)
+		if err != nil {
+			return err
+		}
+		resource.Status.FrozenScnsBeforeFreeze = frozenScns
+		return m.Client.Status().Update(ctx, resource)
+	})
+}
+
+func (m *ObClusterOperationManager) getClusterSysClient(ctx context.Context) (*operation.OceanbaseOperationManager, error) {
+	obcluster, err := m.getOBCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	con, err := resourceutils.GetSysOperationClient(m.Client, m.Logger, obcluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster sys client")
+	}
+	return con, nil
+}
+
+// getRestartAt returns the value of restart annotation set on obcluster by this operation,
+// uid of the operation is used so that every operation requests a new rolling restart
+func (m *ObClusterOperationManager) getRestartAt() string {
+	return string(m.Resource.GetUID())
+}
+
+func (m *ObClusterOperationManager) getOBServerNamespacedName() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: m.Resource.Namespace,
+		Name:      m.Resource.Spec.DeleteOBServer.OBServer,
+	}
+}
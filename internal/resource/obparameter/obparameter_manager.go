@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -69,6 +70,22 @@ func (m *OBParameterManager) SetOperationContext(c *tasktypes.OperationContext)
 	m.OBParameter.Status.OperationContext = c
 }
 
+func (m *OBParameterManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.OBParameter.Status.FlowHistory = tasktypes.AppendFlowRecord(m.OBParameter.Status.FlowHistory, record)
+}
+
+func (m *OBParameterManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.OBParameter)
+}
+
+func (m *OBParameterManager) SetPaused(paused bool) bool {
+	if m.OBParameter.Status.Paused == paused {
+		return false
+	}
+	m.OBParameter.Status.Paused = paused
+	return true
+}
+
 func (m *OBParameterManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 	// exists unfinished task flow, return the last task flow
 	if m.OBParameter.Status.OperationContext != nil {
@@ -127,6 +144,9 @@ func (m *OBParameterManager) retryUpdateStatus() error {
 }
 
 func (m *OBParameterManager) UpdateStatus() error {
+	if m.OBParameter.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	obcluster, err := m.getOBCluster()
 	if err != nil {
 		return errors.Wrap(err, "Get obcluster from K8s")
@@ -205,6 +225,10 @@ func (m *OBParameterManager) HandleFailure() {
 	}
 }
 
+func (m *OBParameterManager) GetTaskTimeout(tasktypes.TaskName) time.Duration {
+	return 0
+}
+
 func (m *OBParameterManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tSetOBParameter:
@@ -218,7 +242,7 @@ func (m *OBParameterManager) PrintErrEvent(err error) {
 	m.Recorder.Event(m.OBParameter, corev1.EventTypeWarning, "Task failed", err.Error())
 }
 
-func (m *OBParameterManager) SetOBParameter() tasktypes.TaskError {
+func (m *OBParameterManager) SetOBParameter(ctx context.Context) tasktypes.TaskError {
 	operationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		m.Logger.Error(err, "Get operation manager failed")
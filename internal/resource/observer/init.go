@@ -29,4 +29,8 @@ func init() {
 	task.GetRegistry().Register(fScaleUpOBServer, ScaleUpOBServer)
 	task.GetRegistry().Register(fExpandPVC, ResizePVC)
 	task.GetRegistry().Register(fMountBackupVolume, MountBackupVolume)
+	task.GetRegistry().Register(fResizeOBServerInPlace, ResizeOBServerInPlace)
+	task.GetRegistry().Register(fEnterMaintenance, EnterMaintenance)
+	task.GetRegistry().Register(fExitMaintenance, ExitMaintenance)
+	task.GetRegistry().Register(fRelocateOBServer, RelocateOBServer)
 }
@@ -13,6 +13,9 @@ See the Mulan PSL v2 for more details.
 package observer
 
 import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -29,6 +32,10 @@ const (
 	fScaleUpOBServer                ttypes.FlowName = "scale up observer"
 	fExpandPVC                      ttypes.FlowName = "expand pvc for observer"
 	fMountBackupVolume              ttypes.FlowName = "mount backup volume for observer"
+	fResizeOBServerInPlace          ttypes.FlowName = "resize observer in place"
+	fEnterMaintenance               ttypes.FlowName = "enter maintenance for observer"
+	fExitMaintenance                ttypes.FlowName = "exit maintenance for observer"
+	fRelocateOBServer               ttypes.FlowName = "relocate observer"
 )
 
 // observer tasks
@@ -52,4 +59,17 @@ const (
 	tWaitForPVCResized            ttypes.TaskName = "wait for pvc being resized"
 	tMountBackupVolume            ttypes.TaskName = "mount backup volume"
 	tWaitForBackupVolumeMounted   ttypes.TaskName = "wait for backup volume to be mounted"
+	tResizePodInPlace             ttypes.TaskName = "resize pod in place"
+	tWaitForPodResized            ttypes.TaskName = "wait for pod being resized"
+	tStopServerInCluster          ttypes.TaskName = "stop observer in cluster"
+	tWaitLeadersSwitchedOut       ttypes.TaskName = "wait leaders switched out"
+	tStartServerInCluster         ttypes.TaskName = "start observer in cluster"
+	tWaitOBServerStartedInCluster ttypes.TaskName = "wait observer started in cluster"
+	tForceDeletePod               ttypes.TaskName = "force delete pod"
+	tDeletePVCs                   ttypes.TaskName = "delete pvcs"
 )
+
+// timeouts of observer tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	tWaitOBServerDeletedInCluster: oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+}
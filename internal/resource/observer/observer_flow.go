@@ -75,7 +75,7 @@ func RecoverOBServer() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
 			Name:         fRecoverOBServer,
-			Tasks:        []tasktypes.TaskName{tCreateOBPod, tWaitOBServerReady, tWaitOBServerActiveInCluster},
+			Tasks:        []tasktypes.TaskName{tCreateOBPod, tWaitOBServerReady, tStartServerInCluster, tWaitOBServerActiveInCluster},
 			TargetStatus: serverstatus.Running,
 			OnFailure: tasktypes.FailureRule{
 				Strategy: strategy.RetryFromCurrent,
@@ -114,6 +114,16 @@ func ScaleUpOBServer() *tasktypes.TaskFlow {
 	}
 }
 
+func ResizeOBServerInPlace() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fResizeOBServerInPlace,
+			Tasks:        []tasktypes.TaskName{tResizePodInPlace, tWaitForPodResized, tWaitOBServerActiveInCluster},
+			TargetStatus: serverstatus.Running,
+		},
+	}
+}
+
 func ResizePVC() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
@@ -139,3 +149,47 @@ func MountBackupVolume() *tasktypes.TaskFlow {
 		},
 	}
 }
+
+func EnterMaintenance() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fEnterMaintenance,
+			Tasks:        []tasktypes.TaskName{tStopServerInCluster, tWaitLeadersSwitchedOut},
+			TargetStatus: serverstatus.Maintenance,
+			// stopping may have taken effect before failure, roll back by starting it again
+			OnFailure: tasktypes.FailureRule{
+				Strategy:      strategy.StartOver,
+				NextTryStatus: serverstatus.ExitMaintenance,
+			},
+		},
+	}
+}
+
+func ExitMaintenance() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fExitMaintenance,
+			Tasks:        []tasktypes.TaskName{tStartServerInCluster, tWaitOBServerStartedInCluster},
+			TargetStatus: serverstatus.Running,
+			OnFailure: tasktypes.FailureRule{
+				Strategy:      strategy.StartOver,
+				NextTryStatus: serverstatus.Maintenance,
+			},
+		},
+	}
+}
+
+func RelocateOBServer() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fRelocateOBServer,
+			Tasks:        []tasktypes.TaskName{tDeleteOBServerInCluster, tWaitOBServerDeletedInCluster, tForceDeletePod, tDeletePVCs},
+			TargetStatus: serverstatus.Unrecoverable,
+			// observer may have been deleted in cluster, it must not be taken as running again
+			OnFailure: tasktypes.FailureRule{
+				Strategy:      strategy.StartOver,
+				NextTryStatus: serverstatus.Relocate,
+			},
+		},
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package observer
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
+	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+var _ = Describe("OBServer task flows", func() {
+	newManager := func(status string) *OBServerManager {
+		logger := logr.Discard()
+		observer := &v1alpha1.OBServer{}
+		observer.Status.Status = status
+		return &OBServerManager{OBServer: observer, Logger: &logger}
+	}
+
+	It("Starts observer again when entering maintenance failed", func() {
+		m := newManager(serverstatus.EnterMaintenance)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Name).To(Equal(fEnterMaintenance))
+		m.OBServer.Status.OperationContext = flow.OperationContext
+		m.HandleFailure()
+		Expect(m.OBServer.Status.Status).To(Equal(serverstatus.ExitMaintenance))
+		Expect(m.OBServer.Status.OperationContext).To(BeNil())
+
+		flow, err = m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Name).To(Equal(fExitMaintenance))
+		Expect(flow.OperationContext.Tasks[0]).To(Equal(tStartServerInCluster))
+	})
+
+	It("Stays in maintenance when exiting maintenance failed", func() {
+		m := newManager(serverstatus.ExitMaintenance)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		m.OBServer.Status.OperationContext = flow.OperationContext
+		m.HandleFailure()
+		Expect(m.OBServer.Status.Status).To(Equal(serverstatus.Maintenance))
+	})
+
+	It("Waits for observer deleted in cluster before dropping its pvcs when relocating", func() {
+		m := newManager(serverstatus.Relocate)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Tasks).To(Equal([]tasktypes.TaskName{
+			tDeleteOBServerInCluster,
+			tWaitOBServerDeletedInCluster,
+			tForceDeletePod,
+			tDeletePVCs,
+		}))
+		for _, name := range flow.OperationContext.Tasks {
+			taskFunc, err := m.GetTaskFunc(name)
+			Expect(err).To(BeNil())
+			Expect(taskFunc).NotTo(BeNil())
+		}
+	})
+
+	It("Retries relocating instead of taking observer as running when relocating failed", func() {
+		m := newManager(serverstatus.Relocate)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.OnFailure.Strategy).To(BeEquivalentTo(strategy.StartOver))
+		m.OBServer.Status.OperationContext = flow.OperationContext
+		m.OBServer.Status.OperationContext.Idx = 2
+		m.HandleFailure()
+		Expect(m.OBServer.Status.Status).To(Equal(serverstatus.Relocate))
+		Expect(m.OBServer.Status.OperationContext).NotTo(BeNil())
+		Expect(m.OBServer.Status.OperationContext.Idx).To(Equal(0))
+	})
+
+	It("Limits only tasks with declared timeouts", func() {
+		m := newManager(serverstatus.Running)
+		Expect(m.GetTaskTimeout(tWaitOBServerDeletedInCluster)).To(Equal(oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second))
+		Expect(m.GetTaskTimeout(tForceDeletePod)).To(BeZero())
+	})
+})
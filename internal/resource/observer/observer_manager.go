@@ -14,6 +14,7 @@ package observer
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -44,6 +45,13 @@ type OBServerManager struct {
 	Logger   *logr.Logger
 }
 
+func (m *OBServerManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	if timeout, ok := taskTimeouts[name]; ok {
+		return timeout
+	}
+	return 0
+}
+
 func (m *OBServerManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tCreateOBServerSvc:
@@ -82,6 +90,22 @@ func (m *OBServerManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFu
 		return m.MountBackupVolume, nil
 	case tWaitForBackupVolumeMounted:
 		return m.WaitForBackupVolumeMounted, nil
+	case tResizePodInPlace:
+		return m.ResizePodInPlace, nil
+	case tWaitForPodResized:
+		return m.WaitForPodResized, nil
+	case tStopServerInCluster:
+		return m.StopServerInCluster, nil
+	case tWaitLeadersSwitchedOut:
+		return m.WaitLeadersSwitchedOut, nil
+	case tStartServerInCluster:
+		return m.StartServerInCluster, nil
+	case tWaitOBServerStartedInCluster:
+		return m.WaitOBServerStartedInCluster, nil
+	case tForceDeletePod:
+		return m.ForceDeletePod, nil
+	case tDeletePVCs:
+		return m.DeletePVCs, nil
 	default:
 		return nil, errors.Errorf("Can not find an function for task %s", name)
 	}
@@ -108,7 +132,35 @@ func (m *OBServerManager) SetOperationContext(c *tasktypes.OperationContext) {
 	m.OBServer.Status.OperationContext = c
 }
 
+func (m *OBServerManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.OBServer.Status.FlowHistory = tasktypes.AppendFlowRecord(m.OBServer.Status.FlowHistory, record)
+}
+
+// IsPaused tells whether reconciliation of observer is paused, pausing obzone or obcluster pauses its observers as well
+func (m *OBServerManager) IsPaused() bool {
+	if resourceutils.IsReconcilePaused(m.OBServer) {
+		return true
+	}
+	obzone, err := m.getOBZone()
+	if err == nil && resourceutils.IsReconcilePaused(obzone) {
+		return true
+	}
+	obcluster, err := m.getOBCluster()
+	return err == nil && resourceutils.IsReconcilePaused(obcluster)
+}
+
+func (m *OBServerManager) SetPaused(paused bool) bool {
+	if m.OBServer.Status.Paused == paused {
+		return false
+	}
+	m.OBServer.Status.Paused = paused
+	return true
+}
+
 func (m *OBServerManager) UpdateStatus() error {
+	if m.OBServer.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	// update deleting status when object is deleting
 	if m.IsDeleting() {
 		m.OBServer.Status.Status = serverstatus.Deleting
@@ -117,7 +169,8 @@ func (m *OBServerManager) UpdateStatus() error {
 		if err != nil {
 			if kubeerrors.IsNotFound(err) {
 				m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Pod not found")
-				if m.OBServer.Status.Status == serverstatus.Running {
+				// observer under maintenance is not recovered until the flag is cleared, recovery starts the server afterwards
+				if !m.OBServer.Spec.Maintenance && (m.OBServer.Status.Status == serverstatus.Running || m.OBServer.Status.Status == serverstatus.Maintenance) {
 					m.setRecoveryStatus()
 				}
 			} else {
@@ -149,7 +202,20 @@ func (m *OBServerManager) UpdateStatus() error {
 		if err != nil {
 			m.Logger.Info("Get pvc failed: " + err.Error())
 		}
-		// 1. Check status of observer in OB database
+		// 0. Check maintenance flag, stopped observer must not be recovered or changed until it exits maintenance
+		if m.OBServer.Status.Status == serverstatus.Running && m.OBServer.Spec.Maintenance {
+			m.Logger.Info("Maintenance flag is set, stop observer in cluster")
+			m.OBServer.Status.Status = serverstatus.EnterMaintenance
+		} else if m.OBServer.Status.Status == serverstatus.Maintenance && !m.OBServer.Spec.Maintenance && pod != nil {
+			m.Logger.Info("Maintenance flag is cleared, start observer in cluster")
+			m.OBServer.Status.Status = serverstatus.ExitMaintenance
+		}
+
+		// 1. Check node of observer and status of observer in OB database
+		if m.OBServer.Status.Status == serverstatus.Running && pod != nil && m.checkIfNodeFailed(pod) && m.checkIfRelocatable() {
+			m.Logger.Info("Node of observer failed, relocate observer", "node", pod.Spec.NodeName)
+			m.OBServer.Status.Status = serverstatus.Relocate
+		}
 		if m.OBServer.Status.Status == serverstatus.Running {
 			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Check observer in obcluster")
 			observer, err := m.getCurrentOBServerFromOB()
@@ -157,11 +223,8 @@ func (m *OBServerManager) UpdateStatus() error {
 				m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Get observer failed, check next time")
 			} else if observer == nil {
 				m.OBServer.Status.Status = serverstatus.AddServer
-			} else if mode, exist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode); exist && mode == oceanbaseconst.ModeStandalone {
-				if pod.Spec.Containers[0].Resources.Limits.Cpu().Cmp(m.OBServer.Spec.OBServerTemplate.Resource.Cpu) != 0 ||
-					pod.Spec.Containers[0].Resources.Limits.Memory().Cmp(m.OBServer.Spec.OBServerTemplate.Resource.Memory) != 0 {
-					m.OBServer.Status.Status = serverstatus.ScaleUp
-				}
+			} else if pod != nil && m.checkIfResourceChanged(pod) {
+				m.OBServer.Status.Status = serverstatus.ScaleUp
 			} else if pvcs != nil && len(pvcs.Items) > 0 && m.checkIfStorageExpand(pvcs) {
 				m.OBServer.Status.Status = serverstatus.ExpandPVC
 			} else if m.checkIfBackupVolumeAdded(pod) {
@@ -273,12 +336,26 @@ func (m *OBServerManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 	case serverstatus.AddServer:
 		taskFlow, err = task.GetRegistry().Get(fAddServerInOB)
 	case serverstatus.ScaleUp:
-		taskFlow, err = task.GetRegistry().Get(fScaleUpOBServer)
+		if m.supportInPlaceResize() {
+			m.Logger.Info("Resize observer in place")
+			taskFlow, err = task.GetRegistry().Get(fResizeOBServerInPlace)
+		} else if m.OBServer.SupportStaticIP() {
+			taskFlow, err = task.GetRegistry().Get(fScaleUpOBServer)
+		} else {
+			return nil, errors.New("Observer could neither be resized in place nor be recreated with the same ip")
+		}
 	case serverstatus.ExpandPVC:
 		taskFlow, err = task.GetRegistry().Get(fExpandPVC)
 	case serverstatus.MountBackupVolume:
 		m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Get task flow when observer need to mount backup volume")
 		taskFlow, err = task.GetRegistry().Get(fMountBackupVolume)
+	case serverstatus.EnterMaintenance:
+		taskFlow, err = task.GetRegistry().Get(fEnterMaintenance)
+	case serverstatus.ExitMaintenance:
+		taskFlow, err = task.GetRegistry().Get(fExitMaintenance)
+	case serverstatus.Relocate:
+		m.Logger.Info("Relocate observer on failed node")
+		taskFlow, err = task.GetRegistry().Get(fRelocateOBServer)
 	default:
 		m.Logger.V(oceanbaseconst.LogLevelTrace).Info("No need to run anything for observer")
 		return nil, nil
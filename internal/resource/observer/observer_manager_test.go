@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package observer
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
+)
+
+var _ = Describe("OBServer manager", func() {
+	newObjects := func() (*v1alpha1.OBCluster, *v1alpha1.OBZone, *v1alpha1.OBServer) {
+		obcluster := &v1alpha1.OBCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "c1"}}
+		obzone := &v1alpha1.OBZone{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "c1-z1"}}
+		observer := &v1alpha1.OBServer{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "c1-z1-s1",
+			Labels: map[string]string{
+				oceanbaseconst.LabelRefOBCluster: obcluster.Name,
+				oceanbaseconst.LabelRefOBZone:    obzone.Name,
+			},
+		}}
+		observer.Status.Status = serverstatus.Running
+		return obcluster, obzone, observer
+	}
+	newManager := func(obcluster *v1alpha1.OBCluster, obzone *v1alpha1.OBZone, observer *v1alpha1.OBServer) *OBServerManager {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		logger := logr.Discard()
+		return &OBServerManager{
+			Ctx:      context.Background(),
+			OBServer: observer,
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(obcluster, obzone, observer).Build(),
+			Logger:   &logger,
+		}
+	}
+	pause := func(obj metav1.Object) {
+		obj.SetAnnotations(map[string]string{oceanbaseconst.AnnotationsPauseReconcile: "true"})
+	}
+
+	It("Pauses observer with its own annotation", func() {
+		obcluster, obzone, observer := newObjects()
+		m := newManager(obcluster, obzone, observer)
+		Expect(m.IsPaused()).To(BeFalse())
+		pause(observer)
+		Expect(m.IsPaused()).To(BeTrue())
+	})
+
+	It("Pauses observer along with its obzone or obcluster", func() {
+		obcluster, obzone, observer := newObjects()
+		pause(obzone)
+		Expect(newManager(obcluster, obzone, observer).IsPaused()).To(BeTrue())
+
+		obcluster, obzone, observer = newObjects()
+		pause(obcluster)
+		Expect(newManager(obcluster, obzone, observer).IsPaused()).To(BeTrue())
+	})
+
+	It("Keeps status when it's paused", func() {
+		obcluster, obzone, observer := newObjects()
+		m := newManager(obcluster, obzone, observer)
+		Expect(m.SetPaused(true)).To(BeTrue())
+		Expect(m.SetPaused(true)).To(BeFalse())
+		Expect(m.OBServer.Status.Paused).To(BeTrue())
+		Expect(m.GetStatus()).To(Equal(serverstatus.Running))
+		Expect(m.SetPaused(false)).To(BeTrue())
+		Expect(m.OBServer.Status.Paused).To(BeFalse())
+	})
+})
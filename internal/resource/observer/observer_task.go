@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package observer
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
@@ -31,14 +33,16 @@ import (
 	podconst "github.com/oceanbase/ob-operator/internal/const/pod"
 	secretconst "github.com/oceanbase/ob-operator/internal/const/secret"
 	clusterstatus "github.com/oceanbase/ob-operator/internal/const/status/obcluster"
+	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	k8sresource "github.com/oceanbase/ob-operator/pkg/k8s/resource"
 	observerstatus "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/server"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
-func (m *OBServerManager) WaitOBServerReady() tasktypes.TaskError {
+func (m *OBServerManager) WaitOBServerReady(ctx context.Context) tasktypes.TaskError {
 	for i := 0; i < podconst.ReadyTimeoutSeconds; i++ {
 		observer, err := m.getOBServer()
 		if err != nil {
@@ -48,7 +52,9 @@ func (m *OBServerManager) WaitOBServerReady() tasktypes.TaskError {
 			m.Logger.Info("Pod is ready")
 			return nil
 		}
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 	return errors.New("Timeout to wait pod ready")
 }
@@ -61,7 +67,7 @@ func (m *OBServerManager) getOceanbaseOperationManager() (*operation.OceanbaseOp
 	return resourceutils.GetSysOperationClient(m.Client, m.Logger, obcluster)
 }
 
-func (m *OBServerManager) AddServer() tasktypes.TaskError {
+func (m *OBServerManager) AddServer(ctx context.Context) tasktypes.TaskError {
 	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
 	if modeAnnoExist && mode == oceanbaseconst.ModeStandalone {
 		m.Recorder.Event(m.OBServer, "SkipAddServer", "AddServer", "Skip add server in standalone mode")
@@ -89,7 +95,7 @@ func (m *OBServerManager) AddServer() tasktypes.TaskError {
 	return oceanbaseOperationManager.AddServer(serverInfo)
 }
 
-func (m *OBServerManager) WaitOBClusterBootstrapped() tasktypes.TaskError {
+func (m *OBServerManager) WaitOBClusterBootstrapped(ctx context.Context) tasktypes.TaskError {
 	for i := 0; i < oceanbaseconst.BootstrapTimeoutSeconds; i++ {
 		obcluster, err := m.getOBCluster()
 		if err != nil {
@@ -99,7 +105,9 @@ func (m *OBServerManager) WaitOBClusterBootstrapped() tasktypes.TaskError {
 			m.Logger.Info("OBCluster bootstrapped")
 			return nil
 		}
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 	return errors.New("Timeout to wait obcluster bootstrapped")
 }
@@ -117,7 +125,7 @@ func (m *OBServerManager) generateStaticIpAnnotation() map[string]string {
 	return annotations
 }
 
-func (m *OBServerManager) CreateOBPod() tasktypes.TaskError {
+func (m *OBServerManager) CreateOBPod(ctx context.Context) tasktypes.TaskError {
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("create observer pod")
 	obcluster, err := m.getOBCluster()
 	if err != nil {
@@ -144,7 +152,11 @@ func (m *OBServerManager) CreateOBPod() tasktypes.TaskError {
 		},
 		Spec: observerPodSpec,
 	}
-	err = m.Client.Create(m.Ctx, observerPod)
+	err = resourceutils.MergePodTemplate(observerPod, m.OBServer.Spec.OBServerTemplate.PodTemplate, m.OBServer.Spec.PodTemplate)
+	if err != nil {
+		return errors.Wrap(err, "Merge pod template overrides")
+	}
+	err = m.Client.Create(ctx, observerPod)
 	if err != nil {
 		m.Logger.Error(err, "failed to create pod")
 		return errors.Wrap(err, "failed to create pod")
@@ -165,7 +177,7 @@ func (m *OBServerManager) generatePVCSpec(storageSpec *apitypes.StorageSpec) cor
 	return *pvcSpec
 }
 
-func (m *OBServerManager) CreateOBPVC() tasktypes.TaskError {
+func (m *OBServerManager) CreateOBPVC(ctx context.Context) tasktypes.TaskError {
 	ownerReferenceList := make([]metav1.OwnerReference, 0)
 	sepVolumeAnnoVal, sepVolumeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsIndependentPVCLifecycle)
 	if !sepVolumeAnnoExist || sepVolumeAnnoVal != "true" {
@@ -196,7 +208,7 @@ func (m *OBServerManager) CreateOBPVC() tasktypes.TaskError {
 			},
 			Spec: m.generatePVCSpec(storageSpec),
 		}
-		err := m.Client.Create(m.Ctx, pvc)
+		err := m.Client.Create(ctx, pvc)
 		if err != nil {
 			return errors.Wrap(err, "Create single pvc of observer")
 		}
@@ -211,7 +223,7 @@ func (m *OBServerManager) CreateOBPVC() tasktypes.TaskError {
 			ObjectMeta: objectMeta,
 			Spec:       m.generatePVCSpec(m.OBServer.Spec.OBServerTemplate.Storage.DataStorage),
 		}
-		err := m.Client.Create(m.Ctx, pvc)
+		err := m.Client.Create(ctx, pvc)
 		if err != nil {
 			return errors.Wrap(err, "Create pvc of data file")
 		}
@@ -226,7 +238,7 @@ func (m *OBServerManager) CreateOBPVC() tasktypes.TaskError {
 			ObjectMeta: objectMeta,
 			Spec:       m.generatePVCSpec(m.OBServer.Spec.OBServerTemplate.Storage.RedoLogStorage),
 		}
-		err = m.Client.Create(m.Ctx, pvc)
+		err = m.Client.Create(ctx, pvc)
 		if err != nil {
 			return errors.Wrap(err, "Create pvc of data log")
 		}
@@ -241,7 +253,7 @@ func (m *OBServerManager) CreateOBPVC() tasktypes.TaskError {
 			ObjectMeta: objectMeta,
 			Spec:       m.generatePVCSpec(m.OBServer.Spec.OBServerTemplate.Storage.LogStorage),
 		}
-		err = m.Client.Create(m.Ctx, pvc)
+		err = m.Client.Create(ctx, pvc)
 		if err != nil {
 			return errors.Wrap(err, "Create pvc of log")
 		}
@@ -415,15 +427,7 @@ func (m *OBServerManager) createOBServerContainer(obcluster *v1alpha1.OBCluster)
 	ports = append(ports, infoPort)
 
 	// resource info
-	observerResource := corev1.ResourceList{}
-	observerResource["memory"] = m.OBServer.Spec.OBServerTemplate.Resource.Memory
-	if !m.OBServer.Spec.OBServerTemplate.Resource.Cpu.IsZero() {
-		observerResource["cpu"] = m.OBServer.Spec.OBServerTemplate.Resource.Cpu
-	}
-	resources := corev1.ResourceRequirements{
-		Requests: observerResource,
-		Limits:   observerResource,
-	}
+	resources := m.generateOBServerResources()
 
 	// volume mounts
 	volumeMountDataFile := corev1.VolumeMount{}
@@ -481,13 +485,9 @@ func (m *OBServerManager) createOBServerContainer(obcluster *v1alpha1.OBCluster)
 		Name:  "LD_LIBRARY_PATH",
 		Value: "/home/admin/oceanbase/lib",
 	}
-	cpuCount := m.OBServer.Spec.OBServerTemplate.Resource.Cpu.Value()
-	if cpuCount < 16 {
-		cpuCount = 16
-	}
 	envCpu := corev1.EnvVar{
 		Name:  "CPU_COUNT",
-		Value: fmt.Sprintf("%d", cpuCount),
+		Value: fmt.Sprintf("%d", m.getCpuCount()),
 	}
 
 	datafileSize, ok := m.OBServer.Spec.OBServerTemplate.Storage.DataStorage.Size.AsInt64()
@@ -589,7 +589,7 @@ func (m *OBServerManager) createOBServerContainer(obcluster *v1alpha1.OBCluster)
 	return container
 }
 
-func (m *OBServerManager) DeleteOBServerInCluster() tasktypes.TaskError {
+func (m *OBServerManager) DeleteOBServerInCluster(ctx context.Context) tasktypes.TaskError {
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("delete observer in cluster")
 	operationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
@@ -619,7 +619,7 @@ func (m *OBServerManager) DeleteOBServerInCluster() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) AnnotateOBServerPod() tasktypes.TaskError {
+func (m *OBServerManager) AnnotateOBServerPod(ctx context.Context) tasktypes.TaskError {
 	observerPod, err := m.getPod()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
@@ -628,14 +628,14 @@ func (m *OBServerManager) AnnotateOBServerPod() tasktypes.TaskError {
 		m.Logger.Info("Update pod annotation, cni is calico")
 		observerPod.Annotations[oceanbaseconst.AnnotationCalicoIpAddrs] = fmt.Sprintf("[\"%s\"]", m.OBServer.Status.PodIp)
 	}
-	err = m.Client.Update(m.Ctx, observerPod)
+	err = m.Client.Update(ctx, observerPod)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to update pod annotation of observer %s", m.OBServer.Name)
 	}
 	return nil
 }
 
-func (m *OBServerManager) UpgradeOBServerImage() tasktypes.TaskError {
+func (m *OBServerManager) UpgradeOBServerImage(ctx context.Context) tasktypes.TaskError {
 	observerPod, err := m.getPod()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
@@ -646,14 +646,14 @@ func (m *OBServerManager) UpgradeOBServerImage() tasktypes.TaskError {
 			break
 		}
 	}
-	err = m.Client.Update(m.Ctx, observerPod)
+	err = m.Client.Update(ctx, observerPod)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to update pod of observer %s", m.OBServer.Name)
 	}
 	return nil
 }
 
-func (m *OBServerManager) WaitOBServerPodReady() tasktypes.TaskError {
+func (m *OBServerManager) WaitOBServerPodReady(ctx context.Context) tasktypes.TaskError {
 	observerPodRestarted := false
 	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
 		observerPod, err := m.getPod()
@@ -672,7 +672,9 @@ func (m *OBServerManager) WaitOBServerPodReady() tasktypes.TaskError {
 			m.Logger.Info("observer pod restarted")
 			break
 		}
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 	if !observerPodRestarted {
 		return errors.Errorf("observer %s pod still not restart when timeout", m.OBServer.Name)
@@ -680,7 +682,7 @@ func (m *OBServerManager) WaitOBServerPodReady() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) WaitOBServerActiveInCluster() tasktypes.TaskError {
+func (m *OBServerManager) WaitOBServerActiveInCluster(ctx context.Context) tasktypes.TaskError {
 	if m.OBServer.SupportStaticIP() {
 		return nil
 	}
@@ -704,7 +706,9 @@ func (m *OBServerManager) WaitOBServerActiveInCluster() tasktypes.TaskError {
 		} else {
 			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("OBServer is nil, check next time")
 		}
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 	if !active {
 		m.Logger.Info("Wait for observer to become active, timeout")
@@ -715,8 +719,9 @@ func (m *OBServerManager) WaitOBServerActiveInCluster() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) WaitOBServerDeletedInCluster() tasktypes.TaskError {
-	if m.OBServer.SupportStaticIP() {
+func (m *OBServerManager) WaitOBServerDeletedInCluster(ctx context.Context) tasktypes.TaskError {
+	// relocated observer must be deleted before its replacement is created, even if the ip is kept
+	if m.OBServer.SupportStaticIP() && m.OBServer.Status.Status != serverstatus.Relocate {
 		return nil
 	}
 	m.Logger.Info("wait observer deleted in cluster")
@@ -738,7 +743,9 @@ func (m *OBServerManager) WaitOBServerDeletedInCluster() tasktypes.TaskError {
 		} else if err != nil {
 			m.Logger.Error(err, "Query observer info failed")
 		}
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 	if !deleted {
 		m.Logger.Info("Wait observer deleted timeout")
@@ -749,13 +756,13 @@ func (m *OBServerManager) WaitOBServerDeletedInCluster() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) DeletePod() tasktypes.TaskError {
+func (m *OBServerManager) DeletePod(ctx context.Context) tasktypes.TaskError {
 	m.Logger.Info("Delete observer pod")
 	pod, err := m.getPod()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
 	}
-	err = m.Client.Delete(m.Ctx, pod)
+	err = m.Client.Delete(ctx, pod)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to delete pod of observer %s", m.OBServer.Name)
 	}
@@ -763,11 +770,13 @@ func (m *OBServerManager) DeletePod() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) WaitForPodDeleted() tasktypes.TaskError {
+func (m *OBServerManager) WaitForPodDeleted(ctx context.Context) tasktypes.TaskError {
 	m.Logger.Info("Wait for observer pod being deleted")
 	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
-		time.Sleep(time.Second)
-		err := m.Client.Get(m.Ctx, m.generateNamespacedName(m.OBServer.Name), &corev1.Pod{})
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+		err := m.Client.Get(ctx, m.generateNamespacedName(m.OBServer.Name), &corev1.Pod{})
 		if err != nil && kubeerrors.IsNotFound(err) {
 			return nil
 		}
@@ -775,7 +784,7 @@ func (m *OBServerManager) WaitForPodDeleted() tasktypes.TaskError {
 	return errors.New("Timeout to wait for pod being deleted")
 }
 
-func (m *OBServerManager) ResizePVC() tasktypes.TaskError {
+func (m *OBServerManager) ResizePVC(ctx context.Context) tasktypes.TaskError {
 	observerPVC, err := m.getPVCs()
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get pvc list of observer %s", m.OBServer.Name)
@@ -785,19 +794,19 @@ func (m *OBServerManager) ResizePVC() tasktypes.TaskError {
 		switch pvc.Name {
 		case fmt.Sprintf("%s-%s", m.OBServer.Name, oceanbaseconst.DataVolumeSuffix):
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = m.OBServer.Spec.OBServerTemplate.Storage.DataStorage.Size
-			err = m.Client.Update(m.Ctx, &pvc)
+			err = m.Client.Update(ctx, &pvc)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to update pvc of observer %s", m.OBServer.Name)
 			}
 		case fmt.Sprintf("%s-%s", m.OBServer.Name, oceanbaseconst.ClogVolumeSuffix):
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = m.OBServer.Spec.OBServerTemplate.Storage.RedoLogStorage.Size
-			err = m.Client.Update(m.Ctx, &pvc)
+			err = m.Client.Update(ctx, &pvc)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to update pvc of observer %s", m.OBServer.Name)
 			}
 		case fmt.Sprintf("%s-%s", m.OBServer.Name, oceanbaseconst.LogVolumeSuffix):
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = m.OBServer.Spec.OBServerTemplate.Storage.LogStorage.Size
-			err = m.Client.Update(m.Ctx, &pvc)
+			err = m.Client.Update(ctx, &pvc)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to update pvc of observer %s", m.OBServer.Name)
 			}
@@ -807,7 +816,7 @@ func (m *OBServerManager) ResizePVC() tasktypes.TaskError {
 			sum.Add(m.OBServer.Spec.OBServerTemplate.Storage.RedoLogStorage.Size)
 			sum.Add(m.OBServer.Spec.OBServerTemplate.Storage.LogStorage.Size)
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = sum
-			err = m.Client.Update(m.Ctx, &pvc)
+			err = m.Client.Update(ctx, &pvc)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to update pvc of observer %s", m.OBServer.Name)
 			}
@@ -816,10 +825,12 @@ func (m *OBServerManager) ResizePVC() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) WaitForPVCResized() tasktypes.TaskError {
+func (m *OBServerManager) WaitForPVCResized(ctx context.Context) tasktypes.TaskError {
 outer:
 	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
-		time.Sleep(time.Second)
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
 
 		observerPVC, err := m.getPVCs()
 		if err != nil {
@@ -858,7 +869,7 @@ outer:
 	}
 	return errors.Errorf("Timeout to wait for pvc resized")
 }
-func (m *OBServerManager) CreateOBServerSvc() tasktypes.TaskError {
+func (m *OBServerManager) CreateOBServerSvc(ctx context.Context) tasktypes.TaskError {
 	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
 	if modeAnnoExist && mode == oceanbaseconst.ModeService {
 		m.Logger.Info("Create observer service")
@@ -887,7 +898,7 @@ func (m *OBServerManager) CreateOBServerSvc() tasktypes.TaskError {
 				}},
 			},
 		}
-		err := m.Client.Create(m.Ctx, svc)
+		err := m.Client.Create(ctx, svc)
 		if err != nil {
 			return errors.Wrapf(err, "Failed to create observer service")
 		}
@@ -895,10 +906,223 @@ func (m *OBServerManager) CreateOBServerSvc() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBServerManager) MountBackupVolume() tasktypes.TaskError {
+func (m *OBServerManager) MountBackupVolume(ctx context.Context) tasktypes.TaskError {
+	return nil
+}
+
+func (m *OBServerManager) WaitForBackupVolumeMounted(ctx context.Context) tasktypes.TaskError {
+	return nil
+}
+
+func (m *OBServerManager) ResizePodInPlace(ctx context.Context) tasktypes.TaskError {
+	pod, err := m.getPod()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
+	}
+	container := m.getOBServerContainer(pod)
+	if container == nil {
+		return errors.Errorf("Container %s not found in pod of observer %s", oceanbaseconst.ContainerName, m.OBServer.Name)
+	}
+	resources := m.generateOBServerResources()
+	// shrink memory_limit of observer first, otherwise the container would be killed for running out of memory
+	if resources.Limits.Memory().Cmp(*container.Resources.Limits.Memory()) < 0 {
+		err = m.setServerMemoryLimit()
+		if err != nil {
+			return errors.Wrapf(err, "Failed to set memory_limit of observer %s", m.OBServer.Name)
+		}
+	}
+	// shrink cpu_count of observer first as well, otherwise observer would be throttled heavily
+	if resources.Limits.Cpu().Cmp(*container.Resources.Limits.Cpu()) < 0 {
+		err = m.setServerCpuCount()
+		if err != nil {
+			return errors.Wrapf(err, "Failed to set cpu_count of observer %s", m.OBServer.Name)
+		}
+	}
+	m.Logger.Info("Resize observer pod in place", "resources", resources)
+	err = k8sresource.ResizePodInPlace(ctx, m.Client, pod, oceanbaseconst.ContainerName, resources, false)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to resize pod of observer %s", m.OBServer.Name)
+	}
 	return nil
 }
 
-func (m *OBServerManager) WaitForBackupVolumeMounted() tasktypes.TaskError {
+func (m *OBServerManager) WaitForPodResized(ctx context.Context) tasktypes.TaskError {
+	resources := m.generateOBServerResources()
+	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+		pod, err := m.getPod()
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
+		}
+		if pod.Status.Resize == corev1.PodResizeStatusInfeasible {
+			return errors.Errorf("Resize of pod %s is infeasible on node %s", pod.Name, pod.Spec.NodeName)
+		}
+		if k8sresource.IsPodResized(pod, oceanbaseconst.ContainerName, resources) {
+			// environment of the container is not changed by in-place resize, so set parameters of the server directly
+			err = m.setServerMemoryLimit()
+			if err != nil {
+				return errors.Wrapf(err, "Failed to set memory_limit of observer %s", m.OBServer.Name)
+			}
+			err = m.setServerCpuCount()
+			if err != nil {
+				return errors.Wrapf(err, "Failed to set cpu_count of observer %s", m.OBServer.Name)
+			}
+			return nil
+		}
+	}
+	return errors.New("Timeout to wait for pod being resized")
+}
+
+func (m *OBServerManager) StopServerInCluster(ctx context.Context) tasktypes.TaskError {
+	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
+	if modeAnnoExist && mode == oceanbaseconst.ModeStandalone {
+		m.Recorder.Event(m.OBServer, "SkipStopServer", "StopServer", "Skip stop server in standalone mode")
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Skip stop server in standalone mode")
+		return nil
+	}
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		return errors.Wrapf(err, "Get oceanbase operation manager failed")
+	}
+	serverInfo := &model.ServerInfo{
+		Ip:   m.OBServer.Status.GetConnectAddr(),
+		Port: oceanbaseconst.RpcPort,
+	}
+	observer, err := operationManager.GetServer(serverInfo)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get observer %s", serverInfo.Ip)
+	}
+	if observer == nil {
+		return errors.Errorf("OBServer %s not found in cluster", serverInfo.Ip)
+	}
+	if observer.StopTime > 0 {
+		m.Logger.Info("OBServer already stopped", "observer", serverInfo)
+		return nil
+	}
+	m.Recorder.Event(m.OBServer, corev1.EventTypeNormal, "StopServer", "Stop observer in cluster for maintenance")
+	return operationManager.StopServer(serverInfo)
+}
+
+func (m *OBServerManager) WaitLeadersSwitchedOut(ctx context.Context) tasktypes.TaskError {
+	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
+	if modeAnnoExist && mode == oceanbaseconst.ModeStandalone {
+		return nil
+	}
+	serverInfo := &model.ServerInfo{
+		Ip:   m.OBServer.Status.GetConnectAddr(),
+		Port: oceanbaseconst.RpcPort,
+	}
+	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
+		operationManager, err := m.getOceanbaseOperationManager()
+		if err != nil {
+			return errors.Wrapf(err, "Get oceanbase operation manager failed")
+		}
+		count, err := operationManager.CountLeadersOfServer(serverInfo)
+		if err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Count leaders of observer failed, check next time", "error", err.Error())
+		} else if count == 0 {
+			m.Logger.Info("All leaders switched out of observer", "observer", serverInfo)
+			return nil
+		} else {
+			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Leaders remain on observer", "count", count)
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("Timeout to wait leaders switched out of observer %s", serverInfo.Ip)
+}
+
+func (m *OBServerManager) StartServerInCluster(ctx context.Context) tasktypes.TaskError {
+	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
+	if modeAnnoExist && mode == oceanbaseconst.ModeStandalone {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Skip start server in standalone mode")
+		return nil
+	}
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		return errors.Wrapf(err, "Get oceanbase operation manager failed")
+	}
+	serverInfo := &model.ServerInfo{
+		Ip:   m.OBServer.Status.GetConnectAddr(),
+		Port: oceanbaseconst.RpcPort,
+	}
+	observer, err := operationManager.GetServer(serverInfo)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get observer %s", serverInfo.Ip)
+	}
+	if observer == nil || observer.StopTime == 0 {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("OBServer is not stopped, no need to start it", "observer", serverInfo)
+		return nil
+	}
+	m.Recorder.Event(m.OBServer, corev1.EventTypeNormal, "StartServer", "Start observer in cluster after maintenance")
+	return operationManager.StartServer(serverInfo)
+}
+
+func (m *OBServerManager) WaitOBServerStartedInCluster(ctx context.Context) tasktypes.TaskError {
+	mode, modeAnnoExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
+	if modeAnnoExist && mode == oceanbaseconst.ModeStandalone {
+		return nil
+	}
+	serverInfo := &model.ServerInfo{
+		Ip:   m.OBServer.Status.GetConnectAddr(),
+		Port: oceanbaseconst.RpcPort,
+	}
+	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
+		operationManager, err := m.getOceanbaseOperationManager()
+		if err != nil {
+			return errors.Wrapf(err, "Get oceanbase operation manager failed")
+		}
+		observer, _ := operationManager.GetServer(serverInfo)
+		if observer != nil && observer.Status == observerstatus.Active && observer.StartServiceTime > 0 && observer.StopTime == 0 {
+			m.Logger.Info("OBServer started in cluster", "observer", serverInfo)
+			return nil
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("Timeout to wait observer %s started in cluster", serverInfo.Ip)
+}
+
+func (m *OBServerManager) ForceDeletePod(ctx context.Context) tasktypes.TaskError {
+	pod, err := m.getPod()
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "Failed to get pod of observer %s", m.OBServer.Name)
+	}
+	m.Logger.Info("Force delete observer pod", "node", pod.Spec.NodeName)
+	// kubelet of the failed node could not confirm the deletion, so the pod is deleted without grace period
+	err = m.Client.Delete(ctx, pod, client.GracePeriodSeconds(0))
+	if err != nil && !kubeerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "Failed to force delete pod of observer %s", m.OBServer.Name)
+	}
+	return nil
+}
+
+func (m *OBServerManager) DeletePVCs(ctx context.Context) tasktypes.TaskError {
+	if m.OBServer.Labels[oceanbaseconst.LabelRefUID] == "" {
+		return errors.Errorf("Observer %s has no label %s to select its pvcs", m.OBServer.Name, oceanbaseconst.LabelRefUID)
+	}
+	if err := m.checkIfVolumesLocal(); err != nil {
+		return errors.Wrapf(err, "Refuse to delete pvcs of observer %s", m.OBServer.Name)
+	}
+	pvcs, err := m.getPVCs()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to list pvcs of observer %s", m.OBServer.Name)
+	}
+	for i := range pvcs.Items {
+		m.Logger.Info("Delete pvc of observer", "pvc", pvcs.Items[i].Name)
+		err = m.Client.Delete(ctx, &pvcs.Items[i])
+		if err != nil && !kubeerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete pvc %s", pvcs.Items[i].Name)
+		}
+	}
+	relocationCheckedAt.Delete(m.OBServer.UID)
+	m.Recorder.Event(m.OBServer, corev1.EventTypeNormal, "ObserverRelocated", "Observer was deleted from cluster and its pvcs were dropped, a replacement will be created")
 	return nil
 }
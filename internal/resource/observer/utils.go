@@ -13,10 +13,15 @@ See the Mulan PSL v2 for more details.
 package observer
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -26,7 +31,10 @@ import (
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	k8sresource "github.com/oceanbase/ob-operator/pkg/k8s/resource"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/param"
+	obutil "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
 )
 
 // get observer from K8s api server
@@ -179,3 +187,205 @@ func (m *OBServerManager) checkIfBackupVolumeAdded(pod *corev1.Pod) bool {
 	}
 	return false
 }
+
+func (m *OBServerManager) generateOBServerResources() corev1.ResourceRequirements {
+	observerResource := corev1.ResourceList{}
+	observerResource["memory"] = m.OBServer.Spec.OBServerTemplate.Resource.Memory
+	if !m.OBServer.Spec.OBServerTemplate.Resource.Cpu.IsZero() {
+		observerResource["cpu"] = m.OBServer.Spec.OBServerTemplate.Resource.Cpu
+	}
+	return corev1.ResourceRequirements{
+		Requests: observerResource,
+		Limits:   observerResource,
+	}
+}
+
+func (m *OBServerManager) getOBServerContainer(pod *corev1.Pod) *corev1.Container {
+	for i, container := range pod.Spec.Containers {
+		if container.Name == oceanbaseconst.ContainerName {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+func (m *OBServerManager) checkIfResourceChanged(pod *corev1.Pod) bool {
+	container := m.getOBServerContainer(pod)
+	if container == nil {
+		return false
+	}
+	resources := m.generateOBServerResources()
+	return container.Resources.Limits.Cpu().Cmp(*resources.Limits.Cpu()) != 0 ||
+		container.Resources.Limits.Memory().Cmp(*resources.Limits.Memory()) != 0
+}
+
+// supportInPlaceResize tells whether the pod of observer could be resized without being recreated
+func (m *OBServerManager) supportInPlaceResize() bool {
+	pod, err := m.getPod()
+	if err != nil {
+		m.Logger.Error(err, "Failed to get pod of observer")
+		return false
+	}
+	err = k8sresource.ResizePodInPlace(m.Ctx, m.Client, pod, oceanbaseconst.ContainerName, m.generateOBServerResources(), true)
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("In-place pod resize is not supported", "reason", err.Error())
+		return false
+	}
+	return true
+}
+
+// setServerMemoryLimit sets memory_limit of this observer to the one of obcluster,
+// which keeps observer from exceeding memory of the container before parameters of obcluster are synced
+func (m *OBServerManager) setServerMemoryLimit() error {
+	memoryLimit := fmt.Sprintf("%dM", m.OBServer.Spec.OBServerTemplate.Resource.Memory.Value()*oceanbaseconst.DefaultMemoryLimitPercent/100/oceanbaseconst.MegaConverter)
+	return m.setServerParameter("memory_limit", memoryLimit)
+}
+
+// setServerCpuCount sets cpu_count of this observer to the one of obcluster, which is the same as
+// the environment of the container in which observer starts
+func (m *OBServerManager) setServerCpuCount() error {
+	return m.setServerParameter("cpu_count", strconv.FormatInt(m.getCpuCount(), 10))
+}
+
+// setServerParameter sets parameter of this observer, value specified in parameters of obcluster takes precedence
+func (m *OBServerManager) setServerParameter(name, value string) error {
+	obcluster, err := m.getOBCluster()
+	if err != nil {
+		return errors.Wrap(err, "Get obcluster")
+	}
+	for _, parameter := range obcluster.Spec.Parameters {
+		if parameter.Name == name {
+			value = parameter.Value
+			break
+		}
+	}
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		return errors.Wrap(err, "Get oceanbase operation manager")
+	}
+	serverIP := m.OBServer.Status.GetConnectAddr()
+	mode, modeExist := resourceutils.GetAnnotationField(m.OBServer, oceanbaseconst.AnnotationsMode)
+	if modeExist && mode == oceanbaseconst.ModeStandalone {
+		serverIP = "127.0.0.1"
+	}
+	return operationManager.SetParameter(name, value, &param.Scope{
+		Name:  "server",
+		Value: fmt.Sprintf("%s:%d", serverIP, oceanbaseconst.RpcPort),
+	})
+}
+
+// getCpuCount returns cpu_count that observer starts with
+func (m *OBServerManager) getCpuCount() int64 {
+	return resourceutils.GetCpuCount(m.OBServer.Spec.OBServerTemplate.Resource.Cpu)
+}
+
+// checkIfNodeFailed checks whether the node of observer keeps NotReady longer than the timeout of node failure policy
+func (m *OBServerManager) checkIfNodeFailed(pod *corev1.Pod) bool {
+	if pod.Spec.NodeName == "" || m.OBServer.Spec.Maintenance {
+		return false
+	}
+	obcluster, err := m.getOBCluster()
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Get obcluster failed, skip checking node")
+		return false
+	}
+	policy := obcluster.Spec.NodeFailurePolicy
+	if policy == nil || !policy.Relocate {
+		return false
+	}
+	timeoutStr := policy.NotReadyTimeout
+	if timeoutStr == "" {
+		timeoutStr = oceanbaseconst.DefaultNodeNotReadyTimeout
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		m.Logger.Error(err, "Invalid not ready timeout of node failure policy", "timeout", timeoutStr)
+		return false
+	}
+	node := &corev1.Node{}
+	err = m.Client.Get(m.Ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+	if err != nil {
+		if !kubeerrors.IsNotFound(err) {
+			return false
+		}
+		// node removed from cluster, take the time when pod became not ready instead
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady {
+				return condition.Status != corev1.ConditionTrue && time.Since(condition.LastTransitionTime.Time) > timeout
+			}
+		}
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status != corev1.ConditionTrue && time.Since(condition.LastTransitionTime.Time) > timeout
+		}
+	}
+	return false
+}
+
+// relocationCheckedAt records the last time of checking whether observers are relocatable, keyed by uid of observer
+var relocationCheckedAt sync.Map
+
+// checkIfRelocatable checks that observer only uses local volumes and that log streams on it keep majority of replicas healthy without it.
+// It connects to obcluster, so it's checked at most once every RelocationCheckGapSeconds for each observer.
+func (m *OBServerManager) checkIfRelocatable() bool {
+	if checkedAt, ok := relocationCheckedAt.Load(m.OBServer.UID); ok && time.Since(checkedAt.(time.Time)) < oceanbaseconst.RelocationCheckGapSeconds*time.Second {
+		return false
+	}
+	relocationCheckedAt.Store(m.OBServer.UID, time.Now())
+	if err := m.checkIfVolumesLocal(); err != nil {
+		m.Recorder.Event(m.OBServer, corev1.EventTypeWarning, "RelocationBlocked", "Observer is not relocated: "+err.Error())
+		return false
+	}
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Get oceanbase operation manager failed, skip relocating")
+		return false
+	}
+	servers, err := operationManager.ListServers()
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("List servers failed, skip relocating")
+		return false
+	}
+	serverInfo := &model.ServerInfo{
+		Ip:   m.OBServer.Status.GetConnectAddr(),
+		Port: oceanbaseconst.RpcPort,
+	}
+	replicas, err := operationManager.ListLSReplicasOfLSOnServer(serverInfo)
+	if err != nil {
+		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("List log stream replicas failed, skip relocating")
+		return false
+	}
+	if !obutil.CheckMajorityReplicasHealthy(replicas, servers, serverInfo) {
+		m.Recorder.Event(m.OBServer, corev1.EventTypeWarning, "RelocationBlocked", "Majority of replicas on other servers is not healthy, observer is not relocated")
+		return false
+	}
+	return true
+}
+
+// checkIfVolumesLocal checks that all pvcs of observer are bound to local volumes pinned to nodes.
+// Data on local volumes is lost along with the failed node, while other volumes could be attached elsewhere and must not be dropped.
+func (m *OBServerManager) checkIfVolumesLocal() error {
+	pvcs, err := m.getPVCs()
+	if err != nil {
+		return err
+	}
+	if len(pvcs.Items) == 0 {
+		return errors.New("no pvc found")
+	}
+	for _, pvc := range pvcs.Items {
+		if pvc.Spec.VolumeName == "" {
+			return errors.Errorf("pvc %s is not bound", pvc.Name)
+		}
+		pv := &corev1.PersistentVolume{}
+		err = m.Client.Get(m.Ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv)
+		if err != nil {
+			return errors.Wrapf(err, "get pv %s", pvc.Spec.VolumeName)
+		}
+		if pv.Spec.Local == nil && (pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil) {
+			return errors.Errorf("pv %s of pvc %s is not a local volume", pv.Name, pvc.Name)
+		}
+	}
+	return nil
+}
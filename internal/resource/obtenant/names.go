@@ -13,6 +13,9 @@ See the Mulan PSL v2 for more details.
 package obtenant
 
 import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -57,3 +60,13 @@ const (
 	tCreateEmptyStandbyTenant      ttypes.TaskName = "create empty standby tenant"
 	tUpgradeTenantIfNeeded         ttypes.TaskName = "upgrade tenant if needed"
 )
+
+// timeouts of obtenant tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	tCreateTenant:       oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+	tMaintainUnitNum:    oceanbaseconst.LocalityChangeTimeoutSeconds * time.Second,
+	tMaintainLocality:   oceanbaseconst.LocalityChangeTimeoutSeconds * time.Second,
+	tAddResourcePool:    oceanbaseconst.LocalityChangeTimeoutSeconds * time.Second,
+	tDeleteResourcePool: oceanbaseconst.LocalityChangeTimeoutSeconds * time.Second,
+	tMaintainUnitConfig: oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+}
@@ -18,6 +18,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -92,7 +93,7 @@ func (m *OBTenantManager) InitStatus() {
 	if m.OBTenant.Spec.Source != nil && m.OBTenant.Spec.Source.Restore != nil {
 		m.OBTenant.Status.Status = tenantstatus.Restoring
 		m.Recorder.Event(m.OBTenant, "InitRestore", "", "start restoring")
-	} else if m.OBTenant.Spec.Source != nil && m.OBTenant.Spec.Source.Tenant != nil {
+	} else if m.OBTenant.Spec.Source != nil && (m.OBTenant.Spec.Source.Tenant != nil || m.OBTenant.Spec.Source.External != nil) {
 		m.Recorder.Event(m.OBTenant, "InitEmptyStandby", "", "start creating empty standby")
 		m.OBTenant.Status.Status = tenantstatus.CreatingEmptyStandby
 	} else {
@@ -105,6 +106,22 @@ func (m *OBTenantManager) SetOperationContext(ctx *tasktypes.OperationContext) {
 	m.OBTenant.Status.OperationContext = ctx
 }
 
+func (m *OBTenantManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.OBTenant.Status.FlowHistory = tasktypes.AppendFlowRecord(m.OBTenant.Status.FlowHistory, record)
+}
+
+func (m *OBTenantManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.OBTenant)
+}
+
+func (m *OBTenantManager) SetPaused(paused bool) bool {
+	if m.OBTenant.Status.Paused == paused {
+		return false
+	}
+	m.OBTenant.Status.Paused = paused
+	return true
+}
+
 func (m *OBTenantManager) ClearTaskInfo() {
 	m.OBTenant.Status.Status = tenantstatus.Running
 	m.OBTenant.Status.OperationContext = nil
@@ -156,6 +173,9 @@ func (m *OBTenantManager) retryUpdateStatus() error {
 }
 
 func (m *OBTenantManager) UpdateStatus() error {
+	if m.OBTenant.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	obtenantName := m.OBTenant.Spec.TenantName
 	var err error
 	if m.OBTenant.Status.Status == tenantstatus.FinalizerFinished {
@@ -179,6 +199,7 @@ func (m *OBTenantManager) UpdateStatus() error {
 			return err
 		}
 		m.OBTenant.Status = *tenantStatusCurrent
+		m.updatePlan()
 
 		nextStatus, err := m.NextStatus()
 		if err != nil {
@@ -224,6 +245,13 @@ func (m *OBTenantManager) CheckAndUpdateFinalizers() error {
 	return nil
 }
 
+func (m *OBTenantManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	if timeout, ok := taskTimeouts[name]; ok {
+		return timeout
+	}
+	return 0
+}
+
 func (m *OBTenantManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tCheckTenant:
@@ -385,47 +413,61 @@ func (m *OBTenantManager) getObTenant() (*v1alpha1.OBTenant, error) {
 // --------- compare spec and status ----------
 
 func (m *OBTenantManager) NextStatus() (string, error) {
+	statuses, err := m.pendingStatuses()
+	if len(statuses) > 0 {
+		return statuses[0], nil
+	}
+	if err != nil {
+		return tenantstatus.Running, err
+	}
+	return tenantstatus.Running, nil
+}
+
+// pendingStatuses returns statuses to handle in order, the first one is what the obtenant turns into.
+// Statuses found before a failed check are returned along with the error
+func (m *OBTenantManager) pendingStatuses() ([]string, error) {
 	tenantName := m.OBTenant.Spec.TenantName
+	statuses := make([]string, 0)
 
 	// note: change order of state check functions may cause bugs
 	hasModifiedResourcePool := m.hasToAddPool()
 	if hasModifiedResourcePool {
 		m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Maintain Tenant ----- Resource Pool modified", "tenantName", tenantName)
-		return tenantstatus.AddingResourcePool, nil
+		statuses = append(statuses, tenantstatus.AddingResourcePool)
 	}
 	hasModifiedTenant := m.hasToDeletePool()
 	if hasModifiedTenant {
 		m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Maintain Tenant ----- Tenant modified", "tenantName", tenantName)
-		return tenantstatus.DeletingResourcePool, nil
+		statuses = append(statuses, tenantstatus.DeletingResourcePool)
 	}
 	hasModifiedLocality := m.hasModifiedLocality()
 	if hasModifiedLocality {
-		return tenantstatus.MaintainingLocality, nil
+		statuses = append(statuses, tenantstatus.MaintainingLocality)
 	}
 	hasModifiedPriority := m.hasModifiedPrimaryZone()
 	if hasModifiedPriority {
-		return tenantstatus.MaintainingPrimaryZone, nil
+		statuses = append(statuses, tenantstatus.MaintainingPrimaryZone)
 	}
 	hasModifiedTcpInvitedNode := m.hasModifiedWhiteList()
 	if hasModifiedTcpInvitedNode {
-		return tenantstatus.MaintainingWhiteList, nil
+		statuses = append(statuses, tenantstatus.MaintainingWhiteList)
 	}
 	hasModifiedCharset := m.hasModifiedCharset()
 	if hasModifiedCharset {
-		return tenantstatus.MaintainingCharset, nil
+		statuses = append(statuses, tenantstatus.MaintainingCharset)
 	}
 	hasModifiedUnitNum := m.hasModifiedUnitNum()
 	if hasModifiedUnitNum {
-		return tenantstatus.MaintainingUnitNum, nil
+		statuses = append(statuses, tenantstatus.MaintainingUnitNum)
 	}
 	hasModifiedUnitConfig, err := m.hasModifiedUnitConfig()
 	if err != nil {
-		return tenantstatus.Running, err
+		return statuses, err
 	}
 	if hasModifiedUnitConfig {
-		return tenantstatus.MaintainingUnitConfig, nil
+		statuses = append(statuses, tenantstatus.MaintainingUnitConfig)
 	}
-	return tenantstatus.Running, nil
+	return statuses, nil
 }
 
 // ---------- Check function ----------
@@ -563,6 +605,8 @@ func (m *OBTenantManager) buildTenantStatus() (*v1alpha1.OBTenantStatus, error)
 	tenantCurrentStatus.Status = m.OBTenant.Status.Status
 	tenantCurrentStatus.Pools = poolStatusList
 	tenantCurrentStatus.OperationContext = m.OBTenant.Status.OperationContext
+	tenantCurrentStatus.FlowHistory = m.OBTenant.Status.FlowHistory
+	tenantCurrentStatus.Plan = m.OBTenant.Status.Plan
 
 	tenantCurrentStatus.TenantRecordInfo = v1alpha1.TenantRecordInfo{}
 	tenantCurrentStatus.TenantRecordInfo.TenantID = int(obtenant.TenantID)
@@ -595,6 +639,18 @@ func (m *OBTenantManager) buildTenantStatus() (*v1alpha1.OBTenantStatus, error)
 	tenantCurrentStatus.TenantRecordInfo.ZoneList = strings.Join(zoneList, ",")
 	tenantCurrentStatus.TenantRecordInfo.Collate = m.OBTenant.Spec.Collate
 
+	tenantCurrentStatus.MajorCompaction = m.OBTenant.Status.MajorCompaction
+	if resourceutils.NeedRefreshMajorCompactionStatus(tenantCurrentStatus.MajorCompaction) {
+		// major compaction status is informative, failure of refreshing it is retried next time
+		if con, err := m.getClusterSysClient(); err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Failed to get sys client when refreshing major compaction status", "error", err.Error())
+		} else if compactionStatus, err := resourceutils.GetMajorCompactionStatus(con, obtenant.TenantID); err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Failed to get major compaction status of tenant", "tenantName", tenantName, "error", err.Error())
+		} else {
+			tenantCurrentStatus.MajorCompaction = compactionStatus
+		}
+	}
+
 	// Root password changed
 	if _, err = m.getTenantClient(); err != nil {
 		tenantCurrentStatus.Credentials.Root = m.OBTenant.Spec.Credentials.Root
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package obtenant
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -42,11 +43,11 @@ import (
 
 // ---------- task entry point ----------
 
-func (m *OBTenantManager) CreateTenantTaskWithClear() tasktypes.TaskError {
-	err := m.CreateTenantTask()
+func (m *OBTenantManager) CreateTenantTaskWithClear(ctx context.Context) tasktypes.TaskError {
+	err := m.CreateTenantTask(ctx)
 	// clean created resource, restore to the initial state
 	if err != nil {
-		err := m.DeleteTenantTask()
+		err := m.DeleteTenantTask(ctx)
 		if err != nil {
 			err = errors.Wrapf(err, "delete tenant when creating tenant")
 			return err
@@ -55,11 +56,11 @@ func (m *OBTenantManager) CreateTenantTaskWithClear() tasktypes.TaskError {
 	return err
 }
 
-func (m *OBTenantManager) CreateResourcePoolAndConfigTaskWithClear() tasktypes.TaskError {
-	err := m.CreateResourcePoolAndConfigTask()
+func (m *OBTenantManager) CreateResourcePoolAndConfigTaskWithClear(ctx context.Context) tasktypes.TaskError {
+	err := m.CreateResourcePoolAndConfigTask(ctx)
 	// clean created resource, restore to the initial state
 	if err != nil {
-		err := m.DeleteTenantTask()
+		err := m.DeleteTenantTask(ctx)
 		if err != nil {
 			err = errors.Wrapf(err, "delete tenant when creating tenant")
 			return err
@@ -68,9 +69,9 @@ func (m *OBTenantManager) CreateResourcePoolAndConfigTaskWithClear() tasktypes.T
 	return err
 }
 
-func (m *OBTenantManager) CreateTenantTask() tasktypes.TaskError {
+func (m *OBTenantManager) CreateTenantTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
-	err := m.createTenant()
+	err := m.createTenant(ctx)
 	if err != nil {
 		m.Logger.Error(err, "Create Tenant failed", "tenantName", tenantName)
 		return err
@@ -78,7 +79,7 @@ func (m *OBTenantManager) CreateTenantTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CreateResourcePoolAndConfigTask() tasktypes.TaskError {
+func (m *OBTenantManager) CreateResourcePoolAndConfigTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 
 	for _, pool := range m.OBTenant.Spec.Pools {
@@ -91,7 +92,7 @@ func (m *OBTenantManager) CreateResourcePoolAndConfigTask() tasktypes.TaskError
 	return nil
 }
 
-func (m *OBTenantManager) CheckTenantTask() tasktypes.TaskError {
+func (m *OBTenantManager) CheckTenantTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	tenantExist, err := m.tenantExist(tenantName)
 	if err != nil {
@@ -106,7 +107,7 @@ func (m *OBTenantManager) CheckTenantTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckPoolAndConfigTask() tasktypes.TaskError {
+func (m *OBTenantManager) CheckPoolAndConfigTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	client, err := m.getClusterSysClient()
 	if err != nil {
@@ -153,9 +154,9 @@ func (m *OBTenantManager) CheckPoolAndConfigTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) MaintainWhiteListTask() tasktypes.TaskError {
+func (m *OBTenantManager) MaintainWhiteListTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
-	err := m.CheckAndApplyWhiteList()
+	err := m.CheckAndApplyWhiteList(ctx)
 	if err != nil {
 		m.Logger.Error(err, "maintain tenant, check and set whitelist (tcp invited node)", "tenantName", tenantName)
 		return err
@@ -163,11 +164,11 @@ func (m *OBTenantManager) MaintainWhiteListTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) AddPoolTask() tasktypes.TaskError {
+func (m *OBTenantManager) AddPoolTask(ctx context.Context) tasktypes.TaskError {
 	// handle add pool
 	poolSpecs := m.getPoolsForAdd()
 	for _, addPool := range poolSpecs {
-		err := m.tenantAddPool(addPool)
+		err := m.tenantAddPool(ctx, addPool)
 		if err != nil {
 			return err
 		}
@@ -175,11 +176,11 @@ func (m *OBTenantManager) AddPoolTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) DeletePoolTask() tasktypes.TaskError {
+func (m *OBTenantManager) DeletePoolTask(ctx context.Context) tasktypes.TaskError {
 	// handle delete pool
 	poolStatuses := m.getPoolsForDelete()
 	for _, poolStatus := range poolStatuses {
-		err := m.TenantDeletePool(poolStatus)
+		err := m.TenantDeletePool(ctx, poolStatus)
 		if err != nil {
 			return err
 		}
@@ -187,7 +188,7 @@ func (m *OBTenantManager) DeletePoolTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) MaintainUnitConfigTask() tasktypes.TaskError {
+func (m *OBTenantManager) MaintainUnitConfigTask(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 
 	version, err := m.getOBVersion()
@@ -196,26 +197,26 @@ func (m *OBTenantManager) MaintainUnitConfigTask() tasktypes.TaskError {
 		return err
 	}
 	if string(version[0]) == tenant.Version4 {
-		return m.CheckAndApplyUnitConfigV4()
+		return m.CheckAndApplyUnitConfigV4(ctx)
 	}
 	return errors.New("no match version for check and set unit config")
 }
 
-func (m *OBTenantManager) DeleteTenantTask() tasktypes.TaskError {
+func (m *OBTenantManager) DeleteTenantTask(ctx context.Context) tasktypes.TaskError {
 	var err error
 	tenantName := m.OBTenant.Spec.TenantName
 	m.Logger.Info("Delete Tenant", "tenantName", tenantName)
-	err = m.deleteTenant()
+	err = m.deleteTenant(ctx)
 	if err != nil {
 		return err
 	}
 	m.Logger.Info("Delete Pool", "tenantName", tenantName)
-	err = m.deletePool()
+	err = m.deletePool(ctx)
 	if err != nil {
 		return err
 	}
 	m.Logger.Info("Delete Unit", "tenantName", tenantName)
-	err = m.deleteUnitConfig()
+	err = m.deleteUnitConfig(ctx)
 	if err != nil {
 		return err
 	}
@@ -223,13 +224,13 @@ func (m *OBTenantManager) DeleteTenantTask() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) AddFinalizerTask() tasktypes.TaskError {
+func (m *OBTenantManager) AddFinalizerTask(ctx context.Context) tasktypes.TaskError {
 	return nil
 }
 
 // ---------- Check And Apply function ----------
 
-func (m *OBTenantManager) CheckAndApplyWhiteList() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyWhiteList(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -257,7 +258,7 @@ func (m *OBTenantManager) CheckAndApplyWhiteList() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckAndApplyUnitConfigV4() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyUnitConfigV4(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	specUnitConfigMap := m.generateSpecUnitConfigV4Map(m.OBTenant.Spec)
 	statusUnitConfigMap := m.GenerateStatusUnitConfigV4Map(m.OBTenant.Status)
@@ -289,7 +290,7 @@ func (m *OBTenantManager) CheckAndApplyUnitConfigV4() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckAndApplyUnitNum() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyUnitNum(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -305,7 +306,7 @@ func (m *OBTenantManager) CheckAndApplyUnitNum() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckAndApplyPrimaryZone() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyPrimaryZone(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -329,7 +330,7 @@ func (m *OBTenantManager) CheckAndApplyPrimaryZone() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckAndApplyLocality() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyLocality(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -357,13 +358,15 @@ func (m *OBTenantManager) CheckAndApplyLocality() tasktypes.TaskError {
 		if !exist {
 			break
 		}
-		time.Sleep(config.PollingJobSleepTime)
+		if err := resourceutils.SleepWithContext(ctx, config.PollingJobSleepTime); err != nil {
+			return err
+		}
 	}
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("'ALTER_TENANT' Job for addPool successes", "tenantName", tenantName)
 	return nil
 }
 
-func (m *OBTenantManager) CheckAndApplyCharset() tasktypes.TaskError {
+func (m *OBTenantManager) CheckAndApplyCharset(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -388,7 +391,7 @@ func (m *OBTenantManager) CheckAndApplyCharset() tasktypes.TaskError {
 
 // ---------- action function ----------
 
-func (m *OBTenantManager) createTenant() tasktypes.TaskError {
+func (m *OBTenantManager) createTenant(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	pools := m.OBTenant.Spec.Pools
 	m.Logger.Info("Create Tenant", "tenantName", tenantName)
@@ -480,7 +483,7 @@ func (m *OBTenantManager) getPoolsForDelete() []v1alpha1.ResourcePoolStatus {
 	return poolStatuses
 }
 
-func (m *OBTenantManager) tenantAddPool(poolAdd v1alpha1.ResourcePoolSpec) error {
+func (m *OBTenantManager) tenantAddPool(ctx context.Context, poolAdd v1alpha1.ResourcePoolSpec) error {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -527,7 +530,9 @@ func (m *OBTenantManager) tenantAddPool(poolAdd v1alpha1.ResourcePoolSpec) error
 		if !exist {
 			break
 		}
-		time.Sleep(config.PollingJobSleepTime)
+		if err := resourceutils.SleepWithContext(ctx, config.PollingJobSleepTime); err != nil {
+			return err
+		}
 	}
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("'ALTER_TENANT' Job for addPool successes", "tenantName", tenantName)
 
@@ -535,7 +540,7 @@ func (m *OBTenantManager) tenantAddPool(poolAdd v1alpha1.ResourcePoolSpec) error
 	return nil
 }
 
-func (m *OBTenantManager) TenantDeletePool(poolDelete v1alpha1.ResourcePoolStatus) error {
+func (m *OBTenantManager) TenantDeletePool(ctx context.Context, poolDelete v1alpha1.ResourcePoolStatus) error {
 	tenantName := m.OBTenant.Spec.TenantName
 	poolName := m.generatePoolName(poolDelete.ZoneList)
 	unitName := m.generateUnitName(poolDelete.ZoneList)
@@ -578,7 +583,9 @@ func (m *OBTenantManager) TenantDeletePool(poolDelete v1alpha1.ResourcePoolStatu
 		if !exist {
 			break
 		}
-		time.Sleep(config.PollingJobSleepTime)
+		if err := resourceutils.SleepWithContext(ctx, config.PollingJobSleepTime); err != nil {
+			return err
+		}
 	}
 
 	// step 1.2: update resource pool list
@@ -1043,7 +1050,7 @@ func (m *OBTenantManager) createUnitAndPoolV4(pool v1alpha1.ResourcePoolSpec) er
 	return nil
 }
 
-func (m *OBTenantManager) deleteTenant() tasktypes.TaskError {
+func (m *OBTenantManager) deleteTenant(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -1061,7 +1068,7 @@ func (m *OBTenantManager) deleteTenant() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) deletePool() tasktypes.TaskError {
+func (m *OBTenantManager) deletePool(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -1084,7 +1091,7 @@ func (m *OBTenantManager) deletePool() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) deleteUnitConfig() tasktypes.TaskError {
+func (m *OBTenantManager) deleteUnitConfig(ctx context.Context) tasktypes.TaskError {
 	tenantName := m.OBTenant.Spec.TenantName
 	oceanbaseOperationManager, err := m.getClusterSysClient()
 	if err != nil {
@@ -1107,12 +1114,12 @@ func (m *OBTenantManager) deleteUnitConfig() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CreateUserWithCredentialSecrets() tasktypes.TaskError {
+func (m *OBTenantManager) CreateUserWithCredentialSecrets(ctx context.Context) tasktypes.TaskError {
 	if m.OBTenant.Spec.TenantRole == constants.TenantRoleStandby {
 		// standby tenant can not create users
 		return nil
 	}
-	err := m.CreateUserWithCredentials()
+	err := m.CreateUserWithCredentials(ctx)
 	if err != nil {
 		m.Recorder.Event(m.OBTenant, corev1.EventTypeWarning, "Failed to create user or change password", err.Error())
 		m.Logger.Error(err, "Failed to create user or change password, please check the credential secrets")
@@ -1121,7 +1128,7 @@ func (m *OBTenantManager) CreateUserWithCredentialSecrets() tasktypes.TaskError
 	return nil
 }
 
-func (m *OBTenantManager) CreateUserWithCredentials() tasktypes.TaskError {
+func (m *OBTenantManager) CreateUserWithCredentials(ctx context.Context) tasktypes.TaskError {
 	var con *operation.OceanbaseOperationManager
 	var err error
 
@@ -1149,7 +1156,7 @@ func (m *OBTenantManager) CreateUserWithCredentials() tasktypes.TaskError {
 	if creds.StandbyRO != "" {
 		var standbyROPwd string
 		secret := &corev1.Secret{}
-		err = m.Client.Get(m.Ctx, types.NamespacedName{
+		err = m.Client.Get(ctx, types.NamespacedName{
 			Namespace: m.OBTenant.GetNamespace(),
 			Name:      creds.StandbyRO,
 		}, secret)
@@ -1167,7 +1174,7 @@ func (m *OBTenantManager) CreateUserWithCredentials() tasktypes.TaskError {
 				secret.StringData = map[string]string{
 					"password": standbyROPwd,
 				}
-				err = m.Client.Create(m.Ctx, secret)
+				err = m.Client.Create(ctx, secret)
 				if err != nil {
 					m.Logger.Error(err, "Failed to create standbyRO password secret")
 					return err
@@ -1212,15 +1219,20 @@ func (m *OBTenantManager) CreateUserWithCredentials() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CreateEmptyStandbyTenant() tasktypes.TaskError {
-	if m.OBTenant.Spec.Source == nil || m.OBTenant.Spec.Source.Tenant == nil {
+func (m *OBTenantManager) CreateEmptyStandbyTenant(ctx context.Context) tasktypes.TaskError {
+	if m.OBTenant.Spec.Source == nil || (m.OBTenant.Spec.Source.Tenant == nil && m.OBTenant.Spec.Source.External == nil) {
 		return errors.New("Empty standby tenant must have source tenant")
 	}
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
 	}
-	restoreSource, err := resourceutils.GetTenantRestoreSource(m.Ctx, m.Client, m.Logger, con, m.OBTenant.Namespace, *m.OBTenant.Spec.Source.Tenant)
+	var restoreSource string
+	if m.OBTenant.Spec.Source.External != nil {
+		restoreSource, err = resourceutils.GetExternalTenantRestoreSource(m.Client, m.OBTenant.Namespace, m.OBTenant.Spec.Source.External)
+	} else {
+		restoreSource, err = resourceutils.GetTenantRestoreSource(ctx, m.Client, m.Logger, con, m.OBTenant.Namespace, *m.OBTenant.Spec.Source.Tenant)
+	}
 	if err != nil {
 		return err
 	}
@@ -1241,13 +1253,18 @@ func (m *OBTenantManager) CreateEmptyStandbyTenant() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBTenantManager) CheckPrimaryTenantLSIntegrity() tasktypes.TaskError {
+func (m *OBTenantManager) CheckPrimaryTenantLSIntegrity(ctx context.Context) tasktypes.TaskError {
 	var err error
+	if m.OBTenant.Spec.Source != nil && m.OBTenant.Spec.Source.External != nil {
+		// views of external primary tenant are not accessible with standbyro user, leave the check to OceanBase
+		m.Logger.Info("Skip checking log integrity of external primary tenant", "address", m.OBTenant.Spec.Source.External.Address)
+		return nil
+	}
 	if m.OBTenant.Spec.Source == nil || m.OBTenant.Spec.Source.Tenant == nil {
 		return errors.New("Primary tenant must have source tenant")
 	}
 	tenantCR := &v1alpha1.OBTenant{}
-	err = m.Client.Get(m.Ctx, types.NamespacedName{
+	err = m.Client.Get(ctx, types.NamespacedName{
 		Namespace: m.OBTenant.Namespace,
 		Name:      *m.OBTenant.Spec.Source.Tenant,
 	}, tenantCR)
@@ -1291,11 +1308,11 @@ func (m *OBTenantManager) generateRestoreOption() string {
 	return fmt.Sprintf("pool_list=%s&primary_zone=%s&locality=%s", strings.Join(poolList, ","), primaryZone, locality)
 }
 
-func (m *OBTenantManager) CreateTenantRestoreJobCR() tasktypes.TaskError {
+func (m *OBTenantManager) CreateTenantRestoreJobCR(ctx context.Context) tasktypes.TaskError {
 	var existingJobs v1alpha1.OBTenantRestoreList
 	var err error
 
-	err = m.Client.List(m.Ctx, &existingJobs,
+	err = m.Client.List(ctx, &existingJobs,
 		client.MatchingLabels{
 			oceanbaseconst.LabelRefOBCluster: m.OBTenant.Spec.ClusterName,
 			oceanbaseconst.LabelTenantName:   m.OBTenant.Spec.TenantName,
@@ -1332,20 +1349,22 @@ func (m *OBTenantManager) CreateTenantRestoreJobCR() tasktypes.TaskError {
 			Source:        *m.OBTenant.Spec.Source.Restore,
 			Option:        m.generateRestoreOption(),
 			PrimaryTenant: m.OBTenant.Spec.Source.Tenant,
+
+			ExternalPrimary: m.OBTenant.Spec.Source.External,
 		},
 	}
-	err = m.Client.Create(m.Ctx, restoreJob)
+	err = m.Client.Create(ctx, restoreJob)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m *OBTenantManager) WatchRestoreJobToFinish() tasktypes.TaskError {
+func (m *OBTenantManager) WatchRestoreJobToFinish(ctx context.Context) tasktypes.TaskError {
 	var err error
 	for {
 		runningRestore := &v1alpha1.OBTenantRestore{}
-		err = m.Client.Get(m.Ctx, types.NamespacedName{
+		err = m.Client.Get(ctx, types.NamespacedName{
 			Namespace: m.OBTenant.GetNamespace(),
 			Name:      m.OBTenant.Name + "-restore",
 		}, runningRestore)
@@ -1358,14 +1377,16 @@ func (m *OBTenantManager) WatchRestoreJobToFinish() tasktypes.TaskError {
 			m.Recorder.Event(m.OBTenant, "RestoreJobFailed", "", "restore job failed")
 			return errors.New("Restore job failed")
 		}
-		time.Sleep(5 * time.Second)
+		if err := resourceutils.SleepWithContext(ctx, 5*time.Second); err != nil {
+			return err
+		}
 	}
 	tenantWhiteListMap.Store(m.OBTenant.Spec.TenantName, m.OBTenant.Spec.ConnectWhiteList)
 	m.Recorder.Event(m.OBTenant, "RestoreJobFinished", "", "restore job finished successfully")
 	return nil
 }
 
-func (m *OBTenantManager) CancelTenantRestoreJob() tasktypes.TaskError {
+func (m *OBTenantManager) CancelTenantRestoreJob(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
@@ -1374,15 +1395,15 @@ func (m *OBTenantManager) CancelTenantRestoreJob() tasktypes.TaskError {
 	if err != nil {
 		return err
 	}
-	err = m.deletePool()
+	err = m.deletePool(ctx)
 	if err != nil {
 		return err
 	}
-	err = m.deleteUnitConfig()
+	err = m.deleteUnitConfig(ctx)
 	if err != nil {
 		return err
 	}
-	err = m.Client.Delete(m.Ctx, &v1alpha1.OBTenantRestore{
+	err = m.Client.Delete(ctx, &v1alpha1.OBTenantRestore{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      m.OBTenant.Name + "-restore",
 			Namespace: m.OBTenant.GetNamespace(),
@@ -1392,14 +1413,14 @@ func (m *OBTenantManager) CancelTenantRestoreJob() tasktypes.TaskError {
 		m.Logger.Error(err, "delete restore job CR")
 		return err
 	}
-	err = m.Client.Delete(m.Ctx, m.OBTenant)
+	err = m.Client.Delete(ctx, m.OBTenant)
 	if err != nil {
 		m.Logger.Error(err, "delete tenant CR")
 	}
 	return nil
 }
 
-func (m *OBTenantManager) UpgradeTenantIfNeeded() tasktypes.TaskError {
+func (m *OBTenantManager) UpgradeTenantIfNeeded(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
@@ -1427,7 +1448,9 @@ func (m *OBTenantManager) UpgradeTenantIfNeeded() tasktypes.TaskError {
 		maxWait5secTimes := oceanbaseconst.DefaultStateWaitTimeout/5 + 1
 	outer:
 		for i := 0; i < maxWait5secTimes; i++ {
-			time.Sleep(5 * time.Second)
+			if err := resourceutils.SleepWithContext(ctx, 5*time.Second); err != nil {
+				return err
+			}
 			params, err := con.ListParametersWithTenantID(int64(m.OBTenant.Status.TenantRecordInfo.TenantID))
 			if err != nil {
 				return err
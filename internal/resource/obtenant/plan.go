@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obtenant
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+// updatePlan plans the spec proposed in annotation and records the result in status, the plan is cleared once annotation is removed.
+// It's called after status of tenant is built from DB, the former plan is kept in other statuses
+func (m *OBTenantManager) updatePlan() {
+	proposal, exist := resourceutils.GetAnnotationField(m.OBTenant, oceanbaseconst.AnnotationsPlanSpec)
+	if !exist {
+		m.OBTenant.Status.Plan = nil
+		return
+	}
+	plan := &tasktypes.ChangePlan{
+		ProposalHash:       tasktypes.HashProposal(proposal),
+		ObservedGeneration: m.OBTenant.Generation,
+		PlannedAt:          metav1.Now(),
+	}
+	flows, err := m.PlanTaskFlows(proposal)
+	if err != nil {
+		m.Logger.Error(err, "Failed to plan proposed spec of obtenant")
+		plan.Error = err.Error()
+	} else {
+		plan.Flows = flows
+	}
+	if !plan.SameAs(m.OBTenant.Status.Plan) {
+		m.OBTenant.Status.Plan = plan
+	}
+}
+
+// PlanTaskFlows returns task flows that would run if the proposed spec is applied, nothing is executed.
+// Proposal is a JSON merge patch of the spec, decisions are made in the same way as NextStatus and GetTaskFlow
+func (m *OBTenantManager) PlanTaskFlows(proposal string) ([]tasktypes.PlannedFlow, error) {
+	shadow := m.OBTenant.DeepCopy()
+	spec := v1alpha1.OBTenantSpec{}
+	if err := resourceutils.ApplyMergePatch(m.OBTenant.Spec, proposal, &spec); err != nil {
+		return nil, errors.Wrap(err, "apply proposed spec")
+	}
+	shadow.Spec = spec
+	shadow.Status.OperationContext = nil
+	logger := m.Logger.WithName("plan")
+	planner := &OBTenantManager{
+		OBTenant: shadow,
+		Ctx:      m.Ctx,
+		Client:   m.Client,
+		Recorder: m.Recorder,
+		Logger:   &logger,
+	}
+	statuses, err := planner.pendingStatuses()
+	if err != nil {
+		return nil, errors.Wrap(err, "compare proposed spec")
+	}
+	flows := make([]tasktypes.PlannedFlow, 0, len(statuses))
+	for _, status := range statuses {
+		planner.OBTenant.Status.Status = status
+		flow, err := planner.GetTaskFlow()
+		if err != nil {
+			return nil, errors.Wrapf(err, "get task flow of status %s", status)
+		}
+		if flow != nil {
+			flows = append(flows, tasktypes.NewPlannedFlow(flow))
+		}
+	}
+	return flows, nil
+}
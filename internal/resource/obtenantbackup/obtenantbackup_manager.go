@@ -15,6 +15,7 @@ package obtenantbackup
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -76,6 +77,22 @@ func (m *OBTenantBackupManager) SetOperationContext(c *tasktypes.OperationContex
 	m.Resource.Status.OperationContext = c
 }
 
+func (m *OBTenantBackupManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.Resource.Status.FlowHistory = tasktypes.AppendFlowRecord(m.Resource.Status.FlowHistory, record)
+}
+
+func (m *OBTenantBackupManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.Resource)
+}
+
+func (m *OBTenantBackupManager) SetPaused(paused bool) bool {
+	if m.Resource.Status.Paused == paused {
+		return false
+	}
+	m.Resource.Status.Paused = paused
+	return true
+}
+
 func (m *OBTenantBackupManager) ClearTaskInfo() {
 	m.Resource.Status.Status = constants.BackupJobStatusRunning
 	m.Resource.Status.OperationContext = nil
@@ -111,6 +128,9 @@ func (m *OBTenantBackupManager) FinishTask() {
 }
 
 func (m *OBTenantBackupManager) UpdateStatus() error {
+	if m.Resource.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	var err error
 	switch m.Resource.Spec.Type {
 	case constants.BackupJobTypeFull, constants.BackupJobTypeIncr:
@@ -128,6 +148,10 @@ func (m *OBTenantBackupManager) UpdateStatus() error {
 	return m.retryUpdateStatus()
 }
 
+func (m *OBTenantBackupManager) GetTaskTimeout(tasktypes.TaskName) time.Duration {
+	return 0
+}
+
 func (m *OBTenantBackupManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	if name == tCreateBackupJobInDB {
 		return m.CreateBackupJobInOB, nil
@@ -163,15 +187,20 @@ func (m *OBTenantBackupManager) ArchiveResource() {
 	m.Resource.Status.OperationContext = nil
 }
 
-func (m *OBTenantBackupManager) CreateBackupJobInOB() tasktypes.TaskError {
+func (m *OBTenantBackupManager) CreateBackupJobInOB(ctx context.Context) tasktypes.TaskError {
 	job := m.Resource
-	con, err := m.getObOperationClient()
+	target, err := m.resolveBackupTarget()
+	if err != nil {
+		m.Logger.Error(err, "failed to resolve backup target")
+		return err
+	}
+	con, err := m.getOperationClientOfTarget(target)
 	if err != nil {
 		m.Logger.Error(err, "failed to get ob operation client")
 		return err
 	}
-	if job.Spec.EncryptionSecret != "" {
-		password, err := resourceutils.ReadPassword(m.Client, job.Namespace, job.Spec.EncryptionSecret)
+	if target.encryptionSecret != "" {
+		password, err := resourceutils.ReadPassword(m.Client, job.Namespace, target.encryptionSecret)
 		if err != nil {
 			m.Logger.Error(err, "failed to read backup encryption secret")
 			m.Recorder.Event(job, "Warning", "ReadBackupEncryptionSecretFailed", err.Error())
@@ -183,30 +212,113 @@ func (m *OBTenantBackupManager) CreateBackupJobInOB() tasktypes.TaskError {
 			}
 		}
 	}
-	_, err = con.CreateAndReturnBackupJob(job.Spec.Type)
+	latest, err := con.CreateAndReturnBackupJob(job.Spec.Type)
 	if err != nil {
 		m.Logger.Error(err, "failed to create and return backup job")
 		m.Recorder.Event(job, "Warning", "CreateAndReturnBackupJobFailed", err.Error())
 		return err
 	}
+	if latest != nil {
+		// Record the created job right away, so that it will be tracked by its id instead of the latest job of the type
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			newestJob := &v1alpha1.OBTenantBackup{}
+			err := m.Client.Get(ctx, types.NamespacedName{
+				Namespace: job.GetNamespace(),
+				Name:      job.GetName(),
+			}, newestJob)
+			if err != nil {
+				return err
+			}
+			newestJob.Status.BackupJob = latest
+			return m.Client.Status().Update(ctx, newestJob)
+		})
+		if err != nil {
+			m.Logger.Error(err, "failed to record created backup job", "jobId", latest.JobID)
+			return err
+		}
+		job.Status.BackupJob = latest
+	}
 
-	// job.Status.BackupJob = latest
 	m.Recorder.Event(job, "Create", "", "create backup job successfully")
 	return nil
 }
 
+type backupTarget struct {
+	obclusterName    string
+	tenantName       string
+	tenantSecret     string
+	encryptionSecret string
+}
+
+// resolveBackupTarget figures out the tenant to back up, which could be specified explicitly,
+// or by referencing an obtenant resource or an obtenantbackuppolicy resource
+func (m *OBTenantBackupManager) resolveBackupTarget() (*backupTarget, error) {
+	spec := m.Resource.Spec
+	target := &backupTarget{
+		obclusterName:    spec.ObClusterName,
+		tenantName:       spec.TenantName,
+		tenantSecret:     spec.TenantSecret,
+		encryptionSecret: spec.EncryptionSecret,
+	}
+	if target.obclusterName != "" && target.tenantName != "" && target.tenantSecret != "" {
+		return target, nil
+	}
+	tenantCRName := spec.TenantCRName
+	if spec.BackupPolicyName != "" {
+		policy := &v1alpha1.OBTenantBackupPolicy{}
+		err := m.Client.Get(m.Ctx, types.NamespacedName{
+			Namespace: m.Resource.Namespace,
+			Name:      spec.BackupPolicyName,
+		}, policy)
+		if err != nil {
+			return nil, errors.Wrap(err, "get backup policy")
+		}
+		target.obclusterName = policy.Spec.ObClusterName
+		if target.encryptionSecret == "" {
+			target.encryptionSecret = policy.Spec.DataBackup.EncryptionSecret
+		}
+		if policy.Spec.TenantName != "" && policy.Spec.TenantSecret != "" {
+			target.tenantName = policy.Spec.TenantName
+			target.tenantSecret = policy.Spec.TenantSecret
+			return target, nil
+		}
+		tenantCRName = policy.Spec.TenantCRName
+	}
+	if tenantCRName == "" {
+		return nil, errors.New("tenant to back up is not specified, set tenantName, tenantSecret and obClusterName, or reference an obtenant or a backup policy")
+	}
+	tenant := &v1alpha1.OBTenant{}
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
+		Namespace: m.Resource.Namespace,
+		Name:      tenantCRName,
+	}, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, "get obtenant")
+	}
+	target.obclusterName = tenant.Spec.ClusterName
+	target.tenantName = tenant.Spec.TenantName
+	target.tenantSecret = tenant.Status.Credentials.Root
+	return target, nil
+}
+
 func (m *OBTenantBackupManager) getObOperationClient() (*operation.OceanbaseOperationManager, error) {
-	var err error
-	job := m.Resource
+	target, err := m.resolveBackupTarget()
+	if err != nil {
+		return nil, err
+	}
+	return m.getOperationClientOfTarget(target)
+}
+
+func (m *OBTenantBackupManager) getOperationClientOfTarget(target *backupTarget) (*operation.OceanbaseOperationManager, error) {
 	obcluster := &v1alpha1.OBCluster{}
-	err = m.Client.Get(m.Ctx, types.NamespacedName{
-		Namespace: job.Namespace,
-		Name:      job.Spec.ObClusterName,
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
+		Namespace: m.Resource.Namespace,
+		Name:      target.obclusterName,
 	}, obcluster)
 	if err != nil {
 		return nil, errors.Wrap(err, "get obcluster")
 	}
-	con, err := resourceutils.GetTenantRootOperationClient(m.Client, m.Logger, obcluster, job.Spec.TenantName, job.Spec.TenantSecret)
+	con, err := resourceutils.GetTenantRootOperationClient(m.Client, m.Logger, obcluster, target.tenantName, target.tenantSecret)
 	if err != nil {
 		return nil, errors.Wrap(err, "get oceanbase operation manager")
 	}
@@ -223,7 +335,12 @@ func (m *OBTenantBackupManager) retryUpdateStatus() error {
 		if err != nil {
 			return client.IgnoreNotFound(err)
 		}
+		backupJob := newestJob.Status.BackupJob
 		newestJob.Status = m.Resource.Status
+		// Backup job may be recorded by the task asynchronously, do not overwrite it
+		if newestJob.Status.BackupJob == nil {
+			newestJob.Status.BackupJob = backupJob
+		}
 		return m.Client.Status().Update(m.Ctx, newestJob)
 	})
 }
@@ -265,6 +382,16 @@ func (m *OBTenantBackupManager) maintainRunningBackupJob() error {
 	}
 	switch targetJob.Status {
 	case "COMPLETED":
+		setFile, err := con.GetBackupSetFileWithSetId(targetJob.BackupSetID)
+		if err != nil {
+			logger.Error(err, "failed to query backup set file", "backupSetId", targetJob.BackupSetID)
+			return err
+		}
+		job.Status.BackupSetID = targetJob.BackupSetID
+		if setFile != nil {
+			job.Status.MinRestoreScn = setFile.MinRestoreScn
+			job.Status.MinRestoreScnDisplay = setFile.MinRestoreScnDisplay
+		}
 		job.Status.Status = constants.BackupJobStatusSuccessful
 	case "FAILED":
 		job.Status.Status = constants.BackupJobStatusFailed
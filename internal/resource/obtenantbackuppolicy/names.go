@@ -13,6 +13,9 @@ See the Mulan PSL v2 for more details.
 package obtenantbackuppolicy
 
 import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -31,7 +34,15 @@ const (
 	tStartBackupJob           ttypes.TaskName = "start backup job"
 	tStopBackupPolicy         ttypes.TaskName = "stop backup policy"
 	tCleanOldBackupJobs       ttypes.TaskName = "clean old backup jobs"
+	tCleanBackupSets          ttypes.TaskName = "clean backup sets"
 	tPauseBackup              ttypes.TaskName = "pause backup"
 	tResumeBackup             ttypes.TaskName = "resume backup"
 	tDeleteBackupPolicy       ttypes.TaskName = "delete backup policy"
 )
+
+// timeouts of backup policy tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	// starting and resuming backup wait for archive log job to run
+	tStartBackupJob: oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+	tResumeBackup:   oceanbaseconst.TimeConsumingStateWaitTimeout * time.Second,
+}
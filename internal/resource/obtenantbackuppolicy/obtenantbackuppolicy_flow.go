@@ -57,7 +57,7 @@ func MaintainRunningPolicy() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
 			Name:         fMaintainRunningPolicy,
-			Tasks:        []tasktypes.TaskName{tConfigureServerForBackup, tCleanOldBackupJobs, tCheckAndSpawnJobs},
+			Tasks:        []tasktypes.TaskName{tConfigureServerForBackup, tCleanOldBackupJobs, tCleanBackupSets, tCheckAndSpawnJobs},
 			TargetStatus: string(constants.BackupPolicyStatusRunning),
 			OnFailure: tasktypes.FailureRule{
 				NextTryStatus: string(constants.BackupPolicyStatusRunning),
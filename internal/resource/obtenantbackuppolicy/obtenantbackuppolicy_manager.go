@@ -149,6 +149,22 @@ func (m *ObTenantBackupPolicyManager) SetOperationContext(c *tasktypes.Operation
 	m.BackupPolicy.Status.OperationContext = c
 }
 
+func (m *ObTenantBackupPolicyManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.BackupPolicy.Status.FlowHistory = tasktypes.AppendFlowRecord(m.BackupPolicy.Status.FlowHistory, record)
+}
+
+func (m *ObTenantBackupPolicyManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.BackupPolicy)
+}
+
+func (m *ObTenantBackupPolicyManager) SetPaused(paused bool) bool {
+	if m.BackupPolicy.Status.Paused == paused {
+		return false
+	}
+	m.BackupPolicy.Status.Paused = paused
+	return true
+}
+
 func (m *ObTenantBackupPolicyManager) ClearTaskInfo() {
 	m.BackupPolicy.Status.Status = constants.BackupPolicyStatusRunning
 	m.BackupPolicy.Status.OperationContext = nil
@@ -160,6 +176,9 @@ func (m *ObTenantBackupPolicyManager) FinishTask() {
 }
 
 func (m *ObTenantBackupPolicyManager) UpdateStatus() error {
+	if m.BackupPolicy.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	if m.BackupPolicy.Spec.Suspend && m.BackupPolicy.Status.Status == constants.BackupPolicyStatusRunning {
 		m.BackupPolicy.Status.Status = constants.BackupPolicyStatusPausing
 		m.BackupPolicy.Status.OperationContext = nil
@@ -196,7 +215,7 @@ func (m *ObTenantBackupPolicyManager) UpdateStatus() error {
 				return err
 			}
 			var backupPath string
-			if m.BackupPolicy.Spec.DataBackup.Destination.Type == constants.BackupDestTypeOSS {
+			if constants.IsObjectStorageDest(m.BackupPolicy.Spec.DataBackup.Destination.Type) {
 				backupPath = m.BackupPolicy.Spec.DataBackup.Destination.Path
 			} else {
 				backupPath = m.getBackupDestPath()
@@ -213,6 +232,13 @@ func (m *ObTenantBackupPolicyManager) UpdateStatus() error {
 			m.BackupPolicy.Status.LatestFullBackupJob = latestFull
 			m.BackupPolicy.Status.LatestIncrementalJob = latestIncr
 
+			// Verification should never block scheduling of backup jobs
+			err = m.maintainVerification()
+			if err != nil {
+				m.Logger.Error(err, "Failed to maintain backup verification")
+				m.PrintErrEvent(err)
+			}
+
 			if latestFull == nil || latestFull.Status == "CANCELED" {
 				m.BackupPolicy.Status.NextFull = time.Now().Format(time.DateTime)
 				m.BackupPolicy.Status.Status = constants.BackupPolicyStatusMaintaining
@@ -275,6 +301,10 @@ func (m *ObTenantBackupPolicyManager) UpdateStatus() error {
 	return m.retryUpdateStatus()
 }
 
+func (m *ObTenantBackupPolicyManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	return taskTimeouts[name]
+}
+
 func (m *ObTenantBackupPolicyManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tConfigureServerForBackup:
@@ -287,6 +317,8 @@ func (m *ObTenantBackupPolicyManager) GetTaskFunc(name tasktypes.TaskName) (task
 		return m.CheckAndSpawnJobs, nil
 	case tCleanOldBackupJobs:
 		return m.CleanOldBackupJobs, nil
+	case tCleanBackupSets:
+		return m.CleanBackupSets, nil
 	case tPauseBackup:
 		return m.PauseBackup, nil
 	case tResumeBackup:
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package obtenantbackuppolicy
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strconv"
@@ -36,10 +37,11 @@ import (
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
-func (m *ObTenantBackupPolicyManager) ConfigureServerForBackup() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) ConfigureServerForBackup(ctx context.Context) tasktypes.TaskError {
 	m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Configure Server For Backup")
 	con, err := m.getOperationManager()
 	if err != nil {
@@ -149,7 +151,7 @@ func (m *ObTenantBackupPolicyManager) ConfigureServerForBackup() tasktypes.TaskE
 	return nil
 }
 
-func (m *ObTenantBackupPolicyManager) StartBackup() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) StartBackup(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getOperationManager()
 	if err != nil {
 		return err
@@ -175,7 +177,9 @@ func (m *ObTenantBackupPolicyManager) StartBackup() tasktypes.TaskError {
 	// Initialization: wait for archive log job to start
 	archiveRunning := false
 	for !archiveRunning {
-		time.Sleep(10 * time.Second)
+		if err := resourceutils.SleepWithContext(ctx, 10*time.Second); err != nil {
+			return err
+		}
 		latestArchiveJob, err := con.GetLatestArchiveLogJob()
 		if err != nil {
 			return err
@@ -188,7 +192,7 @@ func (m *ObTenantBackupPolicyManager) StartBackup() tasktypes.TaskError {
 	return m.createBackupJobIfNotExists(constants.BackupJobTypeFull)
 }
 
-func (m *ObTenantBackupPolicyManager) StopBackup() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) StopBackup(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getOperationManager()
 	if err != nil {
 		return err
@@ -220,9 +224,9 @@ func (m *ObTenantBackupPolicyManager) StopBackup() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantBackupPolicyManager) CheckAndSpawnJobs() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) CheckAndSpawnJobs(ctx context.Context) tasktypes.TaskError {
 	var backupPath string
-	if m.BackupPolicy.Spec.DataBackup.Destination.Type == constants.BackupDestTypeOSS {
+	if constants.IsObjectStorageDest(m.BackupPolicy.Spec.DataBackup.Destination.Type) {
 		backupPath = m.BackupPolicy.Spec.DataBackup.Destination.Path
 	} else {
 		backupPath = m.getBackupDestPath()
@@ -316,7 +320,7 @@ func (m *ObTenantBackupPolicyManager) CheckAndSpawnJobs() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantBackupPolicyManager) CleanOldBackupJobs() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) CleanOldBackupJobs(ctx context.Context) tasktypes.TaskError {
 	// JobKeepWindow is not set, do nothing
 	if m.BackupPolicy.Spec.JobKeepWindow == "" {
 		return nil
@@ -327,7 +331,7 @@ func (m *ObTenantBackupPolicyManager) CleanOldBackupJobs() tasktypes.TaskError {
 	if err != nil {
 		return err
 	}
-	err = m.Client.List(m.Ctx, &jobs,
+	err = m.Client.List(ctx, &jobs,
 		client.MatchingLabels{
 			oceanbaseconst.LabelRefBackupPolicy: m.BackupPolicy.Name,
 		},
@@ -356,7 +360,7 @@ func (m *ObTenantBackupPolicyManager) CleanOldBackupJobs() tasktypes.TaskError {
 				return err
 			}
 			if finishedAt.Add(keepWindowDuration).Before(time.Now()) {
-				err = m.Client.Delete(m.Ctx, &jobs.Items[i])
+				err = m.Client.Delete(ctx, &jobs.Items[i])
 				if err != nil {
 					return err
 				}
@@ -366,7 +370,44 @@ func (m *ObTenantBackupPolicyManager) CleanOldBackupJobs() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantBackupPolicyManager) PauseBackup() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) CleanBackupSets(ctx context.Context) tasktypes.TaskError {
+	rule, err := m.getBackupRetentionRule()
+	if err != nil {
+		return err
+	}
+	// No retention rule is set, backup sets are cleaned by recovery window only
+	if rule == nil {
+		return nil
+	}
+	con, err := m.getOperationManager()
+	if err != nil {
+		return err
+	}
+	jobs, err := con.ListBackupJobHistory()
+	if err != nil {
+		return err
+	}
+	files, err := con.ListBackupSetFiles()
+	if err != nil {
+		return err
+	}
+	decisions := util.ComputeBackupSetsToDelete(jobs, files, m.getBackupDestPath(), *rule, time.Now())
+	for _, decision := range decisions {
+		if !decision.Delete {
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Retain backup set", "backupType", decision.BackupType, "backupSetId", decision.BackupSetID, "reason", decision.Reason)
+			continue
+		}
+		err = con.DeleteBackupSet(decision.BackupSetID)
+		if err != nil {
+			m.Recorder.Event(m.BackupPolicy, v1.EventTypeWarning, "DeleteBackupSetFailed", fmt.Sprintf("Failed to delete %s backup set %d: %s", decision.BackupType, decision.BackupSetID, err.Error()))
+			return err
+		}
+		m.Recorder.Event(m.BackupPolicy, v1.EventTypeNormal, "DeleteBackupSet", fmt.Sprintf("Delete %s backup set %d: %s", decision.BackupType, decision.BackupSetID, decision.Reason))
+	}
+	return nil
+}
+
+func (m *ObTenantBackupPolicyManager) PauseBackup(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getOperationManager()
 	if err != nil {
 		return err
@@ -393,7 +434,7 @@ func (m *ObTenantBackupPolicyManager) PauseBackup() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantBackupPolicyManager) ResumeBackup() tasktypes.TaskError {
+func (m *ObTenantBackupPolicyManager) ResumeBackup(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getOperationManager()
 	if err != nil {
 		return err
@@ -408,7 +449,9 @@ func (m *ObTenantBackupPolicyManager) ResumeBackup() tasktypes.TaskError {
 	}
 	archiveRunning := false
 	for !archiveRunning {
-		time.Sleep(10 * time.Second)
+		if err := resourceutils.SleepWithContext(ctx, 10*time.Second); err != nil {
+			return err
+		}
 		latestArchiveJob, err := con.GetLatestArchiveLogJob()
 		if err != nil {
 			return err
@@ -447,12 +490,67 @@ func (m *ObTenantBackupPolicyManager) getLatestBackupJob(jobType apitypes.Backup
 	return con.GetLatestBackupJobOfType(jobType)
 }
 
+// getLatestBackupJobOfTypeAndPath returns the latest backup job scheduled by the policy,
+// on-demand backup jobs created by users are excluded to keep the schedule undisturbed
 func (m *ObTenantBackupPolicyManager) getLatestBackupJobOfTypeAndPath(jobType apitypes.BackupJobType, path string) (*model.OBBackupJob, error) {
 	con, err := m.getOperationManager()
 	if err != nil {
 		return nil, err
 	}
-	return con.GetLatestBackupJobOfTypeAndPath(jobType, path)
+	onDemandJobIDs, err := m.getOnDemandBackupJobIDs(jobType)
+	if err != nil {
+		return nil, err
+	}
+	if len(onDemandJobIDs) == 0 {
+		return con.GetLatestBackupJobOfTypeAndPath(jobType, path)
+	}
+	return con.GetLatestBackupJobOfTypeAndPathExcluding(jobType, path, onDemandJobIDs)
+}
+
+// getOnDemandBackupJobIDs returns job ids of backup jobs that are created by users for the same tenant
+func (m *ObTenantBackupPolicyManager) getOnDemandBackupJobIDs(jobType apitypes.BackupJobType) ([]int64, error) {
+	var jobs v1alpha1.OBTenantBackupList
+	err := m.Client.List(m.Ctx, &jobs, client.InNamespace(m.BackupPolicy.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	jobIDs := make([]int64, 0)
+	for _, job := range jobs.Items {
+		if _, exist := job.Labels[oceanbaseconst.LabelRefBackupPolicy]; exist {
+			continue
+		}
+		if job.Spec.Type != jobType || job.Status.BackupJob == nil {
+			continue
+		}
+		sameTenant, err := m.isBackupJobOfSameTenant(&job)
+		if err != nil {
+			return nil, err
+		}
+		if sameTenant {
+			jobIDs = append(jobIDs, job.Status.BackupJob.JobID)
+		}
+	}
+	return jobIDs, nil
+}
+
+func (m *ObTenantBackupPolicyManager) isBackupJobOfSameTenant(job *v1alpha1.OBTenantBackup) (bool, error) {
+	policySpec := m.BackupPolicy.Spec
+	switch {
+	case job.Spec.BackupPolicyName != "":
+		return job.Spec.BackupPolicyName == m.BackupPolicy.Name, nil
+	case job.Spec.TenantCRName != "" && policySpec.TenantCRName != "":
+		return job.Spec.TenantCRName == policySpec.TenantCRName, nil
+	case job.Spec.TenantName != "" && job.Spec.ObClusterName == policySpec.ObClusterName:
+		if policySpec.TenantName != "" {
+			return job.Spec.TenantName == policySpec.TenantName, nil
+		}
+		tenantRecordName, err := m.getTenantRecordName()
+		if err != nil {
+			return false, err
+		}
+		return job.Spec.TenantName == tenantRecordName, nil
+	}
+	return false, nil
 }
 
 // get operation manager to exec sql
@@ -498,7 +596,7 @@ func (m *ObTenantBackupPolicyManager) getArchiveDestPath() string {
 	targetDest := m.BackupPolicy.Spec.LogArchive.Destination
 	if targetDest.Type == constants.BackupDestTypeNFS || resourceutils.IsZero(targetDest.Type) {
 		return "file://" + path.Join(oceanbaseconst.BackupPath, targetDest.Path)
-	} else if targetDest.Type == constants.BackupDestTypeOSS && targetDest.OSSAccessSecret != "" {
+	} else if constants.IsObjectStorageDest(targetDest.Type) && targetDest.OSSAccessSecret != "" {
 		secret := &v1.Secret{}
 		err := m.Client.Get(m.Ctx, types.NamespacedName{
 			Namespace: m.BackupPolicy.GetNamespace(),
@@ -508,7 +606,7 @@ func (m *ObTenantBackupPolicyManager) getArchiveDestPath() string {
 			m.PrintErrEvent(err)
 			return ""
 		}
-		return strings.Join([]string{targetDest.Path, "access_id=" + string(secret.Data["accessId"]), "access_key=" + string(secret.Data["accessKey"])}, "&")
+		return resourceutils.GetObjectStorageDestPath(&targetDest, secret)
 	}
 	return targetDest.Path
 }
@@ -529,7 +627,7 @@ func (m *ObTenantBackupPolicyManager) getBackupDestPath() string {
 	targetDest := m.BackupPolicy.Spec.DataBackup.Destination
 	if targetDest.Type == constants.BackupDestTypeNFS || resourceutils.IsZero(targetDest.Type) {
 		return "file://" + path.Join(oceanbaseconst.BackupPath, targetDest.Path)
-	} else if targetDest.Type == constants.BackupDestTypeOSS && targetDest.OSSAccessSecret != "" {
+	} else if constants.IsObjectStorageDest(targetDest.Type) && targetDest.OSSAccessSecret != "" {
 		secret := &v1.Secret{}
 		err := m.Client.Get(m.Ctx, types.NamespacedName{
 			Namespace: m.BackupPolicy.GetNamespace(),
@@ -539,7 +637,7 @@ func (m *ObTenantBackupPolicyManager) getBackupDestPath() string {
 			m.PrintErrEvent(err)
 			return ""
 		}
-		return strings.Join([]string{targetDest.Path, "access_id=" + string(secret.Data["accessId"]), "access_key=" + string(secret.Data["accessKey"])}, "&")
+		return resourceutils.GetObjectStorageDestPath(&targetDest, secret)
 	}
 	return targetDest.Path
 }
@@ -557,18 +655,9 @@ func (m *ObTenantBackupPolicyManager) createBackupJob(jobType apitypes.BackupJob
 	case constants.BackupJobTypeArchive:
 		path = m.getArchiveDestPath()
 	}
-	var tenantRecordName string
-	var tenantSecret string
-	if m.BackupPolicy.Spec.TenantName != "" {
-		tenantRecordName = m.BackupPolicy.Spec.TenantName
-		tenantSecret = m.BackupPolicy.Spec.TenantSecret
-	} else {
-		tenant, err := m.getOBTenantCR()
-		if err != nil {
-			return err
-		}
-		tenantRecordName = tenant.Spec.TenantName
-		tenantSecret = tenant.Status.Credentials.Root
+	tenantRecordName, tenantSecret, err := m.getTenantRootCredential()
+	if err != nil {
+		return err
 	}
 
 	backupJob := &v1alpha1.OBTenantBackup{
@@ -601,6 +690,18 @@ func (m *ObTenantBackupPolicyManager) createBackupJob(jobType apitypes.BackupJob
 	return m.Client.Create(m.Ctx, backupJob)
 }
 
+// getTenantRootCredential returns name of tenant in database and secret that stores its root password
+func (m *ObTenantBackupPolicyManager) getTenantRootCredential() (string, string, error) {
+	if m.BackupPolicy.Spec.TenantName != "" {
+		return m.BackupPolicy.Spec.TenantName, m.BackupPolicy.Spec.TenantSecret, nil
+	}
+	tenant, err := m.getOBTenantCR()
+	if err != nil {
+		return "", "", err
+	}
+	return tenant.Spec.TenantName, tenant.Status.Credentials.Root, nil
+}
+
 func (m *ObTenantBackupPolicyManager) createBackupJobIfNotExists(jobType apitypes.BackupJobType) error {
 	noRunningJobs, err := m.noRunningJobs(jobType)
 	if err != nil {
@@ -701,6 +802,27 @@ func (m *ObTenantBackupPolicyManager) configureBackupCleanPolicy() error {
 	return nil
 }
 
+func (m *ObTenantBackupPolicyManager) getBackupRetentionRule() (*util.BackupRetentionRule, error) {
+	cleanConfig := &m.BackupPolicy.Spec.DataClean
+	rule := &util.BackupRetentionRule{
+		KeepLastFullBackups: cleanConfig.KeepLastFullBackups,
+	}
+	if cleanConfig.KeepOneOlderThan != "" {
+		days, err := strconv.Atoi(strings.TrimRight(cleanConfig.KeepOneOlderThan, "d"))
+		if err != nil {
+			return nil, err
+		}
+		rule.KeepOneOlderThan = time.Duration(days*24) * time.Hour
+	}
+	if cleanConfig.MaxBackupSize != nil {
+		rule.MaxTotalBytes = cleanConfig.MaxBackupSize.Value()
+	}
+	if rule.KeepLastFullBackups <= 0 && rule.MaxTotalBytes <= 0 {
+		return nil, nil
+	}
+	return rule, nil
+}
+
 func (m *ObTenantBackupPolicyManager) getTenantRecordName() (string, error) {
 	if m.BackupPolicy.Status.TenantCR != nil {
 		return m.BackupPolicy.Status.TenantCR.Spec.TenantName, nil
@@ -0,0 +1,262 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obtenantbackuppolicy
+
+import (
+	"fmt"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	v1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	constants "github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	secretconst "github.com/oceanbase/ob-operator/internal/const/secret"
+	"github.com/oceanbase/ob-operator/internal/const/status/tenantstatus"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+)
+
+const (
+	defaultVerificationSmokeCheckSQL = "SELECT 1"
+	defaultVerificationTimeout       = 24 * time.Hour
+)
+
+// maintainVerification verifies backup periodically by restoring the latest backup into a scratch tenant,
+// the scratch tenant is deleted after running smoke check in it
+func (m *ObTenantBackupPolicyManager) maintainVerification() error {
+	spec := m.BackupPolicy.Spec.Verification
+	if spec == nil {
+		return nil
+	}
+	status := m.BackupPolicy.Status.Verification
+	if status != nil && status.Phase == constants.BackupVerificationPhaseRestoring {
+		return m.checkVerificationTenant()
+	}
+	latestFull := m.BackupPolicy.Status.LatestFullBackupJob
+	if latestFull == nil || latestFull.Status != "COMPLETED" {
+		return nil
+	}
+	schedule, err := cron.ParseStandard(spec.Crontab)
+	if err != nil {
+		return err
+	}
+	lastStartedAt := m.BackupPolicy.GetCreationTimestamp().Time
+	if status != nil && status.StartedAt != nil {
+		lastStartedAt = status.StartedAt.Time
+	}
+	if schedule.Next(lastStartedAt).After(time.Now()) {
+		return nil
+	}
+	return m.startVerification()
+}
+
+func (m *ObTenantBackupPolicyManager) startVerification() error {
+	policy := m.BackupPolicy
+	spec := policy.Spec.Verification
+	_, tenantSecret, err := m.getTenantRootCredential()
+	if err != nil {
+		return err
+	}
+	// Restored tenant keeps the root password of the backup source, but the scratch tenant gets its own copy of it
+	// so that deleting the scratch tenant never touches secret of the source tenant
+	rootPassword, err := resourceutils.ReadPassword(m.Client, policy.Namespace, tenantSecret)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	suffix := now.Format("20060102150405")
+	scratchTenantName := policy.Name + "-verify-" + suffix
+	policyOwnerRef := metav1.OwnerReference{
+		APIVersion:         policy.APIVersion,
+		Kind:               policy.Kind,
+		Name:               policy.Name,
+		UID:                policy.GetUID(),
+		BlockOwnerDeletion: resourceutils.GetRef(true),
+	}
+	scratchSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            getScratchRootSecretName(scratchTenantName),
+			Namespace:       policy.Namespace,
+			OwnerReferences: []metav1.OwnerReference{policyOwnerRef},
+			Labels: map[string]string{
+				oceanbaseconst.LabelRefBackupPolicy: policy.Name,
+				oceanbaseconst.LabelRefUID:          string(policy.GetUID()),
+			},
+		},
+		StringData: map[string]string{
+			secretconst.PasswordKeyName: rootPassword,
+		},
+	}
+	err = m.Client.Create(m.Ctx, scratchSecret)
+	if err != nil && !kubeerrors.IsAlreadyExists(err) {
+		return err
+	}
+	pools := make([]v1alpha1.ResourcePoolSpec, len(spec.Pools))
+	for i := range spec.Pools {
+		spec.Pools[i].DeepCopyInto(&pools[i])
+	}
+	archiveSource := policy.Spec.LogArchive.Destination
+	bakDataSource := policy.Spec.DataBackup.Destination
+	scratchTenant := &v1alpha1.OBTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            scratchTenantName,
+			Namespace:       policy.Namespace,
+			OwnerReferences: []metav1.OwnerReference{policyOwnerRef},
+			Labels: map[string]string{
+				oceanbaseconst.LabelRefOBCluster:    spec.ObClusterName,
+				oceanbaseconst.LabelRefBackupPolicy: policy.Name,
+				oceanbaseconst.LabelRefUID:          string(policy.GetUID()),
+			},
+		},
+		Spec: v1alpha1.OBTenantSpec{
+			ClusterName: spec.ObClusterName,
+			TenantName:  "verify" + suffix,
+			UnitNumber:  1,
+			ForceDelete: true,
+			Pools:       pools,
+			TenantRole:  constants.TenantRolePrimary,
+			Credentials: v1alpha1.TenantCredentials{
+				Root: scratchSecret.Name,
+			},
+			Source: &v1alpha1.TenantSourceSpec{
+				Restore: &v1alpha1.RestoreSourceSpec{
+					ArchiveSource:       &archiveSource,
+					BakDataSource:       &bakDataSource,
+					BakEncryptionSecret: policy.Spec.DataBackup.EncryptionSecret,
+					Until: v1alpha1.RestoreUntilConfig{
+						Unlimited: true,
+					},
+				},
+			},
+		},
+	}
+	err = m.Client.Create(m.Ctx, scratchTenant)
+	if err != nil {
+		return err
+	}
+	m.BackupPolicy.Status.Verification = &v1alpha1.BackupVerificationStatus{
+		Phase:        constants.BackupVerificationPhaseRestoring,
+		TenantCRName: scratchTenant.Name,
+		StartedAt:    &now,
+	}
+	m.Recorder.Event(policy, v1.EventTypeNormal, "VerificationStarted", "Restore latest backup into scratch tenant "+scratchTenant.Name)
+	return nil
+}
+
+func (m *ObTenantBackupPolicyManager) checkVerificationTenant() error {
+	status := m.BackupPolicy.Status.Verification
+	var startedAt time.Time
+	if status.StartedAt != nil {
+		startedAt = status.StartedAt.Time
+	}
+	scratchTenant := &v1alpha1.OBTenant{}
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
+		Namespace: m.BackupPolicy.Namespace,
+		Name:      status.TenantCRName,
+	}, scratchTenant)
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			return m.finishVerification(startedAt, constants.BackupVerificationPhaseFailed, "Scratch tenant is not found")
+		}
+		return err
+	}
+
+	switch scratchTenant.Status.Status {
+	case tenantstatus.Running:
+		err = m.runSmokeCheck(scratchTenant)
+		if err != nil {
+			return m.finishVerification(startedAt, constants.BackupVerificationPhaseFailed, "Smoke check failed: "+err.Error())
+		}
+		return m.finishVerification(startedAt, constants.BackupVerificationPhasePassed, "")
+	case tenantstatus.RestoreFailed, tenantstatus.RestoreCanceled, tenantstatus.Failed:
+		return m.finishVerification(startedAt, constants.BackupVerificationPhaseFailed, "Scratch tenant is in status "+scratchTenant.Status.Status)
+	}
+
+	timeout := defaultVerificationTimeout
+	if m.BackupPolicy.Spec.Verification.Timeout != "" {
+		timeout, err = time.ParseDuration(m.BackupPolicy.Spec.Verification.Timeout)
+		if err != nil {
+			return err
+		}
+	}
+	if time.Since(startedAt) > timeout {
+		return m.finishVerification(startedAt, constants.BackupVerificationPhaseFailed, fmt.Sprintf("Verification timed out after %s", timeout))
+	}
+	return nil
+}
+
+func (m *ObTenantBackupPolicyManager) runSmokeCheck(scratchTenant *v1alpha1.OBTenant) error {
+	obcluster := &v1alpha1.OBCluster{}
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
+		Namespace: m.BackupPolicy.Namespace,
+		Name:      scratchTenant.Spec.ClusterName,
+	}, obcluster)
+	if err != nil {
+		return err
+	}
+	con, err := resourceutils.GetTenantRootOperationClient(m.Client, m.Logger, obcluster, scratchTenant.Spec.TenantName, scratchTenant.Status.Credentials.Root)
+	if err != nil {
+		return err
+	}
+	smokeCheckSQL := m.BackupPolicy.Spec.Verification.SmokeCheckSQL
+	if smokeCheckSQL == "" {
+		smokeCheckSQL = defaultVerificationSmokeCheckSQL
+	}
+	return con.ExecWithDefaultTimeout(smokeCheckSQL)
+}
+
+// finishVerification deletes the scratch tenant and records result of verification
+func (m *ObTenantBackupPolicyManager) finishVerification(startedAt time.Time, phase apitypes.BackupVerificationPhase, message string) error {
+	status := m.BackupPolicy.Status.Verification
+	scratchTenant := &v1alpha1.OBTenant{}
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
+		Namespace: m.BackupPolicy.Namespace,
+		Name:      status.TenantCRName,
+	}, scratchTenant)
+	if err == nil {
+		err = m.Client.Delete(m.Ctx, scratchTenant)
+	}
+	if err != nil && !kubeerrors.IsNotFound(err) {
+		return err
+	}
+	err = m.Client.Delete(m.Ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getScratchRootSecretName(status.TenantCRName),
+			Namespace: m.BackupPolicy.Namespace,
+		},
+	})
+	if err != nil && !kubeerrors.IsNotFound(err) {
+		return err
+	}
+
+	now := metav1.Now()
+	status.Phase = phase
+	status.FinishedAt = &now
+	status.Duration = now.Sub(startedAt).Round(time.Second).String()
+	status.Message = message
+	if phase == constants.BackupVerificationPhasePassed {
+		m.Recorder.Event(m.BackupPolicy, v1.EventTypeNormal, "VerificationPassed", "Backup verification passed in "+status.Duration)
+	} else {
+		m.Recorder.Event(m.BackupPolicy, v1.EventTypeWarning, "VerificationFailed", message)
+	}
+	return nil
+}
+
+func getScratchRootSecretName(scratchTenantName string) string {
+	return scratchTenantName + "-root"
+}
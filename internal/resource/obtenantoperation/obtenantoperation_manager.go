@@ -14,6 +14,7 @@ package obtenantoperation
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -27,6 +28,7 @@ import (
 	apitypes "github.com/oceanbase/ob-operator/api/types"
 	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	opresource "github.com/oceanbase/ob-operator/pkg/coordinator"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
@@ -122,6 +124,22 @@ func (m *ObTenantOperationManager) SetOperationContext(c *tasktypes.OperationCon
 	m.Resource.Status.OperationContext = c
 }
 
+func (m *ObTenantOperationManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.Resource.Status.FlowHistory = tasktypes.AppendFlowRecord(m.Resource.Status.FlowHistory, record)
+}
+
+func (m *ObTenantOperationManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.Resource)
+}
+
+func (m *ObTenantOperationManager) SetPaused(paused bool) bool {
+	if m.Resource.Status.Paused == paused {
+		return false
+	}
+	m.Resource.Status.Paused = paused
+	return true
+}
+
 func (m *ObTenantOperationManager) ClearTaskInfo() {
 	m.Resource.Status.Status = constants.TenantOpRunning
 	m.Resource.Status.OperationContext = nil
@@ -174,6 +192,10 @@ func (m *ObTenantOperationManager) ArchiveResource() {
 	m.Resource.Status.OperationContext = nil
 }
 
+func (m *ObTenantOperationManager) GetTaskTimeout(tasktypes.TaskName) time.Duration {
+	return 0
+}
+
 func (m *ObTenantOperationManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tOpChangeTenantRootPassword:
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package obtenantoperation
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -31,7 +32,7 @@ import (
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
-func (m *ObTenantOperationManager) ChangeTenantRootPassword() tasktypes.TaskError {
+func (m *ObTenantOperationManager) ChangeTenantRootPassword(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getTenantRootClient(m.Resource.Spec.ChangePwd.Tenant)
 	if err != nil {
 		return err
@@ -46,7 +47,7 @@ func (m *ObTenantOperationManager) ChangeTenantRootPassword() tasktypes.TaskErro
 	}
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		tenant := &v1alpha1.OBTenant{}
-		err = m.Client.Get(m.Ctx, types.NamespacedName{
+		err = m.Client.Get(ctx, types.NamespacedName{
 			Namespace: m.Resource.Namespace,
 			Name:      m.Resource.Spec.ChangePwd.Tenant,
 		}, tenant)
@@ -54,11 +55,11 @@ func (m *ObTenantOperationManager) ChangeTenantRootPassword() tasktypes.TaskErro
 			return errors.Wrap(err, "get tenant")
 		}
 		tenant.Status.Credentials.Root = m.Resource.Spec.ChangePwd.SecretRef
-		return m.Client.Status().Update(m.Ctx, tenant)
+		return m.Client.Status().Update(ctx, tenant)
 	})
 }
 
-func (m *ObTenantOperationManager) ActivateStandbyTenant() tasktypes.TaskError {
+func (m *ObTenantOperationManager) ActivateStandbyTenant(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient(m.Resource.Status.PrimaryTenant.Spec.ClusterName)
 	if err != nil {
 		return err
@@ -70,7 +71,7 @@ func (m *ObTenantOperationManager) ActivateStandbyTenant() tasktypes.TaskError {
 
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		tenant := &v1alpha1.OBTenant{}
-		err = m.Client.Get(m.Ctx, types.NamespacedName{
+		err = m.Client.Get(ctx, types.NamespacedName{
 			Namespace: m.Resource.Namespace,
 			Name:      m.Resource.Spec.Failover.StandbyTenant,
 		}, tenant)
@@ -78,11 +79,11 @@ func (m *ObTenantOperationManager) ActivateStandbyTenant() tasktypes.TaskError {
 			return errors.Wrap(err, "get tenant")
 		}
 		tenant.Status.TenantRole = constants.TenantRolePrimary
-		return m.Client.Status().Update(m.Ctx, tenant)
+		return m.Client.Status().Update(ctx, tenant)
 	})
 }
 
-func (m *ObTenantOperationManager) CreateUsersForActivatedStandby() tasktypes.TaskError {
+func (m *ObTenantOperationManager) CreateUsersForActivatedStandby(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient(m.Resource.Status.PrimaryTenant.Spec.ClusterName)
 	if err != nil {
 		m.Recorder.Event(m.Resource, "Warning", "Can not get cluster operation client", err.Error())
@@ -104,7 +105,9 @@ func (m *ObTenantOperationManager) CreateUsersForActivatedStandby() tasktypes.Ta
 		if t.TenantType == "USER" && t.TenantRole == "PRIMARY" && t.SwitchoverStatus == "NORMAL" {
 			break
 		}
-		time.Sleep(oceanbaseconst.TenantOpRetryGapSeconds * time.Second)
+		if err := resourceutils.SleepWithContext(ctx, oceanbaseconst.TenantOpRetryGapSeconds*time.Second); err != nil {
+			return err
+		}
 		counter++
 	}
 	if counter >= maxRetry {
@@ -112,7 +115,7 @@ func (m *ObTenantOperationManager) CreateUsersForActivatedStandby() tasktypes.Ta
 	}
 
 	tenantManager := &obtenantresource.OBTenantManager{
-		Ctx:      m.Ctx,
+		Ctx:      ctx,
 		Client:   m.Client,
 		Recorder: m.Recorder,
 		Logger:   m.Logger,
@@ -127,11 +130,11 @@ func (m *ObTenantOperationManager) CreateUsersForActivatedStandby() tasktypes.Ta
 	// Hack:
 	tenantManager.OBTenant.ObjectMeta.SetNamespace(m.Resource.Namespace)
 	// Just reuse the logic of creating users for new coming tenant
-	_ = tenantManager.CreateUserWithCredentials()
+	_ = tenantManager.CreateUserWithCredentials(ctx)
 	return nil
 }
 
-func (m *ObTenantOperationManager) SwitchTenantsRole() tasktypes.TaskError {
+func (m *ObTenantOperationManager) SwitchTenantsRole(ctx context.Context) tasktypes.TaskError {
 	// TODO: check whether the two tenants are in the same cluster
 	con, err := m.getClusterSysClient(m.Resource.Status.PrimaryTenant.Spec.ClusterName)
 	if err != nil {
@@ -154,7 +157,9 @@ func (m *ObTenantOperationManager) SwitchTenantsRole() tasktypes.TaskError {
 			}
 			p := primary[0]
 			if p.TenantRole != "STANDBY" || p.SwitchoverStatus != "NORMAL" {
-				time.Sleep(oceanbaseconst.TenantOpRetryGapSeconds * time.Second)
+				if err := resourceutils.SleepWithContext(ctx, oceanbaseconst.TenantOpRetryGapSeconds*time.Second); err != nil {
+					return err
+				}
 				counter++
 			} else {
 				break
@@ -182,7 +187,9 @@ func (m *ObTenantOperationManager) SwitchTenantsRole() tasktypes.TaskError {
 			}
 			s := standby[0]
 			if s.TenantRole != "PRIMARY" || s.SwitchoverStatus != "NORMAL" {
-				time.Sleep(oceanbaseconst.TenantOpRetryGapSeconds * time.Second)
+				if err := resourceutils.SleepWithContext(ctx, oceanbaseconst.TenantOpRetryGapSeconds*time.Second); err != nil {
+					return err
+				}
 				counter++
 			} else {
 				break
@@ -222,20 +229,20 @@ func (m *ObTenantOperationManager) SwitchTenantsRole() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantOperationManager) SetTenantLogRestoreSource() tasktypes.TaskError {
+func (m *ObTenantOperationManager) SetTenantLogRestoreSource(ctx context.Context) tasktypes.TaskError {
 	var err error
 	if m.Resource.Status.Status == constants.TenantOpRunning {
 		originStandby := m.Resource.Status.SecondaryTenant.DeepCopy()
 		originStandby.SetName(m.Resource.Spec.Switchover.StandbyTenant)
 		originStandby.SetNamespace(m.Resource.GetNamespace())
 		tenantManager := &obtenantresource.OBTenantManager{
-			Ctx:      m.Ctx,
+			Ctx:      ctx,
 			Client:   m.Client,
 			Recorder: m.Recorder,
 			Logger:   m.Logger,
 			OBTenant: originStandby,
 		}
-		err = tenantManager.CreateUserWithCredentials()
+		err = tenantManager.CreateUserWithCredentials(ctx)
 		if err != nil {
 			return err
 		}
@@ -244,7 +251,7 @@ func (m *ObTenantOperationManager) SetTenantLogRestoreSource() tasktypes.TaskErr
 		if err != nil {
 			return err
 		}
-		restoreSource, err := resourceutils.GetTenantRestoreSource(m.Ctx, m.Client, m.Logger, con, m.Resource.Namespace, m.Resource.Spec.Switchover.StandbyTenant)
+		restoreSource, err := resourceutils.GetTenantRestoreSource(ctx, m.Client, m.Logger, con, m.Resource.Namespace, m.Resource.Spec.Switchover.StandbyTenant)
 		if err != nil {
 			return err
 		}
@@ -318,7 +325,7 @@ func (m *ObTenantOperationManager) retryUpdateTenant(obj *v1alpha1.OBTenant) err
 	})
 }
 
-func (m *ObTenantOperationManager) UpgradeTenant() tasktypes.TaskError {
+func (m *ObTenantOperationManager) UpgradeTenant(ctx context.Context) tasktypes.TaskError {
 	targetTenant := m.Resource.Status.PrimaryTenant
 	con, err := m.getClusterSysClient(targetTenant.Spec.ClusterName)
 	if err != nil {
@@ -348,7 +355,9 @@ func (m *ObTenantOperationManager) UpgradeTenant() tasktypes.TaskError {
 		maxWait5secTimes := oceanbaseconst.DefaultStateWaitTimeout/5 + 1
 	outer:
 		for i := 0; i < maxWait5secTimes; i++ {
-			time.Sleep(5 * time.Second)
+			if err := resourceutils.SleepWithContext(ctx, 5*time.Second); err != nil {
+				return err
+			}
 			params, err := con.ListParametersWithTenantID(int64(targetTenant.Status.TenantRecordInfo.TenantID))
 			if err != nil {
 				return err
@@ -367,7 +376,7 @@ func (m *ObTenantOperationManager) UpgradeTenant() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantOperationManager) ReplayLogOfStandby() tasktypes.TaskError {
+func (m *ObTenantOperationManager) ReplayLogOfStandby(ctx context.Context) tasktypes.TaskError {
 	targetTenant := m.Resource.Status.PrimaryTenant
 	if targetTenant.Status.TenantRole != constants.TenantRoleStandby {
 		return errors.New("The target tenant is not standby")
@@ -23,7 +23,8 @@ const (
 )
 
 const (
-	tStartRestoreJob ttypes.TaskName = "start restore job"
-	tStartLogReplay  ttypes.TaskName = "start log replay"
-	tActivateStandby ttypes.TaskName = "activate standby"
+	tCheckRestoreWindow ttypes.TaskName = "check restore window"
+	tStartRestoreJob    ttypes.TaskName = "start restore job"
+	tStartLogReplay     ttypes.TaskName = "start log replay"
+	tActivateStandby    ttypes.TaskName = "activate standby"
 )
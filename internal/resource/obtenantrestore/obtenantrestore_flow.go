@@ -21,7 +21,7 @@ func StartRestoreJob() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
 			Name:         fStartRestoreFlow,
-			Tasks:        []tasktypes.TaskName{tStartRestoreJob},
+			Tasks:        []tasktypes.TaskName{tCheckRestoreWindow, tStartRestoreJob},
 			TargetStatus: string(constants.RestoreJobRunning),
 			OnFailure: tasktypes.FailureRule{
 				NextTryStatus: string(constants.RestoreJobFailed),
@@ -14,6 +14,7 @@ package obtenantrestore
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -25,6 +26,7 @@ import (
 	"github.com/oceanbase/ob-operator/api/constants"
 	apitypes "github.com/oceanbase/ob-operator/api/types"
 	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
+	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
 	"github.com/oceanbase/ob-operator/internal/telemetry"
 	opresource "github.com/oceanbase/ob-operator/pkg/coordinator"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
@@ -71,6 +73,22 @@ func (m ObTenantRestoreManager) SetOperationContext(c *tasktypes.OperationContex
 	m.Resource.Status.OperationContext = c
 }
 
+func (m ObTenantRestoreManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.Resource.Status.FlowHistory = tasktypes.AppendFlowRecord(m.Resource.Status.FlowHistory, record)
+}
+
+func (m ObTenantRestoreManager) IsPaused() bool {
+	return resourceutils.IsReconcilePaused(m.Resource)
+}
+
+func (m ObTenantRestoreManager) SetPaused(paused bool) bool {
+	if m.Resource.Status.Paused == paused {
+		return false
+	}
+	m.Resource.Status.Paused = paused
+	return true
+}
+
 func (m ObTenantRestoreManager) ClearTaskInfo() {
 	m.Resource.Status.Status = constants.RestoreJobRunning
 	m.Resource.Status.OperationContext = nil
@@ -163,6 +181,9 @@ func (m *ObTenantRestoreManager) checkRestoreProgress() error {
 }
 
 func (m ObTenantRestoreManager) UpdateStatus() error {
+	if m.Resource.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	var err error
 	if m.Resource.Status.Status == constants.RestoreJobRunning {
 		err = m.checkRestoreProgress()
@@ -173,8 +194,14 @@ func (m ObTenantRestoreManager) UpdateStatus() error {
 	return m.retryUpdateStatus()
 }
 
+func (m ObTenantRestoreManager) GetTaskTimeout(tasktypes.TaskName) time.Duration {
+	return 0
+}
+
 func (m ObTenantRestoreManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
+	case tCheckRestoreWindow:
+		return m.CheckRestoreWindow, nil
 	case tStartRestoreJob:
 		return m.StartRestoreJobInOB, nil
 	case tStartLogReplay:
@@ -248,7 +275,16 @@ func (m *ObTenantRestoreManager) retryUpdateStatus() error {
 		if err != nil {
 			return client.IgnoreNotFound(err)
 		}
+		restorableWindow := resource.Status.RestorableWindow
+		message := resource.Status.Message
 		resource.Status = m.Resource.Status
+		// Restorable window and message are recorded by task asynchronously, do not overwrite them
+		if resource.Status.RestorableWindow == nil {
+			resource.Status.RestorableWindow = restorableWindow
+		}
+		if resource.Status.Message == "" {
+			resource.Status.Message = message
+		}
 		return m.Client.Status().Update(m.Ctx, resource)
 	})
 }
@@ -13,35 +13,205 @@ See the Mulan PSL v2 for more details.
 package obtenantrestore
 
 import (
+	"context"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/param"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
 // Restore progress:
 // 1. create unit (in tenant manager)
 // 2. create resource pool (in tenant manager)
-// 3. trigger restore job
-// 4. wait for finishing
-// 5. upgrade tenant if needed
-// 6. activate or replay log or do nothing
+// 3. check whether restore target is covered by backup data and archive log
+// 4. trigger restore job
+// 5. wait for finishing
+// 6. upgrade tenant if needed
+// 7. activate or replay log or do nothing
 
 // OBTenantRestore tasks
 
-func (m *ObTenantRestoreManager) StartRestoreJobInOB() tasktypes.TaskError {
+func (m *ObTenantRestoreManager) CheckRestoreWindow(ctx context.Context) tasktypes.TaskError {
+	con, err := m.getClusterSysClient()
+	if err != nil {
+		return err
+	}
+	sourceUri, err := m.getSourceUri()
+	if err != nil {
+		return err
+	}
+	paths := strings.Split(sourceUri, ",")
+	if len(paths) != 2 {
+		return errors.Errorf("Unexpected restore source uri %s, data backup path and archive log path are required", sourceUri)
+	}
+	// Backup sets and archive log pieces are only recorded in the cluster where the source tenant lives
+	sourceCon, sourceTenantName, err := m.getSourceTenantSysClient(ctx, paths[0], paths[1])
+	if err != nil {
+		m.Recorder.Event(m.Resource, v1.EventTypeWarning, "RestoreWindowUnknown", err.Error())
+		return err
+	}
+	sourceTenant, err := sourceCon.GetTenantByName(sourceTenantName)
+	if err != nil {
+		return err
+	}
+	sets, err := sourceCon.ListBackupSetFilesOfTenant(sourceTenant.TenantID)
+	if err != nil {
+		return err
+	}
+	pieces, err := sourceCon.ListArchiveLogPieceFilesOfTenant(sourceTenant.TenantID)
+	if err != nil {
+		return err
+	}
+	window := util.ComputeRestorableWindow(sets, pieces, paths[0], paths[1])
+	if window == nil {
+		message := fmt.Sprintf("No usable backup set or archive log of tenant %s found in the restore source", sourceTenantName)
+		m.Recorder.Event(m.Resource, v1.EventTypeWarning, "RestoreWindowUnknown", message)
+		if err := m.recordRestoreWindow(ctx, nil, message); err != nil {
+			return err
+		}
+		return errors.New(message)
+	}
+
+	until := m.Resource.Spec.Source.Until
+	var message string
+	if !until.Unlimited {
+		var targetScn int64
+		var target string
+		if until.Timestamp != nil {
+			target = "timestamp " + *until.Timestamp
+			// Convert in the target cluster, whose time zone is used to interpret the timestamp of restore job
+			targetScn, err = con.GetScnOfTimestamp(*until.Timestamp)
+			if err != nil {
+				return errors.Wrap(err, "parse restore until timestamp")
+			}
+		} else if until.Scn != nil {
+			target = "scn " + *until.Scn
+			targetScn, err = strconv.ParseInt(*until.Scn, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "parse restore until scn")
+			}
+		}
+		if target != "" && (targetScn < window.LowerBoundScn || targetScn > window.UpperBoundScn) {
+			message = fmt.Sprintf("Restore target %s is out of restorable window [%s (scn %d), %s (scn %d)]",
+				target, window.LowerBoundDisplay, window.LowerBoundScn, window.UpperBoundDisplay, window.UpperBoundScn)
+		}
+	}
+
+	if err := m.recordRestoreWindow(ctx, window, message); err != nil {
+		return err
+	}
+	if message != "" {
+		m.Recorder.Event(m.Resource, v1.EventTypeWarning, "RestoreTargetNotCovered", message)
+		return errors.New(message)
+	}
+	return nil
+}
+
+func (m *ObTenantRestoreManager) recordRestoreWindow(ctx context.Context, window *model.RestorableWindow, message string) error {
+	m.Resource.Status.RestorableWindow = window
+	m.Resource.Status.Message = message
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		resource := &v1alpha1.OBTenantRestore{}
+		err := m.Client.Get(ctx, types.NamespacedName{
+			Namespace: m.Resource.GetNamespace(),
+			Name:      m.Resource.GetName(),
+		}, resource)
+		if err != nil {
+			return err
+		}
+		resource.Status.RestorableWindow = window
+		resource.Status.Message = message
+		return m.Client.Status().Update(ctx, resource)
+	})
+	if err != nil {
+		return errors.Wrap(err, "record restorable window")
+	}
+	return nil
+}
+
+// getSourceTenantSysClient finds the tenant that the restore source is backed up from, by matching destinations
+// of backup policies in the namespace, or by the primary tenant of standby restore.
+// It returns sys client of the cluster where the source tenant lives and name of the source tenant in database.
+func (m *ObTenantRestoreManager) getSourceTenantSysClient(ctx context.Context, dataPath, archivePath string) (*operation.OceanbaseOperationManager, string, error) {
+	var clusterName, tenantName, tenantCRName string
+	policies := &v1alpha1.OBTenantBackupPolicyList{}
+	err := m.Client.List(ctx, policies, client.InNamespace(m.Resource.Namespace))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "list backup policies")
+	}
+	for _, policy := range policies.Items {
+		if util.IsSameBackupDest(destPathOf(&policy.Spec.DataBackup.Destination), dataPath) &&
+			util.IsSameBackupDest(destPathOf(&policy.Spec.LogArchive.Destination), archivePath) {
+			clusterName = policy.Spec.ObClusterName
+			tenantName = policy.Spec.TenantName
+			tenantCRName = policy.Spec.TenantCRName
+			break
+		}
+	}
+	if clusterName == "" && m.Resource.Spec.PrimaryTenant != nil {
+		tenantCRName = *m.Resource.Spec.PrimaryTenant
+	}
+	if tenantName == "" && tenantCRName != "" {
+		tenant := &v1alpha1.OBTenant{}
+		err := m.Client.Get(ctx, types.NamespacedName{
+			Namespace: m.Resource.Namespace,
+			Name:      tenantCRName,
+		}, tenant)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "get source obtenant")
+		}
+		clusterName = tenant.Spec.ClusterName
+		tenantName = tenant.Spec.TenantName
+	}
+	if clusterName == "" || tenantName == "" {
+		return nil, "", errors.New("Source tenant of the restore source is not found in the namespace, restorable window can not be checked")
+	}
+	if clusterName == m.Resource.Spec.TargetCluster {
+		con, err := m.getClusterSysClient()
+		return con, tenantName, err
+	}
+	obcluster := &v1alpha1.OBCluster{}
+	err = m.Client.Get(ctx, types.NamespacedName{
+		Namespace: m.Resource.Namespace,
+		Name:      clusterName,
+	}, obcluster)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "get obcluster of source tenant")
+	}
+	con, err := resourceutils.GetSysOperationClient(m.Client, m.Logger, obcluster)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "get oceanbase operation manager of source cluster")
+	}
+	return con, tenantName, nil
+}
+
+// destPathOf returns path of backup destination without access parameters, which is enough for comparison
+func destPathOf(dest *apitypes.BackupDestination) string {
+	if dest.Type == constants.BackupDestTypeNFS || dest.Type == "" {
+		return "file://" + path.Join(oceanbaseconst.BackupPath, dest.Path)
+	}
+	return dest.Path
+}
+
+func (m *ObTenantRestoreManager) StartRestoreJobInOB(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
@@ -88,13 +258,18 @@ func (m *ObTenantRestoreManager) StartRestoreJobInOB() tasktypes.TaskError {
 	return nil
 }
 
-func (m *ObTenantRestoreManager) StartLogReplay() tasktypes.TaskError {
+func (m *ObTenantRestoreManager) StartLogReplay(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
 	}
-	if m.Resource.Spec.PrimaryTenant != nil {
-		restoreSource, err := resourceutils.GetTenantRestoreSource(m.Ctx, m.Client, m.Logger, con, m.Resource.Namespace, *m.Resource.Spec.PrimaryTenant)
+	if m.Resource.Spec.PrimaryTenant != nil || m.Resource.Spec.ExternalPrimary != nil {
+		var restoreSource string
+		if m.Resource.Spec.ExternalPrimary != nil {
+			restoreSource, err = resourceutils.GetExternalTenantRestoreSource(m.Client, m.Resource.Namespace, m.Resource.Spec.ExternalPrimary)
+		} else {
+			restoreSource, err = resourceutils.GetTenantRestoreSource(ctx, m.Client, m.Logger, con, m.Resource.Namespace, *m.Resource.Spec.PrimaryTenant)
+		}
 		if err != nil {
 			return err
 		}
@@ -120,7 +295,7 @@ func (m *ObTenantRestoreManager) StartLogReplay() tasktypes.TaskError {
 	return err
 }
 
-func (m *ObTenantRestoreManager) ActivateStandby() tasktypes.TaskError {
+func (m *ObTenantRestoreManager) ActivateStandby(ctx context.Context) tasktypes.TaskError {
 	con, err := m.getClusterSysClient()
 	if err != nil {
 		return err
@@ -154,22 +329,22 @@ func (m *ObTenantRestoreManager) getSourceUri() (string, error) {
 		return source.SourceUri, nil
 	}
 	var bakPath, archivePath string
-	if source.BakDataSource != nil && source.BakDataSource.Type == constants.BackupDestTypeOSS {
-		accessId, accessKey, err := m.readAccessCredentials(source.BakDataSource.OSSAccessSecret)
+	if source.BakDataSource != nil && constants.IsObjectStorageDest(source.BakDataSource.Type) {
+		secret, err := m.readAccessSecret(source.BakDataSource.OSSAccessSecret)
 		if err != nil {
 			return "", err
 		}
-		bakPath = strings.Join([]string{source.BakDataSource.Path, "access_id=" + accessId, "access_key=" + accessKey}, "&")
+		bakPath = resourceutils.GetObjectStorageDestPath(source.BakDataSource, secret)
 	} else {
 		bakPath = "file://" + path.Join(oceanbaseconst.BackupPath, source.BakDataSource.Path)
 	}
 
-	if source.ArchiveSource != nil && source.ArchiveSource.Type == constants.BackupDestTypeOSS {
-		accessId, accessKey, err := m.readAccessCredentials(source.ArchiveSource.OSSAccessSecret)
+	if source.ArchiveSource != nil && constants.IsObjectStorageDest(source.ArchiveSource.Type) {
+		secret, err := m.readAccessSecret(source.ArchiveSource.OSSAccessSecret)
 		if err != nil {
 			return "", err
 		}
-		archivePath = strings.Join([]string{source.ArchiveSource.Path, "access_id=" + accessId, "access_key=" + accessKey}, "&")
+		archivePath = resourceutils.GetObjectStorageDestPath(source.ArchiveSource, secret)
 	} else {
 		archivePath = "file://" + path.Join(oceanbaseconst.BackupPath, source.ArchiveSource.Path)
 	}
@@ -181,16 +356,14 @@ func (m *ObTenantRestoreManager) getSourceUri() (string, error) {
 	return strings.Join([]string{bakPath, archivePath}, ","), nil
 }
 
-func (m *ObTenantRestoreManager) readAccessCredentials(secretName string) (accessId, accessKey string, err error) {
+func (m *ObTenantRestoreManager) readAccessSecret(secretName string) (*v1.Secret, error) {
 	secret := &v1.Secret{}
-	err = m.Client.Get(m.Ctx, types.NamespacedName{
+	err := m.Client.Get(m.Ctx, types.NamespacedName{
 		Namespace: m.Resource.Namespace,
 		Name:      secretName,
 	}, secret)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	accessId = string(secret.Data["accessId"])
-	accessKey = string(secret.Data["accessKey"])
-	return accessId, accessKey, nil
+	return secret, nil
 }
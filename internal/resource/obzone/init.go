@@ -30,4 +30,6 @@ func init() {
 	task.GetRegistry().Register(fScaleUpOBServers, ScaleUpOBServers)
 	task.GetRegistry().Register(fExpandPVC, ResizePVC)
 	task.GetRegistry().Register(fMountBackupVolume, MountBackupVolume)
+	task.GetRegistry().Register(fEnterMaintenance, EnterMaintenance)
+	task.GetRegistry().Register(fExitMaintenance, ExitMaintenance)
 }
@@ -13,6 +13,9 @@ See the Mulan PSL v2 for more details.
 package obzone
 
 import (
+	"time"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	ttypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -30,6 +33,8 @@ const (
 	fScaleUpOBServers             ttypes.FlowName = "scale up observers"
 	fExpandPVC                    ttypes.FlowName = "expand pvc for obzone"
 	fMountBackupVolume            ttypes.FlowName = "mount backup volume for obzone"
+	fEnterMaintenance             ttypes.FlowName = "enter maintenance for obzone"
+	fExitMaintenance              ttypes.FlowName = "exit maintenance for obzone"
 )
 
 // obzone tasks
@@ -56,4 +61,14 @@ const (
 	tWaitForOBServerExpandingPVC ttypes.TaskName = "wait for observer to expand pvc"
 	tMountBackupVolume           ttypes.TaskName = "mount backup volume"
 	tWaitForOBServerMounting     ttypes.TaskName = "wait for observer to mount backup volume"
+	tCheckUnitsForScaleIn        ttypes.TaskName = "check units for scale in"
+	tWaitZoneLeadersSwitchedOut  ttypes.TaskName = "wait zone leaders switched out"
+	tWaitOBZoneActive            ttypes.TaskName = "wait obzone active"
 )
+
+// timeouts of obzone tasks, tasks not listed here are not limited and bound their waiting by themselves
+var taskTimeouts = map[ttypes.TaskName]time.Duration{
+	tWaitReplicaMatch:     oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tWaitOBServerDeleted:  oceanbaseconst.ServerDeleteTimeoutSeconds * time.Second,
+	tWaitOBServerUpgraded: oceanbaseconst.TimeConsumingStateWaitTimeout * oceanbaseconst.CommonCheckInterval * time.Second,
+}
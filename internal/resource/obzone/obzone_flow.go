@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package obzone
 
 import (
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	zonestatus "github.com/oceanbase/ob-operator/internal/const/status/obzone"
 	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
@@ -72,10 +73,11 @@ func DeleteOBServer() *tasktypes.TaskFlow {
 	return &tasktypes.TaskFlow{
 		OperationContext: &tasktypes.OperationContext{
 			Name:         fDeleteOBServer,
-			Tasks:        []tasktypes.TaskName{tDeleteOBServer, tWaitReplicaMatch},
+			Tasks:        []tasktypes.TaskName{tCheckUnitsForScaleIn, tDeleteOBServer, tWaitReplicaMatch},
 			TargetStatus: zonestatus.Running,
 			OnFailure: tasktypes.FailureRule{
 				Strategy: strategy.RetryFromCurrent,
+				MaxRetry: oceanbaseconst.ScaleResourceMaxRetryTimes,
 			},
 		},
 	}
@@ -140,3 +142,32 @@ func MountBackupVolume() *tasktypes.TaskFlow {
 		},
 	}
 }
+
+func EnterMaintenance() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fEnterMaintenance,
+			Tasks:        []tasktypes.TaskName{tStopOBZone, tWaitZoneLeadersSwitchedOut},
+			TargetStatus: zonestatus.Maintenance,
+			// stopping may have taken effect before failure, roll back by starting it again
+			OnFailure: tasktypes.FailureRule{
+				Strategy:      strategy.StartOver,
+				NextTryStatus: zonestatus.ExitMaintenance,
+			},
+		},
+	}
+}
+
+func ExitMaintenance() *tasktypes.TaskFlow {
+	return &tasktypes.TaskFlow{
+		OperationContext: &tasktypes.OperationContext{
+			Name:         fExitMaintenance,
+			Tasks:        []tasktypes.TaskName{tStartOBZone, tWaitOBZoneActive},
+			TargetStatus: zonestatus.Running,
+			OnFailure: tasktypes.FailureRule{
+				Strategy:      strategy.StartOver,
+				NextTryStatus: zonestatus.Maintenance,
+			},
+		},
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package obzone
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/api/v1alpha1"
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	zonestatus "github.com/oceanbase/ob-operator/internal/const/status/obzone"
+	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
+)
+
+var _ = Describe("OBZone task flows", func() {
+	newManager := func(status string) *OBZoneManager {
+		logger := logr.Discard()
+		obzone := &v1alpha1.OBZone{}
+		obzone.Status.Status = status
+		return &OBZoneManager{OBZone: obzone, Logger: &logger}
+	}
+
+	It("Gives up deleting observers after limited retries", func() {
+		flow := DeleteOBServer()
+		Expect(flow.OperationContext.Tasks[0]).To(Equal(tCheckUnitsForScaleIn))
+		Expect(flow.OperationContext.OnFailure.Strategy).To(BeEquivalentTo(strategy.RetryFromCurrent))
+		Expect(flow.OperationContext.OnFailure.MaxRetry).To(Equal(oceanbaseconst.ScaleResourceMaxRetryTimes))
+	})
+
+	It("Starts obzone again when entering maintenance failed", func() {
+		m := newManager(zonestatus.EnterMaintenance)
+		flow, err := m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Name).To(Equal(fEnterMaintenance))
+		m.OBZone.Status.OperationContext = flow.OperationContext
+		m.HandleFailure()
+		Expect(m.OBZone.Status.Status).To(Equal(zonestatus.ExitMaintenance))
+		Expect(m.OBZone.Status.OperationContext).To(BeNil())
+
+		flow, err = m.GetTaskFlow()
+		Expect(err).To(BeNil())
+		Expect(flow.OperationContext.Name).To(Equal(fExitMaintenance))
+		Expect(flow.OperationContext.Tasks[0]).To(Equal(tStartOBZone))
+	})
+})
@@ -14,6 +14,7 @@ package obzone
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -72,6 +73,27 @@ func (m *OBZoneManager) SetOperationContext(c *tasktypes.OperationContext) {
 	m.OBZone.Status.OperationContext = c
 }
 
+func (m *OBZoneManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.OBZone.Status.FlowHistory = tasktypes.AppendFlowRecord(m.OBZone.Status.FlowHistory, record)
+}
+
+// IsPaused tells whether reconciliation of obzone is paused, pausing obcluster pauses its obzones as well
+func (m *OBZoneManager) IsPaused() bool {
+	if resourceutils.IsReconcilePaused(m.OBZone) {
+		return true
+	}
+	obcluster, err := m.getOBCluster()
+	return err == nil && resourceutils.IsReconcilePaused(obcluster)
+}
+
+func (m *OBZoneManager) SetPaused(paused bool) bool {
+	if m.OBZone.Status.Paused == paused {
+		return false
+	}
+	m.OBZone.Status.Paused = paused
+	return true
+}
+
 func (m *OBZoneManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 	// exists unfinished task flow, return the last task flow
 	if m.OBZone.Status.OperationContext != nil {
@@ -117,6 +139,10 @@ func (m *OBZoneManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
 		taskFlow, err = task.GetRegistry().Get(fExpandPVC)
 	case zonestatus.MountBackupVolume:
 		taskFlow, err = task.GetRegistry().Get(fMountBackupVolume)
+	case zonestatus.EnterMaintenance:
+		taskFlow, err = task.GetRegistry().Get(fEnterMaintenance)
+	case zonestatus.ExitMaintenance:
+		taskFlow, err = task.GetRegistry().Get(fExitMaintenance)
 	case zonestatus.Upgrade:
 		obcluster, err = m.getOBCluster()
 		if err != nil {
@@ -186,6 +212,9 @@ func (m *OBZoneManager) ArchiveResource() {
 }
 
 func (m *OBZoneManager) UpdateStatus() error {
+	if m.OBZone.Status.Paused {
+		return m.retryUpdateStatus()
+	}
 	observerList, err := m.listOBServers()
 	if err != nil {
 		m.Logger.Error(err, "Got error when list observers")
@@ -213,6 +242,13 @@ func (m *OBZoneManager) UpdateStatus() error {
 	if m.IsDeleting() {
 		m.OBZone.Status.Status = zonestatus.Deleting
 	}
+	if m.OBZone.Status.Status == zonestatus.Running && m.OBZone.Spec.Maintenance {
+		m.Logger.Info("Maintenance flag is set, stop obzone in cluster")
+		m.OBZone.Status.Status = zonestatus.EnterMaintenance
+	} else if m.OBZone.Status.Status == zonestatus.Maintenance && !m.OBZone.Spec.Maintenance {
+		m.Logger.Info("Maintenance flag is cleared, start obzone in cluster")
+		m.OBZone.Status.Status = zonestatus.ExitMaintenance
+	}
 	if m.OBZone.Status.Status != zonestatus.Running {
 		m.Logger.V(oceanbaseconst.LogLevelDebug).Info("OBZone status is not running, skip compare")
 	} else {
@@ -227,15 +263,12 @@ func (m *OBZoneManager) UpdateStatus() error {
 		} else if m.OBZone.Spec.Topology.Replica < len(m.OBZone.Status.OBServerStatus) {
 			m.Logger.Info("Compare topology need delete observer")
 			m.OBZone.Status.Status = zonestatus.DeleteOBServer
-		} else if mode, exist := resourceutils.GetAnnotationField(m.OBZone, oceanbaseconst.AnnotationsMode); exist && mode == oceanbaseconst.ModeStandalone {
+		} else {
 			for _, observer := range observerList.Items {
 				if m.checkIfCalcResourceChange(&observer) {
 					m.OBZone.Status.Status = zonestatus.ScaleUp
 					break
 				}
-			}
-		} else {
-			for _, observer := range observerList.Items {
 				if m.checkIfStorageSizeExpand(&observer) {
 					m.OBZone.Status.Status = zonestatus.ExpandPVC
 					break
@@ -299,6 +332,13 @@ func (m *OBZoneManager) FinishTask() {
 	m.OBZone.Status.OperationContext = nil
 }
 
+func (m *OBZoneManager) GetTaskTimeout(name tasktypes.TaskName) time.Duration {
+	if timeout, ok := taskTimeouts[name]; ok {
+		return timeout
+	}
+	return 0
+}
+
 func (m *OBZoneManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc, error) {
 	switch name {
 	case tCreateOBServer:
@@ -319,6 +359,12 @@ func (m *OBZoneManager) GetTaskFunc(name tasktypes.TaskName) (tasktypes.TaskFunc
 		return m.StartOBZone, nil
 	case tDeleteOBServer:
 		return m.DeleteOBServer, nil
+	case tCheckUnitsForScaleIn:
+		return m.CheckUnitsForScaleIn, nil
+	case tWaitZoneLeadersSwitchedOut:
+		return m.WaitZoneLeadersSwitchedOut, nil
+	case tWaitOBZoneActive:
+		return m.WaitOBZoneActive, nil
 	case tDeleteAllOBServer:
 		return m.DeleteAllOBServer, nil
 	case tWaitReplicaMatch:
@@ -13,20 +13,24 @@ See the Mulan PSL v2 for more details.
 package obzone
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/util/retry"
 
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	v1alpha1 "github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	serverstatus "github.com/oceanbase/ob-operator/internal/const/status/observer"
 	resourceutils "github.com/oceanbase/ob-operator/internal/resource/utils"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
 	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
+	obutil "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -42,7 +46,7 @@ func (m *OBZoneManager) generateServerName() string {
 	return fmt.Sprintf("%s-%d-%s-%s", m.OBZone.Spec.ClusterName, m.OBZone.Spec.ClusterId, m.OBZone.Spec.Topology.Zone, rand.String(6))
 }
 
-func (m *OBZoneManager) AddZone() tasktypes.TaskError {
+func (m *OBZoneManager) AddZone(ctx context.Context) tasktypes.TaskError {
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		m.Logger.Error(err, "Get oceanbase operation manager failed")
@@ -51,7 +55,7 @@ func (m *OBZoneManager) AddZone() tasktypes.TaskError {
 	return oceanbaseOperationManager.AddZone(m.OBZone.Spec.Topology.Zone)
 }
 
-func (m *OBZoneManager) StartOBZone() tasktypes.TaskError {
+func (m *OBZoneManager) StartOBZone(ctx context.Context) tasktypes.TaskError {
 	oceanbaseOperationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		m.Logger.Error(err, "Get oceanbase operation manager failed")
@@ -61,7 +65,7 @@ func (m *OBZoneManager) StartOBZone() tasktypes.TaskError {
 }
 
 func (m *OBZoneManager) generateWaitOBServerStatusFunc(status string, timeoutSeconds int) tasktypes.TaskFunc {
-	f := func() tasktypes.TaskError {
+	f := func(ctx context.Context) tasktypes.TaskError {
 		for i := 1; i < timeoutSeconds; i++ {
 			obzone, err := m.getOBZone()
 			if err != nil {
@@ -78,14 +82,16 @@ func (m *OBZoneManager) generateWaitOBServerStatusFunc(status string, timeoutSec
 			if allMatched {
 				return nil
 			}
-			time.Sleep(time.Second)
+			if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+				return err
+			}
 		}
 		return errors.New("all server still not bootstrap ready when timeout")
 	}
 	return f
 }
 
-func (m *OBZoneManager) CreateOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) CreateOBServer(ctx context.Context) tasktypes.TaskError {
 	m.Logger.Info("Create observers")
 	blockOwnerDeletion := true
 	ownerReferenceList := make([]metav1.OwnerReference, 0)
@@ -135,6 +141,7 @@ func (m *OBZoneManager) CreateOBServer() tasktypes.TaskError {
 				MonitorTemplate:  m.OBZone.Spec.MonitorTemplate,
 				BackupVolume:     m.OBZone.Spec.BackupVolume,
 				ServiceAccount:   m.OBZone.Spec.ServiceAccount,
+				PodTemplate:      m.OBZone.Spec.Topology.PodTemplate,
 			},
 		}
 		observer.ObjectMeta.Annotations = make(map[string]string)
@@ -151,7 +158,7 @@ func (m *OBZoneManager) CreateOBServer() tasktypes.TaskError {
 			observer.ObjectMeta.Annotations[oceanbaseconst.AnnotationsSourceClusterAddress] = migrateAnnoVal
 		}
 		m.Logger.Info("Create observer", "server", serverName)
-		err := m.Client.Create(m.Ctx, observer)
+		err := m.Client.Create(ctx, observer)
 		if err != nil {
 			m.Logger.Error(err, "create observer failed", "server", serverName)
 			return errors.Wrap(err, "create observer")
@@ -161,35 +168,87 @@ func (m *OBZoneManager) CreateOBServer() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) DeleteOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) DeleteOBServer(ctx context.Context) tasktypes.TaskError {
 	m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Delete observers")
 	observerList, err := m.listOBServers()
 	if err != nil {
 		m.Logger.Error(err, "List observers failed")
 		return errors.Wrapf(err, "List observrers of obzone %s", m.OBZone.Name)
 	}
-	observerCount := 0
-	for _, observer := range observerList.Items {
-		// bugfix: if an observer is being deleted, it won't be deleted again or counted as a working observer
-		if observer.Status.Status == serverstatus.Deleting {
-			continue
-		}
-		if observer.Status.Status == serverstatus.Unrecoverable || observerCount >= m.OBZone.Spec.Topology.Replica {
-			m.Logger.Info("Delete observer", "observer", observer)
-			err = m.Client.Delete(m.Ctx, &observer)
-			if err != nil {
-				return errors.Wrapf(err, "Delete observer %s failed", observer.Name)
+	observersToDelete := v1alpha1.SelectOBServersToDelete(observerList.Items, m.OBZone.Spec.Topology.Replica)
+	if len(observersToDelete) == 0 {
+		return nil
+	}
+	err = m.updateScaleInStatus(func(s *apitypes.ScaleInStatus) {
+	outer:
+		for _, observer := range observersToDelete {
+			for _, name := range s.OBServers {
+				if name == observer.Name {
+					continue outer
+				}
 			}
-			m.Recorder.Event(m.OBZone, "DeleteObServer", "DeleteObserver", fmt.Sprintf("Delete observer %+v", observer))
+			s.OBServers = append(s.OBServers, observer.Name)
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "Update scale in status")
+	}
+	for _, observer := range observersToDelete {
+		m.Logger.Info("Delete observer", "observer", observer)
+		err = m.Client.Delete(ctx, &observer)
+		if err != nil {
+			return errors.Wrapf(err, "Delete observer %s failed", observer.Name)
+		}
+		m.Recorder.Event(m.OBZone, "DeleteObServer", "DeleteObserver", fmt.Sprintf("Delete observer %+v", observer))
+	}
+	return nil
+}
+
+// CheckUnitsForScaleIn simulates migrating units from observers to delete onto the remaining ones,
+// so that deleting servers would not stall for lack of resource in the zone
+func (m *OBZoneManager) CheckUnitsForScaleIn(ctx context.Context) tasktypes.TaskError {
+	observerList, err := m.listOBServers()
+	if err != nil {
+		return errors.Wrapf(err, "List observrers of obzone %s", m.OBZone.Name)
+	}
+	observersToDelete := v1alpha1.SelectOBServersToDelete(observerList.Items, m.OBZone.Spec.Topology.Replica)
+	if len(observersToDelete) == 0 {
+		return nil
+	}
+	deleting := make(map[string]struct{}, len(observersToDelete))
+	for _, observer := range observersToDelete {
+		deleting[observer.Name] = struct{}{}
+	}
+	remainingServers := make([]string, 0, len(observerList.Items))
+	for _, observer := range observerList.Items {
+		if _, ok := deleting[observer.Name]; ok || observer.Status.Status == serverstatus.Deleting {
 			continue
 		}
-		observerCount++
+		remainingServers = append(remainingServers, observer.Status.GetConnectAddr())
+	}
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		return errors.Wrapf(err, "Get oceanbase operation manager of obzone %s", m.OBZone.Name)
+	}
+	zoneName := m.OBZone.Spec.Topology.Zone
+	resourceTotal, err := operationManager.GetResourceTotal(zoneName)
+	if err != nil {
+		return errors.Wrapf(err, "Get resource of zone %s", zoneName)
+	}
+	units, err := operationManager.ListUnitsWithZone(zoneName)
+	if err != nil {
+		return errors.Wrapf(err, "List units of zone %s", zoneName)
+	}
+	err = obutil.CheckZoneScaleIn(units, resourceTotal, remainingServers)
+	if err != nil {
+		m.Recorder.Event(m.OBZone, corev1.EventTypeWarning, "ScaleInBlocked", "Units could not be migrated to remaining observers: "+err.Error())
+		return errors.Wrapf(err, "Units of zone %s could not be migrated to remaining observers", zoneName)
 	}
 	return nil
 }
 
 // TODO refactor Delete observer method together
-func (m *OBZoneManager) DeleteAllOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) DeleteAllOBServer(ctx context.Context) tasktypes.TaskError {
 	m.Logger.Info("Delete all observers")
 	observerList, err := m.listOBServers()
 	if err != nil {
@@ -198,7 +257,7 @@ func (m *OBZoneManager) DeleteAllOBServer() tasktypes.TaskError {
 	}
 	for _, observer := range observerList.Items {
 		m.Logger.Info("Need to delete observer", "observer", observer.Name)
-		err = m.Client.Delete(m.Ctx, &observer)
+		err = m.Client.Delete(ctx, &observer)
 		if err != nil {
 			return errors.Wrapf(err, "Delete observer %s failed", observer.Name)
 		}
@@ -206,8 +265,12 @@ func (m *OBZoneManager) DeleteAllOBServer() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) WaitReplicaMatch() tasktypes.TaskError {
+func (m *OBZoneManager) WaitReplicaMatch(ctx context.Context) tasktypes.TaskError {
 	matched := false
+	operationManager, err := m.getOceanbaseOperationManager()
+	if err != nil {
+		m.Logger.Error(err, "Get oceanbase operation manager failed, progress of unit migration will not be reported")
+	}
 	for i := 0; i < oceanbaseconst.ServerDeleteTimeoutSeconds; i++ {
 		obzone, err := m.getOBZone()
 		if err != nil {
@@ -217,17 +280,26 @@ func (m *OBZoneManager) WaitReplicaMatch() tasktypes.TaskError {
 			matched = true
 			break
 		} else {
-			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Zone replica not match", "desired replica", m.OBZone.Spec.Topology.Replica, "current replica", len(m.OBZone.Status.OBServerStatus))
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Zone replica not match", "desired replica", m.OBZone.Spec.Topology.Replica, "current replica", len(obzone.Status.OBServerStatus))
+			if operationManager != nil && obzone.Status.ScaleIn != nil && i%oceanbaseconst.ScaleInCheckGapSeconds == 0 {
+				m.reportUnitMigrationProgress(operationManager, obzone.Status.ScaleIn)
+			}
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second*1); err != nil {
+			return err
 		}
-		time.Sleep(time.Second * 1)
 	}
 	if !matched {
 		return errors.Errorf("wait obzone %s replica match timeout", m.OBZone.Name)
 	}
+	err = m.clearScaleInStatus()
+	if err != nil {
+		return errors.Wrap(err, "Clear scale in status")
+	}
 	return nil
 }
 
-func (m *OBZoneManager) WaitOBServerDeleted() tasktypes.TaskError {
+func (m *OBZoneManager) WaitOBServerDeleted(ctx context.Context) tasktypes.TaskError {
 	matched := false
 	for i := 0; i < oceanbaseconst.ServerDeleteTimeoutSeconds; i++ {
 		obzone, err := m.getOBZone()
@@ -239,7 +311,9 @@ func (m *OBZoneManager) WaitOBServerDeleted() tasktypes.TaskError {
 			matched = true
 			break
 		}
-		time.Sleep(time.Second * 1)
+		if err := resourceutils.SleepWithContext(ctx, time.Second*1); err != nil {
+			return err
+		}
 	}
 	if !matched {
 		return errors.Errorf("wait obzone %s observer deleted timeout", m.OBZone.Name)
@@ -247,7 +321,7 @@ func (m *OBZoneManager) WaitOBServerDeleted() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) StopOBZone() tasktypes.TaskError {
+func (m *OBZoneManager) StopOBZone(ctx context.Context) tasktypes.TaskError {
 	operationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "OBZone %s get oceanbase operation manager", m.OBZone.Name)
@@ -259,7 +333,7 @@ func (m *OBZoneManager) StopOBZone() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) OBClusterHealthCheck() tasktypes.TaskError {
+func (m *OBZoneManager) OBClusterHealthCheck(ctx context.Context) tasktypes.TaskError {
 	obcluster, err := m.getOBCluster()
 	if err != nil {
 		return errors.Wrap(err, "Get obcluster from K8s")
@@ -268,7 +342,7 @@ func (m *OBZoneManager) OBClusterHealthCheck() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) OBZoneHealthCheck() tasktypes.TaskError {
+func (m *OBZoneManager) OBZoneHealthCheck(ctx context.Context) tasktypes.TaskError {
 	obcluster, err := m.getOBCluster()
 	if err != nil {
 		return errors.Wrap(err, "Get obcluster from K8s")
@@ -278,7 +352,7 @@ func (m *OBZoneManager) OBZoneHealthCheck() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) UpgradeOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) UpgradeOBServer(ctx context.Context) tasktypes.TaskError {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		observerList, err := m.listOBServers()
 		if err != nil {
@@ -288,7 +362,7 @@ func (m *OBZoneManager) UpgradeOBServer() tasktypes.TaskError {
 		for _, observer := range observerList.Items {
 			m.Logger.Info("Upgrade observer", "observer", observer.Name)
 			observer.Spec.OBServerTemplate.Image = m.OBZone.Spec.OBServerTemplate.Image
-			err = m.Client.Update(m.Ctx, &observer)
+			err = m.Client.Update(ctx, &observer)
 			if err != nil {
 				return errors.Wrapf(err, "Upgrade observer %s failed", observer.Name)
 			}
@@ -297,7 +371,7 @@ func (m *OBZoneManager) UpgradeOBServer() tasktypes.TaskError {
 	})
 }
 
-func (m *OBZoneManager) WaitOBServerUpgraded() tasktypes.TaskError {
+func (m *OBZoneManager) WaitOBServerUpgraded(ctx context.Context) tasktypes.TaskError {
 	for i := 0; i < oceanbaseconst.TimeConsumingStateWaitTimeout; i++ {
 		observerList, err := m.listOBServers()
 		if err != nil {
@@ -316,12 +390,14 @@ func (m *OBZoneManager) WaitOBServerUpgraded() tasktypes.TaskError {
 			m.Logger.Info("All server upgraded")
 			return nil
 		}
-		time.Sleep(oceanbaseconst.CommonCheckInterval * time.Second)
+		if err := resourceutils.SleepWithContext(ctx, oceanbaseconst.CommonCheckInterval*time.Second); err != nil {
+			return err
+		}
 	}
 	return errors.New("Wait all server upgraded timeout")
 }
 
-func (m *OBZoneManager) DeleteOBZoneInCluster() tasktypes.TaskError {
+func (m *OBZoneManager) DeleteOBZoneInCluster(ctx context.Context) tasktypes.TaskError {
 	operationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "OBZone %s get oceanbase operation manager", m.OBZone.Name)
@@ -333,19 +409,18 @@ func (m *OBZoneManager) DeleteOBZoneInCluster() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) ScaleUpOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) ScaleUpOBServer(ctx context.Context) tasktypes.TaskError {
 	observerList, err := m.listOBServers()
 	if err != nil {
 		return err
 	}
 	for _, observer := range observerList.Items {
-		if observer.Spec.OBServerTemplate.Resource.Cpu != m.OBZone.Spec.OBServerTemplate.Resource.Cpu ||
-			observer.Spec.OBServerTemplate.Resource.Memory != m.OBZone.Spec.OBServerTemplate.Resource.Memory {
+		if m.checkIfCalcResourceChange(&observer) {
 			m.Logger.Info("Scale up observer", "observer", observer.Name)
 			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 				observer.Spec.OBServerTemplate.Resource.Cpu = m.OBZone.Spec.OBServerTemplate.Resource.Cpu
 				observer.Spec.OBServerTemplate.Resource.Memory = m.OBZone.Spec.OBServerTemplate.Resource.Memory
-				return m.Client.Update(m.Ctx, &observer)
+				return m.Client.Update(ctx, &observer)
 			})
 			if err != nil {
 				return errors.Wrapf(err, "Scale up observer %s failed", observer.Name)
@@ -355,7 +430,7 @@ func (m *OBZoneManager) ScaleUpOBServer() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) ResizePVC() tasktypes.TaskError {
+func (m *OBZoneManager) ResizePVC(ctx context.Context) tasktypes.TaskError {
 	observerList, err := m.listOBServers()
 	if err != nil {
 		return err
@@ -369,7 +444,7 @@ func (m *OBZoneManager) ResizePVC() tasktypes.TaskError {
 				observer.Spec.OBServerTemplate.Storage.DataStorage.Size = m.OBZone.Spec.OBServerTemplate.Storage.DataStorage.Size
 				observer.Spec.OBServerTemplate.Storage.LogStorage.Size = m.OBZone.Spec.OBServerTemplate.Storage.LogStorage.Size
 				observer.Spec.OBServerTemplate.Storage.RedoLogStorage.Size = m.OBZone.Spec.OBServerTemplate.Storage.RedoLogStorage.Size
-				return m.Client.Update(m.Ctx, &observer)
+				return m.Client.Update(ctx, &observer)
 			})
 			if err != nil {
 				return errors.Wrapf(err, "Expand observer %s failed", observer.Name)
@@ -379,7 +454,7 @@ func (m *OBZoneManager) ResizePVC() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) MountBackupVolume() tasktypes.TaskError {
+func (m *OBZoneManager) MountBackupVolume(ctx context.Context) tasktypes.TaskError {
 	observerList, err := m.listOBServers()
 	if err != nil {
 		return err
@@ -389,7 +464,7 @@ func (m *OBZoneManager) MountBackupVolume() tasktypes.TaskError {
 			m.Logger.Info("Mount backup volume", "observer", observer.Name)
 			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 				observer.Spec.BackupVolume = m.OBZone.Spec.BackupVolume
-				return m.Client.Update(m.Ctx, &observer)
+				return m.Client.Update(ctx, &observer)
 			})
 			if err != nil {
 				return errors.Wrapf(err, "Mount backup volume %s failed", observer.Name)
@@ -399,7 +474,7 @@ func (m *OBZoneManager) MountBackupVolume() tasktypes.TaskError {
 	return nil
 }
 
-func (m *OBZoneManager) DeleteLegacyOBServer() tasktypes.TaskError {
+func (m *OBZoneManager) DeleteLegacyOBServer(ctx context.Context) tasktypes.TaskError {
 	operationManager, err := m.getOceanbaseOperationManager()
 	if err != nil {
 		return errors.Wrapf(err, "OBZone %s get oceanbase operation manager", m.OBZone.Name)
@@ -435,3 +510,42 @@ func (m *OBZoneManager) DeleteLegacyOBServer() tasktypes.TaskError {
 	}
 	return nil
 }
+
+func (m *OBZoneManager) WaitZoneLeadersSwitchedOut(ctx context.Context) tasktypes.TaskError {
+	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
+		operationManager, err := m.getOceanbaseOperationManager()
+		if err != nil {
+			return errors.Wrapf(err, "OBZone %s get oceanbase operation manager", m.OBZone.Name)
+		}
+		count, err := operationManager.CountLeadersOfZone(m.OBZone.Spec.Topology.Zone)
+		if err != nil {
+			m.Logger.V(oceanbaseconst.LogLevelDebug).Info("Count leaders of obzone failed, check next time", "error", err.Error())
+		} else if count == 0 {
+			m.Logger.Info("All leaders switched out of obzone", "zone", m.OBZone.Spec.Topology.Zone)
+			return nil
+		} else {
+			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("Leaders remain in obzone", "count", count)
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("Timeout to wait leaders switched out of obzone %s", m.OBZone.Spec.Topology.Zone)
+}
+
+func (m *OBZoneManager) WaitOBZoneActive(ctx context.Context) tasktypes.TaskError {
+	for i := 0; i < oceanbaseconst.DefaultStateWaitTimeout; i++ {
+		operationManager, err := m.getOceanbaseOperationManager()
+		if err != nil {
+			return errors.Wrapf(err, "OBZone %s get oceanbase operation manager", m.OBZone.Name)
+		}
+		if m.isOBZoneActive(operationManager) {
+			m.Logger.Info("OBZone and its observers become active", "zone", m.OBZone.Spec.Topology.Zone)
+			return nil
+		}
+		if err := resourceutils.SleepWithContext(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("Timeout to wait obzone %s active", m.OBZone.Spec.Topology.Zone)
+}
@@ -18,8 +18,12 @@ import (
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
+	observerstatus "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/server"
+	obzonestatus "github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/zone"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/operation"
 )
 
 func (m *OBZoneManager) checkIfStorageSizeExpand(observer *v1alpha1.OBServer) bool {
@@ -43,11 +47,88 @@ func (m *OBZoneManager) retryUpdateStatus() error {
 		if err != nil {
 			return client.IgnoreNotFound(err)
 		}
+		scaleInStatus := obzone.Status.ScaleIn
 		obzone.Status = *m.OBZone.Status.DeepCopy()
+		// scale in progress is maintained by the tasks deleting observers, keep the persisted one
+		obzone.Status.ScaleIn = scaleInStatus
 		return m.Client.Status().Update(m.Ctx, obzone)
 	})
 }
 
+// updateScaleInStatus applies the updater on the latest scale in status and persists it
+func (m *OBZoneManager) updateScaleInStatus(updater func(*apitypes.ScaleInStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obzone, err := m.getOBZone()
+		if err != nil {
+			return err
+		}
+		if obzone.Status.ScaleIn == nil {
+			obzone.Status.ScaleIn = &apitypes.ScaleInStatus{}
+		}
+		updater(obzone.Status.ScaleIn)
+		return m.Client.Status().Update(m.Ctx, obzone)
+	})
+}
+
+func (m *OBZoneManager) clearScaleInStatus() error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obzone, err := m.getOBZone()
+		if err != nil {
+			return err
+		}
+		if obzone.Status.ScaleIn == nil {
+			return nil
+		}
+		obzone.Status.ScaleIn = nil
+		return m.Client.Status().Update(m.Ctx, obzone)
+	})
+}
+
+// reportUnitMigrationProgress counts units left on observers being removed, including those migrating out of them
+func (m *OBZoneManager) reportUnitMigrationProgress(operationManager *operation.OceanbaseOperationManager, scaleInStatus *apitypes.ScaleInStatus) {
+	observerList, err := m.listOBServers()
+	if err != nil {
+		m.Logger.Error(err, "List observers failed")
+		return
+	}
+	removing := make(map[string]struct{}, len(scaleInStatus.OBServers))
+	for _, name := range scaleInStatus.OBServers {
+		removing[name] = struct{}{}
+	}
+	serverIPs := make(map[string]struct{})
+	for _, observer := range observerList.Items {
+		if _, ok := removing[observer.Name]; ok {
+			serverIPs[observer.Status.GetConnectAddr()] = struct{}{}
+		}
+	}
+	units, err := operationManager.GetUnitList()
+	if err != nil {
+		m.Logger.Error(err, "List units failed")
+		return
+	}
+	unitsToMigrate := 0
+	for _, unit := range units {
+		if unit.Zone != m.OBZone.Spec.Topology.Zone {
+			continue
+		}
+		_, onRemoving := serverIPs[unit.SvrIP]
+		_, fromRemoving := serverIPs[unit.MigrateFromSvrIP.String]
+		if onRemoving || (unit.MigrateFromSvrIP.Valid && fromRemoving) {
+			unitsToMigrate++
+		}
+	}
+	if unitsToMigrate == scaleInStatus.UnitsToMigrate {
+		return
+	}
+	m.Logger.Info("Units left to migrate", "count", unitsToMigrate)
+	err = m.updateScaleInStatus(func(s *apitypes.ScaleInStatus) {
+		s.UnitsToMigrate = unitsToMigrate
+	})
+	if err != nil {
+		m.Logger.Error(err, "Update scale in status failed")
+	}
+}
+
 func (m *OBZoneManager) listOBServers() (*v1alpha1.OBServerList, error) {
 	// this label always exists
 	observerList := &v1alpha1.OBServerList{}
@@ -87,3 +168,21 @@ func (m *OBZoneManager) getOBCluster() (*v1alpha1.OBCluster, error) {
 	}
 	return obcluster, nil
 }
+
+func (m *OBZoneManager) isOBZoneActive(operationManager *operation.OceanbaseOperationManager) bool {
+	zone, err := operationManager.GetZone(m.OBZone.Spec.Topology.Zone)
+	if err != nil || zone.Status != obzonestatus.Active {
+		return false
+	}
+	servers, err := operationManager.ListServersOfZone(m.OBZone.Spec.Topology.Zone)
+	if err != nil {
+		return false
+	}
+	for _, server := range servers {
+		if server.Status != observerstatus.Active || server.StopTime > 0 {
+			m.Logger.V(oceanbaseconst.LogLevelTrace).Info("OBServer in obzone is not active", "server", server.Ip)
+			return false
+		}
+	}
+	return true
+}
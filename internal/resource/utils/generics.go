@@ -14,6 +14,8 @@ package utils
 
 import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 )
 
 func GetRef[T any](val T) *T {
@@ -48,3 +50,9 @@ func GetAnnotationField[T client.Object](obj T, key string) (string, bool) {
 	}
 	return "", false
 }
+
+// IsReconcilePaused tells whether reconciliation of the object is paused by annotation
+func IsReconcilePaused[T client.Object](obj T) bool {
+	paused, exist := GetAnnotationField(obj, oceanbaseconst.AnnotationsPauseReconcile)
+	return exist && paused == "true"
+}
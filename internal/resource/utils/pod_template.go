@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// MergePodTemplate merges overrides into pod in the way of strategic merge patch, later overrides take precedence.
+// Labels and annotations set by operator are kept, only new keys are added.
+func MergePodTemplate(pod *corev1.Pod, overrides ...*corev1.PodTemplateSpec) error {
+	for _, override := range overrides {
+		if override == nil {
+			continue
+		}
+		pod.Labels = mergeMissingKeys(pod.Labels, override.Labels)
+		pod.Annotations = mergeMissingKeys(pod.Annotations, override.Annotations)
+
+		original, err := json.Marshal(pod.Spec)
+		if err != nil {
+			return errors.Wrap(err, "Marshal pod spec")
+		}
+		patch, err := marshalWithoutNull(override.Spec)
+		if err != nil {
+			return errors.Wrap(err, "Marshal pod template override")
+		}
+		merged, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodSpec{})
+		if err != nil {
+			return errors.Wrap(err, "Merge pod template override")
+		}
+		spec := corev1.PodSpec{}
+		err = json.Unmarshal(merged, &spec)
+		if err != nil {
+			return errors.Wrap(err, "Unmarshal merged pod spec")
+		}
+		pod.Spec = spec
+	}
+	return nil
+}
+
+// mergeMissingKeys returns a new map so that maps shared with other objects are not modified
+func mergeMissingKeys(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	merged := make(map[string]string, len(dst)+len(src))
+	for k, v := range src {
+		merged[k] = v
+	}
+	for k, v := range dst {
+		merged[k] = v
+	}
+	return merged
+}
+
+// marshalWithoutNull marshals obj and drops null values, which means deleting fields in strategic merge patch.
+// Zero values of typed struct like containers of pod spec are marshaled as null and should be ignored.
+func marshalWithoutNull(obj any) ([]byte, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	err = json.Unmarshal(raw, &value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(dropNull(value))
+}
+
+func dropNull(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+			} else {
+				v[key] = dropNull(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = dropNull(item)
+		}
+	}
+	return value
+}
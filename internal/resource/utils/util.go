@@ -15,21 +15,26 @@ package utils
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/api/v1alpha1"
 	oceanbaseconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
 	secretconst "github.com/oceanbase/ob-operator/internal/const/secret"
@@ -398,3 +403,115 @@ func GetTenantRestoreSource(ctx context.Context, clt client.Client, logger *logr
 
 	return restoreSource, nil
 }
+
+func GetExternalTenantRestoreSource(clt client.Client, ns string, source *v1alpha1.ExternalTenantSourceSpec) (string, error) {
+	standbyRoPwd, err := ReadPassword(clt, ns, source.StandbyROSecret)
+	if err != nil {
+		return "", errors.Wrap(err, "Read standby ro password of external primary tenant")
+	}
+	port := source.Port
+	if port == 0 {
+		port = oceanbaseconst.SqlPort
+	}
+	return fmt.Sprintf("SERVICE=%s:%d USER=%s@%s PASSWORD=%s", source.Address, port, oceanbaseconst.StandbyROUser, source.TenantName, standbyRoPwd), nil
+}
+
+// NeedRefreshMajorCompactionStatus tells whether major compaction status is absent or older than oceanbaseconst.MajorCompactionRefreshSeconds
+func NeedRefreshMajorCompactionStatus(status *apitypes.MajorCompactionStatus) bool {
+	return status == nil || status.RefreshTime == nil || time.Since(status.RefreshTime.Time) >= oceanbaseconst.MajorCompactionRefreshSeconds*time.Second
+}
+
+// GetMajorCompactionStatus builds major compaction status of tenant from CDB_OB_MAJOR_COMPACTION and CDB_OB_ZONE_MAJOR_COMPACTION
+func GetMajorCompactionStatus(con *operation.OceanbaseOperationManager, tenantID int64) (*apitypes.MajorCompactionStatus, error) {
+	compaction, err := con.GetMajorCompactionByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	zoneCompactions, err := con.ListZoneMajorCompactionsByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	status := &apitypes.MajorCompactionStatus{
+		TenantID:           compaction.TenantID,
+		FrozenScn:          compaction.FrozenScn,
+		GlobalBroadcastScn: compaction.GlobalBroadcastScn,
+		LastScn:            compaction.LastScn,
+		LastFinishTime:     compaction.LastFinishTime,
+		Status:             compaction.Status,
+		IsError:            compaction.IsError == "YES",
+		IsSuspended:        compaction.IsSuspended == "YES",
+		Info:               compaction.Info,
+	}
+	now := metav1.Now()
+	status.RefreshTime = &now
+	for _, zoneCompaction := range zoneCompactions {
+		status.Zones = append(status.Zones, apitypes.ZoneMajorCompactionStatus{
+			Zone:           zoneCompaction.Zone,
+			BroadcastScn:   zoneCompaction.BroadcastScn,
+			LastScn:        zoneCompaction.LastScn,
+			LastFinishTime: zoneCompaction.LastFinishTime,
+			Status:         zoneCompaction.Status,
+		})
+	}
+	return status, nil
+}
+
+// GetObjectStorageDestPath appends access credentials in secret to the path of object storage destination
+func GetObjectStorageDestPath(dest *apitypes.BackupDestination, secret *corev1.Secret) string {
+	params := []string{"access_id=" + string(secret.Data["accessId"]), "access_key=" + string(secret.Data["accessKey"])}
+	switch dest.Type {
+	case constants.BackupDestTypeCOS:
+		params = append(params, "appid="+string(secret.Data["appId"]))
+	case constants.BackupDestTypeS3:
+		params = append(params, "s3_region="+string(secret.Data["region"]))
+		if style, ok := secret.Data["addressingStyle"]; ok && len(style) > 0 {
+			params = append(params, "addressing_model="+string(style))
+		}
+	}
+	destPath := dest.Path
+	if endpoint, ok := secret.Data["endpoint"]; ok && len(endpoint) > 0 && !strings.Contains(destPath, "host=") {
+		params = append([]string{"host=" + string(endpoint)}, params...)
+	}
+	if strings.Contains(destPath, "?") {
+		return destPath + "&" + strings.Join(params, "&")
+	}
+	return destPath + "?" + strings.Join(params, "&")
+}
+
+// SleepWithContext pauses for the duration, it returns early with error of ctx once ctx is done
+func SleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ApplyMergePatch applies the JSON merge patch on a copy of original and decodes the result into out,
+// fields absent in patch keep values of original and lists in patch replace the original ones
+func ApplyMergePatch(original any, patch string, out any) error {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return errors.Wrap(err, "marshal original object")
+	}
+	patchedJSON, err := jsonpatch.MergePatch(originalJSON, []byte(patch))
+	if err != nil {
+		return errors.Wrap(err, "apply merge patch")
+	}
+	if err := json.Unmarshal(patchedJSON, out); err != nil {
+		return errors.Wrap(err, "unmarshal patched object")
+	}
+	return nil
+}
+
+// GetCpuCount returns cpu_count that observer with the cpu starts with, it's no less than MinCpuCount
+func GetCpuCount(cpu resource.Quantity) int64 {
+	cpuCount := cpu.Value()
+	if cpuCount < oceanbaseconst.MinCpuCount {
+		cpuCount = oceanbaseconst.MinCpuCount
+	}
+	return cpuCount
+}
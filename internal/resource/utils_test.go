@@ -13,10 +13,15 @@ See the Mulan PSL v2 for more details.
 package resource
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/oceanbase/ob-operator/api/constants"
+	apitypes "github.com/oceanbase/ob-operator/api/types"
 	"github.com/oceanbase/ob-operator/internal/resource/utils"
 )
 
@@ -37,4 +42,87 @@ var _ = Describe("Test utils", func() {
 		Expect(utils.IsZero("a")).Should(BeFalse())
 		Expect(utils.IsZero(&v1.Secret{})).Should(BeFalse())
 	})
+
+	It("MergePodTemplate", func() {
+		labels := map[string]string{"app": "observer"}
+		pod := &v1.Pod{}
+		pod.Labels = labels
+		pod.Spec.Containers = []v1.Container{{
+			Name:    "observer",
+			Image:   "oceanbase-cloud-native",
+			Command: []string{"bash", "-c", "start"},
+		}}
+		pod.Spec.ServiceAccountName = "default"
+		priority := &v1.PodTemplateSpec{}
+		priority.Labels = map[string]string{"app": "override", "team": "db"}
+		priority.Spec.PriorityClassName = "high"
+		priority.Spec.Containers = []v1.Container{{
+			Name: "observer",
+			Env:  []v1.EnvVar{{Name: "TZ", Value: "Asia/Shanghai"}},
+		}, {
+			Name:  "sidecar",
+			Image: "busybox",
+		}}
+		zone := &v1.PodTemplateSpec{}
+		zone.Spec.PriorityClassName = "zone-high"
+		Expect(utils.MergePodTemplate(pod, priority, nil, zone)).Should(Succeed())
+		Expect(pod.Labels).Should(Equal(map[string]string{"app": "observer", "team": "db"}))
+		Expect(labels).Should(HaveLen(1))
+		Expect(pod.Spec.PriorityClassName).Should(Equal("zone-high"))
+		Expect(pod.Spec.ServiceAccountName).Should(Equal("default"))
+		Expect(pod.Spec.Containers).Should(HaveLen(2))
+		Expect(pod.Spec.Containers[0].Command).Should(Equal([]string{"bash", "-c", "start"}))
+		Expect(pod.Spec.Containers[0].Image).Should(Equal("oceanbase-cloud-native"))
+		Expect(pod.Spec.Containers[0].Env).Should(HaveLen(1))
+	})
+
+	It("GetObjectStorageDestPath", func() {
+		secret := &v1.Secret{Data: map[string][]byte{
+			"accessId":  []byte("id"),
+			"accessKey": []byte("key"),
+		}}
+		oss := &apitypes.BackupDestination{Type: constants.BackupDestTypeOSS, Path: "oss://bucket/backup?host=oss.aliyuncs.com"}
+		Expect(utils.GetObjectStorageDestPath(oss, secret)).Should(Equal("oss://bucket/backup?host=oss.aliyuncs.com&access_id=id&access_key=key"))
+
+		secret.Data["appId"] = []byte("1250000000")
+		cos := &apitypes.BackupDestination{Type: constants.BackupDestTypeCOS, Path: "cos://bucket/backup?host=cos.ap-guangzhou.myqcloud.com"}
+		Expect(utils.GetObjectStorageDestPath(cos, secret)).Should(Equal("cos://bucket/backup?host=cos.ap-guangzhou.myqcloud.com&access_id=id&access_key=key&appid=1250000000"))
+
+		secret.Data["region"] = []byte("us-east-1")
+		secret.Data["endpoint"] = []byte("minio.local:9000")
+		secret.Data["addressingStyle"] = []byte("path")
+		s3 := &apitypes.BackupDestination{Type: constants.BackupDestTypeS3, Path: "s3://bucket/backup"}
+		Expect(utils.GetObjectStorageDestPath(s3, secret)).Should(Equal("s3://bucket/backup?host=minio.local:9000&access_id=id&access_key=key&s3_region=us-east-1&addressing_model=path"))
+	})
+
+	It("ApplyMergePatch", func() {
+		original := apitypes.OBZoneTopology{
+			Zone:    "zone1",
+			Replica: 1,
+			NodeSelector: map[string]string{
+				"zone": "z1",
+			},
+			Tolerations: []v1.Toleration{{Key: "a"}, {Key: "b"}},
+		}
+		patched := apitypes.OBZoneTopology{}
+		Expect(utils.ApplyMergePatch(original, `{"replica":3,"nodeSelector":{"disk":"ssd"},"tolerations":[{"key":"c"}]}`, &patched)).Should(Succeed())
+		Expect(patched.Zone).Should(Equal("zone1"))
+		Expect(patched.Replica).Should(Equal(3))
+		Expect(patched.NodeSelector).Should(Equal(map[string]string{"zone": "z1", "disk": "ssd"}))
+		Expect(patched.Tolerations).Should(Equal([]v1.Toleration{{Key: "c"}}))
+		Expect(original.Replica).Should(Equal(1))
+
+		Expect(utils.ApplyMergePatch(original, `{"replica":`, &patched)).ShouldNot(Succeed())
+	})
+
+	It("NeedRefreshMajorCompactionStatus", func() {
+		Expect(utils.NeedRefreshMajorCompactionStatus(nil)).Should(BeTrue())
+		status := &apitypes.MajorCompactionStatus{}
+		Expect(utils.NeedRefreshMajorCompactionStatus(status)).Should(BeTrue())
+		now := metav1.Now()
+		status.RefreshTime = &now
+		Expect(utils.NeedRefreshMajorCompactionStatus(status)).Should(BeFalse())
+		status.RefreshTime = &metav1.Time{Time: now.Add(-2 * time.Minute)}
+		Expect(utils.NeedRefreshMajorCompactionStatus(status)).Should(BeTrue())
+	})
 })
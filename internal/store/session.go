@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+// SessionStore keeps sessions of dashboard users revoked before they expire.
+// Sessions themselves are carried by signed cookies, so that any replica sharing the session secret accepts them.
+type SessionStore interface {
+	// Revoke revokes the session until its expiration, after which the session is rejected anyway
+	Revoke(ctx context.Context, sessionID string, expiration time.Time) error
+	// IsRevoked tells whether the session has been revoked
+	IsRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+var sessionStore SessionStore
+var sessionStoreOnce sync.Once
+
+// GetSessionStore returns the store shared by replicas in the configmap named by env SESSION_REVOCATION_CONFIGMAP
+// in USER_NAMESPACE, revocations are kept in memory of the process if it is not set.
+func GetSessionStore() SessionStore {
+	sessionStoreOnce.Do(func() {
+		name := os.Getenv("SESSION_REVOCATION_CONFIGMAP")
+		ns := os.Getenv("USER_NAMESPACE")
+		if name != "" && ns != "" {
+			sessionStore = NewConfigMapSessionStore(client.GetClient().ClientSet, ns, name, DefaultSessionSyncInterval)
+		} else {
+			sessionStore = NewMemorySessionStore()
+		}
+	})
+	return sessionStore
+}
+
+// NewSessionID generates a random ID for a new session
+func NewSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+type memorySessionStore struct {
+	mu sync.Mutex
+	// session ID -> unix time of expiration
+	revoked map[string]int64
+}
+
+// NewMemorySessionStore returns a session store in memory, which only works for a single replica
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		revoked: make(map[string]int64),
+	}
+}
+
+func (s *memorySessionStore) Revoke(_ context.Context, sessionID string, expiration time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pruneExpired(s.revoked)
+	s.revoked[sessionID] = expiration.Unix()
+	return nil
+}
+
+func (s *memorySessionStore) IsRevoked(_ context.Context, sessionID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, exist := s.revoked[sessionID]
+	return exist, nil
+}
+
+func pruneExpired(revoked map[string]int64) {
+	now := time.Now().Unix()
+	for id, expiration := range revoked {
+		if expiration < now {
+			delete(revoked, id)
+		}
+	}
+}
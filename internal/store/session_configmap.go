@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package store
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultSessionSyncInterval is the longest time for a replica to notice sessions revoked by other replicas
+const DefaultSessionSyncInterval = 5 * time.Second
+
+type configMapSessionStore struct {
+	clientset    kubernetes.Interface
+	namespace    string
+	name         string
+	syncInterval time.Duration
+
+	mu       sync.Mutex
+	revoked  map[string]int64
+	syncedAt time.Time
+}
+
+// NewConfigMapSessionStore returns a session store keeping revoked sessions in the configmap, data of which are
+// session IDs mapped to unix time of expiration. Revocations are cached and synced at most once in syncInterval.
+func NewConfigMapSessionStore(clientset kubernetes.Interface, namespace, name string, syncInterval time.Duration) SessionStore {
+	return &configMapSessionStore{
+		clientset:    clientset,
+		namespace:    namespace,
+		name:         name,
+		syncInterval: syncInterval,
+		revoked:      make(map[string]int64),
+	}
+}
+
+func (s *configMapSessionStore) Revoke(ctx context.Context, sessionID string, expiration time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var revoked map[string]int64
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return kubeerrors.IsConflict(err) || kubeerrors.IsAlreadyExists(err)
+	}, func() error {
+		cm, err := s.clientset.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		notFound := kubeerrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		if notFound {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.name,
+					Namespace: s.namespace,
+				},
+			}
+		}
+		revoked = parseRevoked(cm.Data)
+		pruneExpired(revoked)
+		revoked[sessionID] = expiration.Unix()
+		cm.Data = make(map[string]string, len(revoked))
+		for id, exp := range revoked {
+			cm.Data[id] = strconv.FormatInt(exp, 10)
+		}
+		if notFound {
+			_, err = s.clientset.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+		} else {
+			_, err = s.clientset.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		}
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "revoke session")
+	}
+	s.revoked = revoked
+	s.syncedAt = time.Now()
+	return nil
+}
+
+func (s *configMapSessionStore) IsRevoked(ctx context.Context, sessionID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.syncedAt) >= s.syncInterval {
+		cm, err := s.clientset.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		switch {
+		case kubeerrors.IsNotFound(err):
+			s.revoked = make(map[string]int64)
+		case err != nil:
+			return false, errors.Wrap(err, "get revoked sessions")
+		default:
+			s.revoked = parseRevoked(cm.Data)
+		}
+		s.syncedAt = time.Now()
+	}
+	_, exist := s.revoked[sessionID]
+	return exist, nil
+}
+
+func parseRevoked(data map[string]string) map[string]int64 {
+	revoked := make(map[string]int64, len(data))
+	for id, raw := range data {
+		expiration, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		revoked[id] = expiration
+	}
+	return revoked
+}
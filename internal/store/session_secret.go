@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/oceanbase/ob-operator/pkg/k8s/client"
+)
+
+const (
+	sessionSecretKey    = "secret"
+	sessionSecretLength = 32
+)
+
+// SessionsShared tells whether sessions are signed with a secret shared by replicas, which is set by env
+// SESSION_SECRET or kept in the secret named by env SESSION_SECRET_NAME in USER_NAMESPACE.
+// Otherwise sessions are only accepted by the process where users logged in.
+func SessionsShared() bool {
+	return os.Getenv("SESSION_SECRET") != "" || (os.Getenv("SESSION_SECRET_NAME") != "" && os.Getenv("USER_NAMESPACE") != "")
+}
+
+// GetSessionSecret returns the secret signing sessions, a random one is generated for the process if it is not shared
+func GetSessionSecret(ctx context.Context) ([]byte, error) {
+	if secret := os.Getenv("SESSION_SECRET"); secret != "" {
+		return []byte(secret), nil
+	}
+	if SessionsShared() {
+		return getOrCreateSessionSecret(ctx, client.GetClient().ClientSet, os.Getenv("USER_NAMESPACE"), os.Getenv("SESSION_SECRET_NAME"))
+	}
+	return randomSessionSecret()
+}
+
+// getOrCreateSessionSecret reads the session secret, which is generated by the replica starting first
+func getOrCreateSessionSecret(ctx context.Context, clientset kubernetes.Interface, namespace, name string) ([]byte, error) {
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		if raw := secret.Data[sessionSecretKey]; len(raw) > 0 {
+			return raw, nil
+		}
+		return nil, errors.Errorf("key %s not found in secret %s", sessionSecretKey, name)
+	}
+	if !kubeerrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "get session secret")
+	}
+	raw, err := randomSessionSecret()
+	if err != nil {
+		return nil, err
+	}
+	_, err = clientset.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{sessionSecretKey: raw},
+	}, metav1.CreateOptions{})
+	if kubeerrors.IsAlreadyExists(err) {
+		// created by another replica at the same time
+		return getOrCreateSessionSecret(ctx, clientset, namespace, name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "create session secret")
+	}
+	return raw, nil
+}
+
+func randomSessionSecret() ([]byte, error) {
+	raw := make([]byte, sessionSecretLength)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, errors.Wrap(err, "generate session secret")
+	}
+	return raw, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package store
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("SessionStore", func() {
+	It("Revokes sessions in memory", func() {
+		s := NewMemorySessionStore()
+		Expect(s.Revoke(context.TODO(), "expired", time.Now().Add(-time.Minute))).To(Succeed())
+		Expect(s.Revoke(context.TODO(), "session", time.Now().Add(time.Hour))).To(Succeed())
+		revoked, err := s.IsRevoked(context.TODO(), "session")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeTrue())
+		revoked, err = s.IsRevoked(context.TODO(), "other")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeFalse())
+		Expect(s.(*memorySessionStore).revoked).NotTo(HaveKey("expired"))
+	})
+
+	It("Shares revoked sessions among replicas with configmap", func() {
+		clientset := fake.NewSimpleClientset()
+		replica1 := NewConfigMapSessionStore(clientset, "default", "revoked-sessions", 0)
+		replica2 := NewConfigMapSessionStore(clientset, "default", "revoked-sessions", 0)
+
+		revoked, err := replica2.IsRevoked(context.TODO(), "session")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeFalse())
+
+		Expect(replica1.Revoke(context.TODO(), "expired", time.Now().Add(-time.Minute))).To(Succeed())
+		Expect(replica1.Revoke(context.TODO(), "session", time.Now().Add(time.Hour))).To(Succeed())
+		revoked, err = replica2.IsRevoked(context.TODO(), "session")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeTrue())
+		revoked, err = replica2.IsRevoked(context.TODO(), "other")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeFalse())
+
+		Expect(replica2.Revoke(context.TODO(), "session2", time.Now().Add(time.Hour))).To(Succeed())
+		cm, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "revoked-sessions", metav1.GetOptions{})
+		Expect(err).To(BeNil())
+		Expect(cm.Data).To(HaveLen(2))
+		Expect(cm.Data).To(HaveKey("session"))
+		Expect(cm.Data).To(HaveKey("session2"))
+	})
+
+	It("Caches revoked sessions within sync interval", func() {
+		clientset := fake.NewSimpleClientset()
+		replica1 := NewConfigMapSessionStore(clientset, "default", "revoked-sessions", 0)
+		replica2 := NewConfigMapSessionStore(clientset, "default", "revoked-sessions", time.Hour)
+
+		revoked, err := replica2.IsRevoked(context.TODO(), "session")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeFalse())
+		Expect(replica1.Revoke(context.TODO(), "session", time.Now().Add(time.Hour))).To(Succeed())
+		revoked, err = replica2.IsRevoked(context.TODO(), "session")
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeFalse())
+	})
+
+	It("Generates session secret once for replicas", func() {
+		clientset := fake.NewSimpleClientset()
+		secret1, err := getOrCreateSessionSecret(context.TODO(), clientset, "default", "session-secret")
+		Expect(err).To(BeNil())
+		Expect(secret1).To(HaveLen(sessionSecretLength))
+		secret2, err := getOrCreateSessionSecret(context.TODO(), clientset, "default", "session-secret")
+		Expect(err).To(BeNil())
+		Expect(secret2).To(Equal(secret1))
+	})
+
+	It("Generates random session secret if it is not shared", func() {
+		Expect(os.Unsetenv("SESSION_SECRET")).To(Succeed())
+		Expect(os.Unsetenv("SESSION_SECRET_NAME")).To(Succeed())
+		Expect(SessionsShared()).To(BeFalse())
+		secret1, err := GetSessionSecret(context.TODO())
+		Expect(err).To(BeNil())
+		secret2, err := GetSessionSecret(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(secret1).NotTo(Equal(secret2))
+
+		Expect(os.Setenv("SESSION_SECRET", "shared")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "SESSION_SECRET")
+		Expect(SessionsShared()).To(BeTrue())
+		secret, err := GetSessionSecret(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(string(secret)).To(Equal("shared"))
+	})
+})
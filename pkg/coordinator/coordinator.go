@@ -17,6 +17,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	obconst "github.com/oceanbase/ob-operator/internal/const/oceanbase"
@@ -36,6 +38,11 @@ const (
 type Coordinator struct {
 	Manager ResourceManager
 	Logger  *logr.Logger
+
+	kind     string
+	owner    types.NamespacedName
+	metrics  *metricsTarget
+	priority tasktypes.TaskPriority
 }
 
 func NewCoordinator(m ResourceManager, logger *logr.Logger) *Coordinator {
@@ -45,6 +52,45 @@ func NewCoordinator(m ResourceManager, logger *logr.Logger) *Coordinator {
 	}
 }
 
+// WithOwner identifies the resource by kind and key, so that tasks submitted for it are limited per resource
+// and abandoned when it's being deleted
+func (c *Coordinator) WithOwner(kind string, key types.NamespacedName) *Coordinator {
+	c.kind = kind
+	c.owner = key
+	return c
+}
+
+// WithMetrics enables exporting metrics about reconciliation of the resource identified by kind and key, it implies WithOwner
+func (c *Coordinator) WithMetrics(kind string, key types.NamespacedName) *Coordinator {
+	c.WithOwner(kind, key)
+	c.metrics = &metricsTarget{
+		kind:      kind,
+		namespace: key.Namespace,
+		name:      key.Name,
+	}
+	return c
+}
+
+// WithPriority sets priority of tasks submitted for the resource, tasks are submitted with normal priority by default
+func (c *Coordinator) WithPriority(p tasktypes.TaskPriority) *Coordinator {
+	c.priority = p
+	return c
+}
+
+// taskMeta describes tasks submitted for the resource, the owner is set only if the resource is identified by WithOwner.
+// Tasks submitted before the resource is being deleted could be abandoned on deletion.
+func (c *Coordinator) taskMeta() tasktypes.TaskMeta {
+	meta := tasktypes.TaskMeta{
+		Priority:    c.priority,
+		Abandonable: !c.Manager.IsDeleting(),
+	}
+	if c.kind != "" {
+		meta.Kind = c.kind
+		meta.Owner = c.owner.String()
+	}
+	return meta
+}
+
 // 1. If the returned error is non-nil, the Result is ignored and the request will be
 // requeued using exponential backoff. The only exception is if the error is a
 // TerminalError in which case no requeuing happens.
@@ -60,10 +106,28 @@ func (c *Coordinator) Coordinate() (ctrl.Result, error) {
 	}
 	var f *tasktypes.TaskFlow
 	var err error
+	flowFinished := false
 	beforeStatus := c.Manager.GetStatus()
 	if c.Manager.IsNewResource() {
 		c.Manager.InitStatus()
+	} else if c.Manager.IsPaused() && !c.Manager.IsDeleting() {
+		// deletion of resource is never paused, otherwise it's blocked by finalizers
+		f, err = c.pauseTaskFlow()
+		if err != nil {
+			return result, errors.Wrap(err, "Pause task flow")
+		}
+		if f == nil || f.OperationContext.TaskStatus != taskstatus.Running {
+			result.RequeueAfter = NormalRequeueDuration
+		}
 	} else {
+		if c.Manager.SetPaused(false) {
+			c.Logger.Info("Reconciliation is resumed")
+		}
+		if c.Manager.IsDeleting() && c.kind != "" {
+			// stop waiting for tasks which are meaningless for a deleting resource, they result in failure
+			meta := c.taskMeta()
+			task.GetTaskManager().Abandon(meta.Kind, meta.Owner)
+		}
 		f, err = c.Manager.GetTaskFlow()
 		if err != nil {
 			return result, errors.Wrap(err, "Get task flow")
@@ -71,10 +135,14 @@ func (c *Coordinator) Coordinate() (ctrl.Result, error) {
 			// No need to execute task flow
 			result.RequeueAfter = NormalRequeueDuration
 		} else {
+			if f.OperationContext.StartTime == nil {
+				now := metav1.Now()
+				f.OperationContext.StartTime = &now
+			}
 			c.Logger.V(obconst.LogLevelDebug).Info("Set operation context", "operation context", f.OperationContext)
 			c.Manager.SetOperationContext(f.OperationContext)
 			// execution errors reflects by task status
-			c.executeTaskFlow(f)
+			flowFinished = c.executeTaskFlow(f)
 			// if task status is `failed`, requeue after 2 ^ min(retryCount, threshold) * 500ms.
 			// maximum backoff time is about 2 hrs with 14 as threshold.
 			if f.OperationContext.OnFailure.RetryCount > 0 && f.OperationContext.TaskStatus == taskstatus.Failed {
@@ -98,6 +166,14 @@ func (c *Coordinator) Coordinate() (ctrl.Result, error) {
 	if err != nil {
 		c.Logger.Error(err, "Failed to update status")
 	}
+	if c.metrics != nil {
+		c.metrics.recordStatus(c.Manager.GetStatus())
+		if f != nil && !flowFinished {
+			c.metrics.recordOperation(f.OperationContext)
+		} else {
+			c.metrics.recordOperation(nil)
+		}
+	}
 	// When status changes(e.g. from running to other status), set a shorter `requeue after` to speed up processing.
 	if c.Manager.GetStatus() != beforeStatus {
 		result.RequeueAfter = ExecutionRequeueDuration
@@ -106,15 +182,34 @@ func (c *Coordinator) Coordinate() (ctrl.Result, error) {
 	return result, err
 }
 
-func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) {
+// pauseTaskFlow keeps the resource paused, no task is started and the unfinished flow stays where it is.
+// The running task is still watched until it finishes, so that the flow continues from the next task on resume.
+func (c *Coordinator) pauseTaskFlow() (*tasktypes.TaskFlow, error) {
+	if c.Manager.SetPaused(true) {
+		c.Logger.Info("Reconciliation is paused")
+	}
+	f, err := c.Manager.GetTaskFlow()
+	if err != nil || f == nil {
+		return nil, err
+	}
+	if f.OperationContext.TaskStatus == taskstatus.Running {
+		c.executeTaskFlow(f)
+		c.Manager.SetOperationContext(f.OperationContext)
+	}
+	return f, nil
+}
+
+// executeTaskFlow executes the task flow for one step, returns whether the task flow is finished
+func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) bool {
 	switch f.OperationContext.TaskStatus {
 	case taskstatus.Empty:
 		if !f.HasNext() {
 			// clean task info sets resource status to normal, and context to nil
 			c.Manager.ClearTaskInfo()
-		} else {
-			f.NextTask()
+			c.recordFlowFinished(f, flowResultSuccessful)
+			return true
 		}
+		f.NextTask()
 	case taskstatus.Pending:
 		// run the current task while set task status to running
 		taskFunc, err := c.Manager.GetTaskFunc(f.OperationContext.Task)
@@ -123,8 +218,10 @@ func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) {
 			c.Manager.PrintErrEvent(err)
 		} else {
 			c.Logger.V(obconst.LogLevelDebug).Info("Successfully get task func " + f.OperationContext.Task.Display())
-			taskId := task.GetTaskManager().Submit(taskFunc)
-			c.Logger.V(obconst.LogLevelDebug).Info("Successfully submit task", "taskId", taskId)
+			meta := c.taskMeta()
+			meta.Timeout = c.Manager.GetTaskTimeout(f.OperationContext.Task)
+			taskId := task.GetTaskManager().SubmitTask(taskFunc, meta)
+			c.Logger.V(obconst.LogLevelDebug).Info("Successfully submit task", "taskId", taskId, "timeout", meta.Timeout)
 			f.OperationContext.TaskId = taskId
 			f.OperationContext.TaskStatus = taskstatus.Running
 		}
@@ -136,10 +233,23 @@ func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) {
 			c.Logger.Error(err, "Get task result got error", "task id", f.OperationContext.TaskId)
 			c.Manager.PrintErrEvent(err)
 			f.OperationContext.TaskStatus = taskstatus.Failed
+			f.OperationContext.AppendTaskRecord(tasktypes.TaskRecord{
+				Name:   f.OperationContext.Task,
+				Status: taskstatus.Failed,
+				Error:  err.Error(),
+			})
 		} else if taskResult != nil {
 			c.Logger.V(obconst.LogLevelDebug).Info("Task finished", "task id", f.OperationContext.TaskId, "task result", taskResult)
 			f.OperationContext.TaskStatus = taskResult.Status
+			f.OperationContext.AppendTaskRecord(newTaskRecord(f.OperationContext.Task, taskResult))
+			if c.metrics != nil {
+				c.metrics.recordTaskResult(f.OperationContext.Task, taskResult)
+			}
 			if taskResult.Error != nil {
+				if errors.Is(taskResult.Error, tasktypes.ErrTaskTimeout) {
+					// timed out task fails as usual, failure rule of the flow decides what to do next
+					c.Logger.Info("Task timed out", "task", f.OperationContext.Task, "task id", f.OperationContext.TaskId)
+				}
 				c.Manager.PrintErrEvent(taskResult.Error)
 			}
 		}
@@ -148,9 +258,10 @@ func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) {
 		// clean operation context and set status to target status
 		if !f.HasNext() {
 			c.Manager.FinishTask()
-		} else {
-			f.NextTask()
+			c.recordFlowFinished(f, flowResultSuccessful)
+			return true
 		}
+		f.NextTask()
 	case taskstatus.Failed:
 		switch f.OperationContext.OnFailure.Strategy {
 		case strategy.RetryFromCurrent, strategy.StartOver:
@@ -162,15 +273,33 @@ func (c *Coordinator) executeTaskFlow(f *tasktypes.TaskFlow) {
 			if f.OperationContext.OnFailure.RetryCount > maxRetry {
 				c.Logger.Info("Retry count exceeds limit, archive the resource")
 				c.Manager.ArchiveResource()
+				c.recordFlowFinished(f, flowResultFailed)
+				return true
 			} else {
+				statusBefore := c.Manager.GetStatus()
 				c.Manager.HandleFailure()
+				if c.Manager.GetStatus() != statusBefore {
+					// the flow is dropped when trying again from another status, record the failed run before it's lost
+					c.recordFlowFinished(f, flowResultFailed)
+					return true
+				}
 				f.OperationContext.OnFailure.RetryCount++
 			}
 		default:
 			c.Manager.HandleFailure()
+			c.recordFlowFinished(f, flowResultFailed)
+			return true
 		}
 	}
 	// Coordinate finished
+	return false
+}
+
+func (c *Coordinator) recordFlowFinished(f *tasktypes.TaskFlow, result string) {
+	if c.metrics != nil {
+		c.metrics.recordFlowFinished(f.OperationContext.Name, result)
+	}
+	c.Manager.AddFlowRecord(newFlowRecord(f.OperationContext, tasktypes.TaskStatus(result)))
 }
 
 func (c *Coordinator) cleanTaskResultMap(f *tasktypes.TaskFlow) error {
@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package coordinator
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
+	taskstatus "github.com/oceanbase/ob-operator/pkg/task/const/status"
+	"github.com/oceanbase/ob-operator/pkg/task/const/strategy"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+// fakeManager implements the methods of ResourceManager used in tests, others panic
+type fakeManager struct {
+	ResourceManager
+	deleting bool
+	status   string
+	// status to try again from on failure, the flow is dropped if it differs from the current one
+	nextTryStatus string
+	records       []tasktypes.FlowRecord
+	paused        bool
+	// paused state shown in status
+	pausedInStatus bool
+	flowRequested  bool
+}
+
+func (m *fakeManager) IsNewResource() bool {
+	return false
+}
+
+func (m *fakeManager) IsPaused() bool {
+	return m.paused
+}
+
+func (m *fakeManager) SetPaused(paused bool) bool {
+	if m.pausedInStatus == paused {
+		return false
+	}
+	m.pausedInStatus = paused
+	return true
+}
+
+func (m *fakeManager) GetTaskFlow() (*tasktypes.TaskFlow, error) {
+	m.flowRequested = true
+	return nil, nil
+}
+
+func (m *fakeManager) UpdateStatus() error {
+	return nil
+}
+
+func (m *fakeManager) IsDeleting() bool {
+	return m.deleting
+}
+
+func (m *fakeManager) GetStatus() string {
+	return m.status
+}
+
+func (m *fakeManager) HandleFailure() {
+	m.status = m.nextTryStatus
+}
+
+func (m *fakeManager) PrintErrEvent(error) {}
+
+func (m *fakeManager) AddFlowRecord(record *tasktypes.FlowRecord) {
+	m.records = tasktypes.AppendFlowRecord(m.records, record)
+}
+
+var _ = Describe("Test Coordinator", func() {
+	logger := logr.Discard()
+	key := types.NamespacedName{Namespace: "ns", Name: "c1"}
+
+	It("Identify owner of tasks without metrics", func() {
+		c := NewCoordinator(&fakeManager{}, &logger).WithOwner("OBCluster", key).WithPriority(priority.High)
+		Expect(c.metrics).To(BeNil())
+		meta := c.taskMeta()
+		Expect(meta.Kind).To(Equal("OBCluster"))
+		Expect(meta.Owner).To(Equal("ns/c1"))
+		Expect(meta.Priority).To(Equal(priority.High))
+		Expect(meta.Abandonable).To(BeTrue())
+	})
+
+	It("Identify owner of tasks with metrics", func() {
+		c := NewCoordinator(&fakeManager{deleting: true}, &logger).WithMetrics("OBCluster", key)
+		meta := c.taskMeta()
+		Expect(meta.Kind).To(Equal("OBCluster"))
+		Expect(meta.Owner).To(Equal("ns/c1"))
+		Expect(meta.Abandonable).To(BeFalse())
+	})
+
+	It("Leave owner of tasks empty if not identified", func() {
+		meta := NewCoordinator(&fakeManager{}, &logger).taskMeta()
+		Expect(meta.Kind).To(BeEmpty())
+		Expect(meta.Owner).To(BeEmpty())
+	})
+
+	Describe("Handle failed flow", func() {
+		newFailedFlow := func() *tasktypes.TaskFlow {
+			return &tasktypes.TaskFlow{
+				OperationContext: &tasktypes.OperationContext{
+					Name:       "enter maintenance",
+					Tasks:      []tasktypes.TaskName{"stop server"},
+					Task:       "stop server",
+					TaskStatus: taskstatus.Failed,
+					OnFailure: tasktypes.FailureRule{
+						Strategy:      strategy.StartOver,
+						NextTryStatus: "exit maintenance",
+					},
+					TaskRecords: []tasktypes.TaskRecord{{Name: "stop server", Status: taskstatus.Failed, Error: "timeout"}},
+				},
+			}
+		}
+
+		It("Record the flow started over from another status", func() {
+			m := &fakeManager{status: "enter maintenance", nextTryStatus: "exit maintenance"}
+			c := NewCoordinator(m, &logger)
+			Expect(c.executeTaskFlow(newFailedFlow())).To(BeTrue())
+			Expect(m.status).To(Equal("exit maintenance"))
+			Expect(m.records).To(HaveLen(1))
+			Expect(m.records[0].Name).To(BeEquivalentTo("enter maintenance"))
+			Expect(m.records[0].Status).To(BeEquivalentTo(flowResultFailed))
+			Expect(m.records[0].LastError).To(Equal("timeout"))
+		})
+
+		It("Keep the flow started over from the same status", func() {
+			m := &fakeManager{status: "enter maintenance", nextTryStatus: "enter maintenance"}
+			c := NewCoordinator(m, &logger)
+			f := newFailedFlow()
+			Expect(c.executeTaskFlow(f)).To(BeFalse())
+			Expect(m.records).To(BeEmpty())
+			Expect(f.OperationContext.OnFailure.RetryCount).To(Equal(1))
+		})
+	})
+
+	It("Keep status of paused resource and show it's paused", func() {
+		m := &fakeManager{status: "running", paused: true}
+		c := NewCoordinator(m, &logger)
+		result, err := c.Coordinate()
+		Expect(err).To(BeNil())
+		Expect(result.RequeueAfter).To(Equal(NormalRequeueDuration))
+		Expect(m.status).To(Equal("running"))
+		Expect(m.pausedInStatus).To(BeTrue())
+
+		m.paused = false
+		m.flowRequested = false
+		_, err = c.Coordinate()
+		Expect(err).To(BeNil())
+		Expect(m.status).To(Equal("running"))
+		Expect(m.pausedInStatus).To(BeFalse())
+		Expect(m.flowRequested).To(BeTrue())
+	})
+})
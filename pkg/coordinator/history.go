@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package coordinator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+func newTaskRecord(name tasktypes.TaskName, result *tasktypes.TaskResult) tasktypes.TaskRecord {
+	record := tasktypes.TaskRecord{
+		Name:     name,
+		Status:   result.Status,
+		Duration: metav1.Duration{Duration: result.Duration},
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+	}
+	return record
+}
+
+// newFlowRecord summarizes the finished flow, the last error is taken from the latest failed task
+func newFlowRecord(c *tasktypes.OperationContext, status tasktypes.TaskStatus) *tasktypes.FlowRecord {
+	record := &tasktypes.FlowRecord{
+		Name:    c.Name,
+		Status:  status,
+		EndTime: metav1.Now(),
+	}
+	if c.StartTime != nil {
+		record.StartTime = *c.StartTime
+	} else {
+		record.StartTime = record.EndTime
+	}
+	if len(c.TaskRecords) > 0 {
+		record.Tasks = make([]tasktypes.TaskRecord, len(c.TaskRecords))
+		copy(record.Tasks, c.TaskRecords)
+	}
+	for i := len(c.TaskRecords) - 1; i >= 0; i-- {
+		if c.TaskRecords[i].Error != "" {
+			record.LastError = c.TaskRecords[i].Error
+			break
+		}
+	}
+	return record
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package coordinator
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	taskstatus "github.com/oceanbase/ob-operator/pkg/task/const/status"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+var _ = Describe("Test History", func() {
+	It("Summarize finished flow", func() {
+		startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		c := &tasktypes.OperationContext{
+			Name:      "upgrade",
+			StartTime: &startTime,
+			TaskRecords: []tasktypes.TaskRecord{
+				{Name: "t1", Status: taskstatus.Failed, Error: "first"},
+				{Name: "t1", Status: taskstatus.Failed, Error: "second"},
+				{Name: "t1", Status: taskstatus.Successful},
+			},
+		}
+		record := newFlowRecord(c, taskstatus.Successful)
+		Expect(record.Name).To(BeEquivalentTo("upgrade"))
+		Expect(record.Status).To(BeEquivalentTo(taskstatus.Successful))
+		Expect(record.StartTime).To(Equal(startTime))
+		Expect(record.EndTime.After(startTime.Time)).To(BeTrue())
+		Expect(record.LastError).To(Equal("second"))
+		Expect(record.Tasks).To(Equal(c.TaskRecords))
+
+		By("Records are copied from the operation context")
+		c.TaskRecords[0].Error = "changed"
+		Expect(record.Tasks[0].Error).To(Equal("first"))
+
+		By("Flow without start time")
+		record = newFlowRecord(&tasktypes.OperationContext{Name: "empty"}, taskstatus.Failed)
+		Expect(record.StartTime).To(Equal(record.EndTime))
+		Expect(record.Tasks).To(BeNil())
+		Expect(record.LastError).To(BeEmpty())
+	})
+
+	It("Replace record of the same run", func() {
+		startTime := metav1.Now()
+		record := &tasktypes.FlowRecord{Name: "f1", Status: taskstatus.Failed, StartTime: startTime}
+		history := tasktypes.AppendFlowRecord(nil, record)
+		history = tasktypes.AppendFlowRecord(history, record.DeepCopy())
+		Expect(history).To(HaveLen(1))
+
+		By("The same run changes")
+		changed := record.DeepCopy()
+		changed.Tasks = []tasktypes.TaskRecord{{Name: "t1", Status: taskstatus.Failed}}
+		history = tasktypes.AppendFlowRecord(history, changed)
+		Expect(history).To(HaveLen(1))
+		Expect(history[0].Tasks).To(HaveLen(1))
+
+		By("Another run of the same flow")
+		another := record.DeepCopy()
+		another.StartTime = metav1.NewTime(startTime.Add(time.Second))
+		history = tasktypes.AppendFlowRecord(history, another)
+		Expect(history).To(HaveLen(2))
+	})
+
+	It("Keep the latest records only", func() {
+		var history []tasktypes.FlowRecord
+		startTime := metav1.Now()
+		for i := 0; i < tasktypes.FlowHistoryLimit+3; i++ {
+			history = tasktypes.AppendFlowRecord(history, &tasktypes.FlowRecord{
+				Name:      tasktypes.FlowName(fmt.Sprintf("f%d", i)),
+				StartTime: startTime,
+			})
+		}
+		Expect(history).To(HaveLen(tasktypes.FlowHistoryLimit))
+		Expect(history[0].Name).To(BeEquivalentTo("f3"))
+		Expect(history[tasktypes.FlowHistoryLimit-1].Name).To(BeEquivalentTo(fmt.Sprintf("f%d", tasktypes.FlowHistoryLimit+2)))
+	})
+
+	It("Keep the latest task records of a flow only", func() {
+		c := &tasktypes.OperationContext{}
+		for i := 0; i < tasktypes.FlowTaskRecordLimit+1; i++ {
+			c.AppendTaskRecord(tasktypes.TaskRecord{Name: tasktypes.TaskName(fmt.Sprintf("t%d", i))})
+		}
+		Expect(c.TaskRecords).To(HaveLen(tasktypes.FlowTaskRecordLimit))
+		Expect(c.TaskRecords[0].Name).To(BeEquivalentTo("t1"))
+	})
+})
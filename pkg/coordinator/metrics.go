@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package coordinator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+const metricsNamespace = "ob_operator"
+
+var (
+	resourceStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "resource_status",
+		Help:      "Current status of resource, value is always 1",
+	}, []string{"kind", "namespace", "name", "status"})
+
+	resourceOperation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "resource_operation",
+		Help:      "Task flow and task that resource is currently executing, value is always 1",
+	}, []string{"kind", "namespace", "name", "flow", "task"})
+
+	resourceRetryCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "resource_retry_count",
+		Help:      "Retry count of the task flow that resource is currently executing",
+	}, []string{"kind", "namespace", "name", "flow"})
+
+	taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "task_duration_seconds",
+		Help:      "Duration of tasks executed by task manager",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+	}, []string{"kind", "namespace", "name", "task", "result"})
+
+	taskWaitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "task_wait_duration_seconds",
+		Help:      "Duration of tasks waiting in queue of task manager",
+		Buckets:   []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 600},
+	}, []string{"kind", "namespace", "name", "task"})
+
+	flowTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "flow_total",
+		Help:      "Count of finished task flows",
+	}, []string{"kind", "namespace", "name", "flow", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(resourceStatus, resourceOperation, resourceRetryCount, taskDuration, taskWaitDuration, flowTotal)
+}
+
+const (
+	flowResultSuccessful = "successful"
+	flowResultFailed     = "failed"
+)
+
+type metricsTarget struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (t *metricsTarget) labels() prometheus.Labels {
+	return prometheus.Labels{
+		"kind":      t.kind,
+		"namespace": t.namespace,
+		"name":      t.name,
+	}
+}
+
+func (t *metricsTarget) recordStatus(status string) {
+	resourceStatus.DeletePartialMatch(t.labels())
+	resourceStatus.WithLabelValues(t.kind, t.namespace, t.name, status).Set(1)
+}
+
+func (t *metricsTarget) recordOperation(ctx *tasktypes.OperationContext) {
+	resourceOperation.DeletePartialMatch(t.labels())
+	resourceRetryCount.DeletePartialMatch(t.labels())
+	if ctx == nil {
+		return
+	}
+	resourceOperation.WithLabelValues(t.kind, t.namespace, t.name, string(ctx.Name), string(ctx.Task)).Set(1)
+	resourceRetryCount.WithLabelValues(t.kind, t.namespace, t.name, string(ctx.Name)).Set(float64(ctx.OnFailure.RetryCount))
+}
+
+func (t *metricsTarget) recordTaskResult(taskName tasktypes.TaskName, result *tasktypes.TaskResult) {
+	taskDuration.WithLabelValues(t.kind, t.namespace, t.name, string(taskName), string(result.Status)).Observe(result.Duration.Seconds())
+	taskWaitDuration.WithLabelValues(t.kind, t.namespace, t.name, string(taskName)).Observe(result.WaitDuration.Seconds())
+}
+
+func (t *metricsTarget) recordFlowFinished(flowName tasktypes.FlowName, result string) {
+	flowTotal.WithLabelValues(t.kind, t.namespace, t.name, string(flowName), result).Inc()
+}
+
+// CleanMetrics removes metrics of the resource, it should be called after the resource is deleted
+func CleanMetrics(kind string, key types.NamespacedName) {
+	t := &metricsTarget{kind: kind, namespace: key.Namespace, name: key.Name}
+	resourceStatus.DeletePartialMatch(t.labels())
+	resourceOperation.DeletePartialMatch(t.labels())
+	resourceRetryCount.DeletePartialMatch(t.labels())
+	taskDuration.DeletePartialMatch(t.labels())
+	taskWaitDuration.DeletePartialMatch(t.labels())
+	flowTotal.DeletePartialMatch(t.labels())
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package coordinator
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+
+	taskstatus "github.com/oceanbase/ob-operator/pkg/task/const/status"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+var _ = Describe("Test Metrics", Serial, func() {
+	t1 := &metricsTarget{kind: "OBCluster", namespace: "ns", name: "c1"}
+	t2 := &metricsTarget{kind: "OBCluster", namespace: "ns", name: "c2"}
+
+	AfterEach(func() {
+		CleanMetrics(t1.kind, types.NamespacedName{Namespace: t1.namespace, Name: t1.name})
+		CleanMetrics(t2.kind, types.NamespacedName{Namespace: t2.namespace, Name: t2.name})
+	})
+
+	It("Record status of resource", func() {
+		t1.recordStatus("running")
+		t1.recordStatus("upgrading")
+		Expect(testutil.CollectAndCount(resourceStatus)).Should(Equal(1))
+		Expect(testutil.ToFloat64(resourceStatus.WithLabelValues(t1.kind, t1.namespace, t1.name, "upgrading"))).Should(BeEquivalentTo(1))
+	})
+
+	It("Record operation of resource", func() {
+		ctx := &tasktypes.OperationContext{
+			Name: "upgrade",
+			Task: "backup essential parameters",
+			OnFailure: tasktypes.FailureRule{
+				RetryCount: 2,
+			},
+		}
+		t1.recordOperation(ctx)
+		Expect(testutil.ToFloat64(resourceOperation.WithLabelValues(t1.kind, t1.namespace, t1.name, "upgrade", "backup essential parameters"))).Should(BeEquivalentTo(1))
+		Expect(testutil.ToFloat64(resourceRetryCount.WithLabelValues(t1.kind, t1.namespace, t1.name, "upgrade"))).Should(BeEquivalentTo(2))
+		t1.recordOperation(nil)
+		Expect(testutil.CollectAndCount(resourceOperation)).Should(Equal(0))
+		Expect(testutil.CollectAndCount(resourceRetryCount)).Should(Equal(0))
+	})
+
+	It("Flows and tasks are recorded per resource", func() {
+		result := &tasktypes.TaskResult{
+			Status:       taskstatus.Successful,
+			Duration:     time.Second,
+			WaitDuration: time.Millisecond,
+		}
+		t1.recordTaskResult("wait obzone running", result)
+		t2.recordTaskResult("wait obzone running", result)
+		t1.recordFlowFinished("upgrade", flowResultSuccessful)
+		t1.recordFlowFinished("upgrade", flowResultSuccessful)
+		t2.recordFlowFinished("upgrade", flowResultFailed)
+
+		Expect(testutil.CollectAndCount(taskDuration)).Should(Equal(2))
+		Expect(testutil.CollectAndCount(taskWaitDuration)).Should(Equal(2))
+		Expect(testutil.ToFloat64(flowTotal.WithLabelValues(t1.kind, t1.namespace, t1.name, "upgrade", flowResultSuccessful))).Should(BeEquivalentTo(2))
+		Expect(testutil.ToFloat64(flowTotal.WithLabelValues(t2.kind, t2.namespace, t2.name, "upgrade", flowResultFailed))).Should(BeEquivalentTo(1))
+	})
+
+	It("Clean metrics of deleted resource", func() {
+		result := &tasktypes.TaskResult{Status: taskstatus.Failed}
+		t1.recordStatus("running")
+		t2.recordStatus("running")
+		t1.recordTaskResult("wait obzone running", result)
+		t2.recordTaskResult("wait obzone running", result)
+		t1.recordFlowFinished("upgrade", flowResultFailed)
+		t2.recordFlowFinished("upgrade", flowResultFailed)
+
+		CleanMetrics(t1.kind, types.NamespacedName{Namespace: t1.namespace, Name: t1.name})
+		Expect(testutil.CollectAndCount(resourceStatus)).Should(Equal(1))
+		Expect(testutil.CollectAndCount(taskDuration)).Should(Equal(1))
+		Expect(testutil.CollectAndCount(taskWaitDuration)).Should(Equal(1))
+		Expect(testutil.CollectAndCount(flowTotal)).Should(Equal(1))
+	})
+})
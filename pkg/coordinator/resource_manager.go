@@ -13,6 +13,8 @@ See the Mulan PSL v2 for more details.
 package coordinator
 
 import (
+	"time"
+
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
 
@@ -22,13 +24,21 @@ type ResourceManager interface {
 	CheckAndUpdateFinalizers() error
 	InitStatus()
 	SetOperationContext(*tasktypes.OperationContext)
+	// AddFlowRecord adds record of a finished flow to history in status of the resource
+	AddFlowRecord(*tasktypes.FlowRecord)
 	ClearTaskInfo()
 	HandleFailure()
 	FinishTask()
 	UpdateStatus() error
 	GetStatus() string
 	GetTaskFunc(tasktypes.TaskName) (tasktypes.TaskFunc, error)
+	// GetTaskTimeout returns the declared timeout of the task, zero means no limit
+	GetTaskTimeout(tasktypes.TaskName) time.Duration
 	GetTaskFlow() (*tasktypes.TaskFlow, error)
 	PrintErrEvent(error)
 	ArchiveResource()
+	// IsPaused tells whether reconciliation of the resource is paused by users
+	IsPaused() bool
+	// SetPaused shows whether reconciliation is paused in status without touching the status itself, returns whether it changes
+	SetPaused(bool) bool
 }
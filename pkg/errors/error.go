@@ -70,6 +70,8 @@ func (e *httpErr) Status() int {
 		return http.StatusBadRequest
 	case ErrUnauthorized:
 		return http.StatusUnauthorized
+	case ErrForbidden:
+		return http.StatusForbidden
 	case ErrNotImplemented:
 		return http.StatusNotImplemented
 	case ErrInternal:
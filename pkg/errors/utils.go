@@ -33,6 +33,13 @@ func NewUnauthorized(msg string) ObError {
 	}
 }
 
+func NewForbidden(msg string) ObError {
+	return &httpErr{
+		errorType: ErrForbidden,
+		message:   msg,
+	}
+}
+
 func NewNotFound(msg string) ObError {
 	return &httpErr{
 		errorType: ErrNotFound,
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package resource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResizePodInPlace changes resources of the container without recreating the pod.
+// The resize subresource is preferred and patching pod directly is the fallback for clusters without it,
+// an error is returned if the cluster does not support in-place pod resize at all.
+// With dryRun set, it only tells whether the pod could be resized in place.
+func ResizePodInPlace(ctx context.Context, c client.Client, pod *corev1.Pod, containerName string, resources corev1.ResourceRequirements, dryRun bool) error {
+	resized := pod.DeepCopy()
+	found := false
+	for i := range resized.Spec.Containers {
+		if resized.Spec.Containers[i].Name == containerName {
+			resized.Spec.Containers[i].Resources = resources
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("Container %s not found in pod %s", containerName, pod.Name)
+	}
+	patch := client.MergeFrom(pod)
+
+	subResourceOpts := []client.SubResourcePatchOption{}
+	patchOpts := []client.PatchOption{}
+	if dryRun {
+		subResourceOpts = append(subResourceOpts, client.DryRunAll)
+		patchOpts = append(patchOpts, client.DryRunAll)
+	}
+	err := c.SubResource("resize").Patch(ctx, resized, patch, subResourceOpts...)
+	if err == nil {
+		return nil
+	}
+	if !kubeerrors.IsNotFound(err) && !kubeerrors.IsMethodNotSupported(err) {
+		return errors.Wrap(err, "Resize pod through resize subresource")
+	}
+	err = c.Patch(ctx, resized, patch, patchOpts...)
+	if err != nil {
+		return errors.Wrap(err, "Resize pod in place")
+	}
+	return nil
+}
+
+// IsPodResized tells whether resources of the container in pod status have become the desired ones
+func IsPodResized(pod *corev1.Pod, containerName string, resources corev1.ResourceRequirements) bool {
+	if pod.Status.Resize != "" {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != containerName {
+			continue
+		}
+		// kubelet without resources in container status reports nothing more about resizing
+		if status.Resources == nil {
+			return true
+		}
+		return status.Resources.Limits.Cpu().Cmp(*resources.Limits.Cpu()) == 0 &&
+			status.Resources.Limits.Memory().Cmp(*resources.Limits.Memory()) == 0
+	}
+	return false
+}
@@ -19,6 +19,8 @@ const backupJobFields = jobCommonFields + ", backup_set_id, plus_archivelog, bac
 const backupTaskFields = "job_id, backup_set_id, start_timestamp, end_timestamp, status, result, comment, task_id, incarnation, start_scn, end_scn, user_ls_start_scn, encryption_mode, passwd, input_bytes, output_bytes, output_rate_bytes, extra_meta_bytes, tablet_count, finish_tablet_count, macro_block_count, finish_macro_block_count, file_count, meta_turn_id, data_turn_id, path"
 const cleanJobFields = jobCommonFields + ", type, parameter, task_count, success_task_count"
 const logArchiveJobFields = "dest_id, round_id, dest_no, status, start_scn, start_scn_display, checkpoint_scn, checkpoint_scn_display, compatible, base_piece_id, used_piece_id, piece_switch_interval, input_bytes, input_bytes_display, output_bytes, output_bytes_display, compression_ratio, deleted_input_bytes, deleted_input_bytes_display, deleted_output_bytes, deleted_output_bytes_display, comment, path"
+const backupSetFileFields = "backup_set_id, dest_id, incarnation, backup_type, start_timestamp, end_timestamp, status, file_status, start_replay_scn, start_replay_scn_display, min_restore_scn, min_restore_scn_display, output_bytes, path"
+const archivePieceRangeFields = "round_id, piece_id, status, start_scn, start_scn_display, checkpoint_scn, checkpoint_scn_display, path"
 const logArchivePieceFileFields = "dest_id, round_id, piece_id, incarnation, dest_no, status, start_scn, start_scn_display, checkpoint_scn, checkpoint_scn_display, max_scn, end_scn, end_scn_display, compatible, unit_size, compression, input_bytes, input_bytes_display, output_bytes, output_bytes_display, compression_ratio, file_status, path"
 
 const (
@@ -51,6 +53,8 @@ const (
 	QueryLatestBackupJobHistoryOfType        = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOB_HISTORY WHERE backup_type = ? order by job_id DESC LIMIT 1"
 	QueryLatestBackupJobOfTypeAndPath        = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOBS WHERE backup_type = ? and path = ? order by job_id DESC LIMIT 1"
 	QueryLatestBackupJobHistoryOfTypeAndPath = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOB_HISTORY WHERE backup_type = ? and path = ? order by job_id DESC LIMIT 1"
+	QueryBackupJobsOfTypeAndPath             = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOBS WHERE backup_type = ? and path = ? order by job_id DESC LIMIT ?"
+	QueryBackupJobHistoryOfTypeAndPath       = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOB_HISTORY WHERE backup_type = ? and path = ? order by job_id DESC LIMIT ?"
 	QueryLatestRunningBackupJob              = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOBS order by job_id DESC LIMIT 1"
 	QueryLatestCleanJob                      = "SELECT " + cleanJobFields + " FROM DBA_OB_BACKUP_DELETE_JOBS ORDER BY job_id DESC LIMIT 1"
 	QueryLatestCleanJobHistory               = "SELECT " + cleanJobFields + " FROM DBA_OB_BACKUP_DELETE_JOB_HISTORY ORDER BY job_id DESC LIMIT 1"
@@ -60,4 +64,13 @@ const (
 	QueryBackupHistoryWithId        = "SELECT " + backupJobFields + " FROM DBA_OB_BACKUP_JOB_HISTORY WHERE job_id = ?"
 	QueryBackupTaskWithJobId        = "SELECT " + backupTaskFields + " FROM DBA_OB_BACKUP_TASKS WHERE job_id = ?"
 	QueryBackupTaskHistoryWithJobId = "SELECT " + backupTaskFields + " FROM DBA_OB_BACKUP_TASK_HISTORY WHERE job_id = ?"
+	QueryBackupSetFileWithSetId     = "SELECT " + backupSetFileFields + " FROM DBA_OB_BACKUP_SET_FILES WHERE backup_set_id = ?"
+	QueryBackupSetFiles             = "SELECT " + backupSetFileFields + " FROM DBA_OB_BACKUP_SET_FILES"
+	DeleteBackupSet                 = "ALTER SYSTEM DELETE BACKUPSET ?"
+
+	// Used in sys tenant to figure out which range of backup and archive log of a tenant are available in a destination
+	QueryBackupSetFilesOfTenant       = "SELECT " + backupSetFileFields + " FROM CDB_OB_BACKUP_SET_FILES WHERE tenant_id = ?"
+	QueryArchiveLogPieceFilesOfTenant = "SELECT " + archivePieceRangeFields + " FROM CDB_OB_ARCHIVELOG_PIECE_FILES WHERE tenant_id = ?"
+	// Timestamp is interpreted in time zone of the session, which is the same as the one restore job uses
+	QueryScnOfTimestamp = "SELECT TIME_TO_USEC(?) * 1000"
 )
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package sql
+
+const majorCompactionFields = "tenant_id, frozen_scn, COALESCE(frozen_time, '') as frozen_time, global_broadcast_scn, last_scn, COALESCE(last_finish_time, '') as last_finish_time, COALESCE(start_time, '') as start_time, status, is_error, is_suspended, COALESCE(info, '') as info"
+const zoneMajorCompactionFields = "tenant_id, zone, broadcast_scn, last_scn, COALESCE(last_finish_time, '') as last_finish_time, COALESCE(start_time, '') as start_time, status"
+
+const (
+	MajorFreeze           = "alter system major freeze"
+	MajorFreezeTenants    = "alter system major freeze tenant = %s"
+	MajorFreezeAllTenants = "alter system major freeze tenant = all"
+
+	QueryMajorCompactions            = "SELECT " + majorCompactionFields + " FROM oceanbase.CDB_OB_MAJOR_COMPACTION;"
+	QueryMajorCompactionByTenantID   = "SELECT " + majorCompactionFields + " FROM oceanbase.CDB_OB_MAJOR_COMPACTION WHERE tenant_id = ?;"
+	QueryZoneMajorCompactionByTenant = "SELECT " + zoneMajorCompactionFields + " FROM oceanbase.CDB_OB_ZONE_MAJOR_COMPACTION WHERE tenant_id = ?;"
+)
@@ -13,12 +13,24 @@ See the Mulan PSL v2 for more details.
 package sql
 
 const (
-	ListServer   = "select id, zone, svr_ip, svr_port, inner_port, with_rootserver, with_partition, lower(status) as status, start_service_time, build_version from __all_server"
-	GetServer    = "select id, zone, svr_ip, svr_port, inner_port, with_rootserver, with_partition, lower(status) as status, start_service_time, build_version from __all_server where svr_ip = ? and svr_port = ?"
+	ListServer   = "select id, zone, svr_ip, svr_port, inner_port, with_rootserver, with_partition, lower(status) as status, start_service_time, stop_time, build_version from __all_server"
+	GetServer    = "select id, zone, svr_ip, svr_port, inner_port, with_rootserver, with_partition, lower(status) as status, start_service_time, stop_time, build_version from __all_server where svr_ip = ? and svr_port = ?"
 	AddServer    = "alter system add server ?"
 	DeleteServer = "alter system delete server ?"
+	StopServer   = "alter system stop server ?"
+	StartServer  = "alter system start server ?"
+)
+
+const (
+	CountLeadersOfServer       = "select count(*) from oceanbase.CDB_OB_LS_LOCATIONS where svr_ip = ? and svr_port = ? and role = 'LEADER'"
+	CountLeadersOfZone         = "select count(*) from oceanbase.CDB_OB_LS_LOCATIONS where zone = ? and role = 'LEADER'"
+	ListLSReplicasOfLSOnServer = "select tenant_id, ls_id, svr_ip, svr_port, zone, replica_type from oceanbase.CDB_OB_LS_LOCATIONS where (tenant_id, ls_id) in (select tenant_id, ls_id from oceanbase.CDB_OB_LS_LOCATIONS where svr_ip = ? and svr_port = ?)"
 )
 
 const (
 	ListGVServers = "select svr_ip, svr_port, zone, sql_port, cpu_capacity, cpu_capacity_max, cpu_assigned, cpu_assigned_max, mem_capacity, mem_assigned, memory_limit, log_disk_capacity, log_disk_assigned, data_disk_capacity, data_disk_allocated, data_disk_in_use, data_disk_health_status from oceanbase.GV$OB_SERVERS"
 )
+
+const (
+	CountLogStatNotInSync = "select count(*) from oceanbase.GV$OB_LOG_STAT where in_sync = 'NO'"
+)
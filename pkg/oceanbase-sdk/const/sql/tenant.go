@@ -25,7 +25,7 @@ const (
 	GetUnitConfigV4CountByName = "SELECT count(*) FROM oceanbase.DBA_OB_UNIT_CONFIGS WHERE name = ?;"
 	GetRsJobCount              = "select count(*) from DBA_OB_TENANT_JOBS where tenant_id=? and job_status ='INPROGRESS' and job_type='ALTER_TENANT_LOCALITY'"
 
-	GetResourceTotal = "SELECT cpu_capacity, mem_capacity, data_disk_capacity FROM oceanbase.GV$OB_SERVERS;"
+	GetResourceTotal = "SELECT SUM(cpu_capacity) AS cpu_capacity, SUM(mem_capacity) AS mem_capacity, SUM(data_disk_capacity) AS data_disk_capacity, SUM(log_disk_capacity) AS log_disk_capacity, COUNT(*) AS server_count FROM oceanbase.GV$OB_SERVERS WHERE zone = ?"
 	GetCharset       = "SELECT CHARSET('oceanbase') as charset;"
 	GetVariableLike  = "SHOW VARIABLES LIKE ?;"
 	GetRsJob         = "select job_id, job_type, job_status, tenant_id from DBA_OB_TENANT_JOBS where tenant_name=? and job_status ='INPROGRESS' and job_type='ALTER_TENANT_LOCALITY'"
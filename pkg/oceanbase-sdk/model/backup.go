@@ -156,3 +156,21 @@ type OBArchiveLogPieceFile struct {
 	FileStatus           int64  `json:"file_status" db:"file_status"`
 	Path                 string `json:"path" db:"path"`
 }
+
+// OBBackupSetFile matches view DBA_OB_BACKUP_SET_FILES
+type OBBackupSetFile struct {
+	BackupSetID           int64   `json:"backup_set_id" db:"backup_set_id"`
+	DestID                int64   `json:"dest_id" db:"dest_id"`
+	Incarnation           int64   `json:"incarnation" db:"incarnation"`
+	BackupType            string  `json:"backup_type" db:"backup_type"`
+	StartTimestamp        string  `json:"start_timestamp" db:"start_timestamp"`
+	EndTimestamp          *string `json:"end_timestamp,omitempty" db:"end_timestamp"`
+	Status                string  `json:"status" db:"status"`
+	FileStatus            string  `json:"file_status" db:"file_status"`
+	StartReplayScn        int64   `json:"start_replay_scn" db:"start_replay_scn"`
+	StartReplayScnDisplay string  `json:"start_replay_scn_display" db:"start_replay_scn_display"`
+	MinRestoreScn         int64   `json:"min_restore_scn" db:"min_restore_scn"`
+	MinRestoreScnDisplay  string  `json:"min_restore_scn_display" db:"min_restore_scn_display"`
+	OutputBytes           int64   `json:"output_bytes" db:"output_bytes"`
+	Path                  string  `json:"path" db:"path"`
+}
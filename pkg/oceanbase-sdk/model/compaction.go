@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package model
+
+// OBMajorCompaction is a record of CDB_OB_MAJOR_COMPACTION
+type OBMajorCompaction struct {
+	TenantID           int64  `json:"tenant_id" db:"tenant_id"`
+	FrozenScn          int64  `json:"frozen_scn" db:"frozen_scn"`
+	FrozenTime         string `json:"frozen_time" db:"frozen_time"`
+	GlobalBroadcastScn int64  `json:"global_broadcast_scn" db:"global_broadcast_scn"`
+	LastScn            int64  `json:"last_scn" db:"last_scn"`
+	LastFinishTime     string `json:"last_finish_time" db:"last_finish_time"`
+	StartTime          string `json:"start_time" db:"start_time"`
+	Status             string `json:"status" db:"status"`
+	IsError            string `json:"is_error" db:"is_error"`
+	IsSuspended        string `json:"is_suspended" db:"is_suspended"`
+	Info               string `json:"info" db:"info"`
+}
+
+// OBZoneMajorCompaction is a record of CDB_OB_ZONE_MAJOR_COMPACTION
+type OBZoneMajorCompaction struct {
+	TenantID       int64  `json:"tenant_id" db:"tenant_id"`
+	Zone           string `json:"zone" db:"zone"`
+	BroadcastScn   int64  `json:"broadcast_scn" db:"broadcast_scn"`
+	LastScn        int64  `json:"last_scn" db:"last_scn"`
+	LastFinishTime string `json:"last_finish_time" db:"last_finish_time"`
+	StartTime      string `json:"start_time" db:"start_time"`
+	Status         string `json:"status" db:"status"`
+}
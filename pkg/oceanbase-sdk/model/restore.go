@@ -47,3 +47,12 @@ type RestoreHistory struct {
 	TabletCount          int64  `json:"tablet_count" db:"tablet_count"`
 	FinishTabletCount    int64  `json:"finish_tablet_count" db:"finish_tablet_count"`
 }
+
+// RestorableWindow is the range of scn that a tenant could be restored to with given backup data and archive log,
+// lower bound is the min restore scn of backup sets and upper bound is the checkpoint scn of archive log
+type RestorableWindow struct {
+	LowerBoundScn     int64  `json:"lowerBoundScn"`
+	LowerBoundDisplay string `json:"lowerBoundDisplay"`
+	UpperBoundScn     int64  `json:"upperBoundScn"`
+	UpperBoundDisplay string `json:"upperBoundDisplay"`
+}
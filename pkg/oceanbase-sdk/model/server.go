@@ -29,6 +29,7 @@ type OBServer struct {
 	WithPartition    int64  `json:"with_partition" db:"with_partition"`
 	Status           string `json:"status" db:"status"`
 	StartServiceTime int64  `json:"start_service_time" db:"start_service_time"`
+	StopTime         int64  `json:"stop_time" db:"stop_time"`
 	BuildVersion     string `json:"build_version" db:"build_version"`
 }
 
@@ -52,3 +53,13 @@ type GVOBServer struct {
 	DataDiskInUse        int64  `json:"dataDiskInUse" db:"data_disk_in_use"`
 	DataDiskHealthStatus string `json:"dataDiskHealthStatus" db:"data_disk_health_status"`
 }
+
+// LSReplica is a replica of log stream
+type LSReplica struct {
+	TenantID    int64  `json:"tenant_id" db:"tenant_id"`
+	LSID        int64  `json:"ls_id" db:"ls_id"`
+	Ip          string `json:"svr_ip" db:"svr_ip"`
+	Port        int64  `json:"svr_port" db:"svr_port"`
+	Zone        string `json:"zone" db:"zone"`
+	ReplicaType string `json:"replica_type" db:"replica_type"`
+}
@@ -42,22 +42,22 @@ type OBTenant struct {
 
 // OBUnit is the unit model of OB system
 type OBUnit struct {
-	UnitId         int64  `json:"unit_id" db:"unit_id"`
-	TenantId       int64  `json:"tenant_id" db:"tenant_id"`
-	Status         string `json:"status" db:"status"`
-	ResourcePoolId int64  `json:"resource_pool_id" db:"resource_pool_id"`
-	UnitGroupId    int64  `json:"unit_group_id" db:"unit_group_id"`
-	CreateTime     string `json:"create_time" db:"create_time"`
-	ModifyTime     string `json:"modify_time" db:"modify_time"`
-	Zone           string `json:"zone" db:"zone"`
-	SvrIp          string `json:"svr_ip" db:"svr_ip"`
-	SvrPort        int64  `json:"svr_port" db:"svr_port"`
-	UnitConfigId   int64  `json:"unit_config_id" db:"unit_config_id"`
-	MaxCpu         int64  `json:"max_cpu" db:"max_cpu"`
-	MinCpu         int64  `json:"min_cpu" db:"min_cpu"`
-	MemorySize     int64  `json:"memory_size" db:"memory_size"`
-	LogDiskSize    int64  `json:"log_disk_size" db:"log_disk_size"`
-	MaxIops        int64  `json:"max_iops" db:"max_iops"`
-	MinIops        int64  `json:"min_iops" db:"min_iops"`
-	IopsWeight     int64  `json:"iops_weight" db:"iops_weight"`
+	UnitId         int64   `json:"unit_id" db:"unit_id"`
+	TenantId       int64   `json:"tenant_id" db:"tenant_id"`
+	Status         string  `json:"status" db:"status"`
+	ResourcePoolId int64   `json:"resource_pool_id" db:"resource_pool_id"`
+	UnitGroupId    int64   `json:"unit_group_id" db:"unit_group_id"`
+	CreateTime     string  `json:"create_time" db:"create_time"`
+	ModifyTime     string  `json:"modify_time" db:"modify_time"`
+	Zone           string  `json:"zone" db:"zone"`
+	SvrIp          string  `json:"svr_ip" db:"svr_ip"`
+	SvrPort        int64   `json:"svr_port" db:"svr_port"`
+	UnitConfigId   int64   `json:"unit_config_id" db:"unit_config_id"`
+	MaxCpu         float64 `json:"max_cpu" db:"max_cpu"`
+	MinCpu         float64 `json:"min_cpu" db:"min_cpu"`
+	MemorySize     int64   `json:"memory_size" db:"memory_size"`
+	LogDiskSize    int64   `json:"log_disk_size" db:"log_disk_size"`
+	MaxIops        int64   `json:"max_iops" db:"max_iops"`
+	MinIops        int64   `json:"min_iops" db:"min_iops"`
+	IopsWeight     int64   `json:"iops_weight" db:"iops_weight"`
 }
@@ -64,9 +64,11 @@ type UnitConfigV4 struct {
 }
 
 type ResourceTotal struct {
-	CPUTotal  float64 `json:"cpu_total" db:"cpu_capacity"`
-	MemTotal  int64   `json:"mem_total" db:"mem_capacity"`
-	DiskTotal int64   `json:"disk_total" db:"data_disk_capacity"`
+	CPUTotal     float64 `json:"cpu_total" db:"cpu_capacity"`
+	MemTotal     int64   `json:"mem_total" db:"mem_capacity"`
+	DiskTotal    int64   `json:"disk_total" db:"data_disk_capacity"`
+	LogDiskTotal int64   `json:"log_disk_total" db:"log_disk_capacity"`
+	ServerCount  int64   `json:"server_count" db:"server_count"`
 }
 
 type Charset struct {
@@ -206,6 +206,30 @@ func (m *OceanbaseOperationManager) GetLatestBackupJobOfTypeAndPath(jobType apit
 	return m.getLatestBackupJob([]string{sql.QueryLatestBackupJobOfTypeAndPath, sql.QueryLatestBackupJobHistoryOfTypeAndPath}, jobType, path)
 }
 
+// GetLatestBackupJobOfTypeAndPathExcluding returns the latest backup job of given type and path whose job id is not in excludedIDs
+func (m *OceanbaseOperationManager) GetLatestBackupJobOfTypeAndPathExcluding(jobType apitypes.BackupJobType, path string, excludedIDs []int64) (*model.OBBackupJob, error) {
+	excluded := make(map[int64]struct{}, len(excludedIDs))
+	for _, id := range excludedIDs {
+		excluded[id] = struct{}{}
+	}
+	// Fetching one more row than the number of excluded jobs guarantees a candidate if there is any
+	limit := len(excludedIDs) + 1
+	for _, statement := range []string{sql.QueryBackupJobsOfTypeAndPath, sql.QueryBackupJobHistoryOfTypeAndPath} {
+		jobs := make([]*model.OBBackupJob, 0)
+		err := m.QueryList(&jobs, statement, jobType, path, limit)
+		if err != nil {
+			m.Logger.Error(err, "Failed to query backup jobs of type and path")
+			return nil, errors.Wrap(err, "Query backup jobs of type and path")
+		}
+		for _, job := range jobs {
+			if _, ok := excluded[job.JobID]; !ok {
+				return job, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (m *OceanbaseOperationManager) getLatestBackupJob(statements []string, params ...any) (*model.OBBackupJob, error) {
 	if len(statements) != 2 {
 		return nil, errors.New("unexpected # of statements, require exactly 2 statement")
@@ -248,6 +272,32 @@ func (m *OceanbaseOperationManager) GetBackupJobWithId(jobId int64) (*model.OBBa
 	return jobs[0], nil
 }
 
+func (m *OceanbaseOperationManager) GetBackupSetFileWithSetId(backupSetId int64) (*model.OBBackupSetFile, error) {
+	files := make([]*model.OBBackupSetFile, 0)
+	err := m.QueryList(&files, sql.QueryBackupSetFileWithSetId, backupSetId)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return files[0], nil
+}
+
+func (m *OceanbaseOperationManager) ListBackupSetFiles() ([]*model.OBBackupSetFile, error) {
+	files := make([]*model.OBBackupSetFile, 0)
+	err := m.QueryList(&files, sql.QueryBackupSetFiles)
+	if err != nil {
+		m.Logger.Error(err, "Failed to query backup set files")
+		return nil, errors.Wrap(err, "Query backup set files")
+	}
+	return files, nil
+}
+
+func (m *OceanbaseOperationManager) DeleteBackupSet(backupSetId int64) error {
+	return m.ExecWithDefaultTimeout(sql.DeleteBackupSet, backupSetId)
+}
+
 func (m *OceanbaseOperationManager) ListBackupTaskWithJobId(jobId int64) ([]*model.OBBackupTask, error) {
 	tasks := make([]*model.OBBackupTask, 0)
 	taskHistory := make([]*model.OBBackupTask, 0)
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package operation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/sql"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/util"
+)
+
+func (m *OceanbaseOperationManager) MajorFreeze() error {
+	err := m.ExecWithDefaultTimeout(sql.MajorFreeze)
+	if err != nil {
+		m.Logger.Error(err, "Got exception when trigger major freeze")
+		return errors.Wrap(err, "Major freeze")
+	}
+	return nil
+}
+
+// MajorFreezeTenants triggers major freeze of given tenants, all tenants are frozen if none is specified
+func (m *OceanbaseOperationManager) MajorFreezeTenants(tenantNames ...string) error {
+	sqlStatement := sql.MajorFreezeAllTenants
+	if len(tenantNames) > 0 {
+		quotedNames := make([]string, 0, len(tenantNames))
+		for _, tenantName := range tenantNames {
+			if !util.IsValidIdentifier(tenantName) {
+				return errors.Errorf("Invalid tenant name %q", tenantName)
+			}
+			quotedNames = append(quotedNames, util.QuoteIdentifier(tenantName))
+		}
+		sqlStatement = fmt.Sprintf(sql.MajorFreezeTenants, strings.Join(quotedNames, ","))
+	}
+	err := m.ExecWithDefaultTimeout(sqlStatement)
+	if err != nil {
+		m.Logger.Error(err, "Got exception when trigger major freeze of tenants", "tenants", tenantNames)
+		return errors.Wrap(err, "Major freeze tenants")
+	}
+	return nil
+}
+
+func (m *OceanbaseOperationManager) ListMajorCompactions() ([]model.OBMajorCompaction, error) {
+	compactions := make([]model.OBMajorCompaction, 0)
+	err := m.QueryList(&compactions, sql.QueryMajorCompactions)
+	if err != nil {
+		m.Logger.Error(err, "Failed to query major compactions")
+		return nil, errors.Wrap(err, "Query major compactions")
+	}
+	return compactions, nil
+}
+
+func (m *OceanbaseOperationManager) GetMajorCompactionByTenantID(tenantID int64) (*model.OBMajorCompaction, error) {
+	compaction := &model.OBMajorCompaction{}
+	err := m.QueryRow(compaction, sql.QueryMajorCompactionByTenantID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Get major compaction by tenant id")
+	}
+	return compaction, nil
+}
+
+func (m *OceanbaseOperationManager) ListZoneMajorCompactionsByTenantID(tenantID int64) ([]model.OBZoneMajorCompaction, error) {
+	compactions := make([]model.OBZoneMajorCompaction, 0)
+	err := m.QueryList(&compactions, sql.QueryZoneMajorCompactionByTenant, tenantID)
+	if err != nil {
+		m.Logger.Error(err, "Failed to query zone major compactions")
+		return nil, errors.Wrap(err, "Query zone major compactions")
+	}
+	return compactions, nil
+}
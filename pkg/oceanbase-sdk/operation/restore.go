@@ -123,3 +123,34 @@ func (m *OceanbaseOperationManager) GetLatestRestoreHistoryOfTenant(tenant strin
 	}
 	return latest[0], nil
 }
+
+func (m *OceanbaseOperationManager) ListBackupSetFilesOfTenant(tenantID int64) ([]*model.OBBackupSetFile, error) {
+	files := make([]*model.OBBackupSetFile, 0)
+	err := m.QueryList(&files, sql.QueryBackupSetFilesOfTenant, tenantID)
+	if err != nil {
+		m.Logger.Error(err, "Failed to query backup set files", "tenantId", tenantID)
+		return nil, errors.Wrap(err, "Query backup set files")
+	}
+	return files, nil
+}
+
+func (m *OceanbaseOperationManager) ListArchiveLogPieceFilesOfTenant(tenantID int64) ([]*model.OBArchiveLogPieceFile, error) {
+	pieces := make([]*model.OBArchiveLogPieceFile, 0)
+	err := m.QueryList(&pieces, sql.QueryArchiveLogPieceFilesOfTenant, tenantID)
+	if err != nil {
+		m.Logger.Error(err, "Failed to query archive log piece files", "tenantId", tenantID)
+		return nil, errors.Wrap(err, "Query archive log piece files")
+	}
+	return pieces, nil
+}
+
+// GetScnOfTimestamp converts timestamp to scn in time zone of the cluster
+func (m *OceanbaseOperationManager) GetScnOfTimestamp(timestamp string) (int64, error) {
+	var scn int64
+	err := m.QueryRow(&scn, sql.QueryScnOfTimestamp, timestamp)
+	if err != nil {
+		m.Logger.Error(err, "Failed to convert timestamp to scn", "timestamp", timestamp)
+		return 0, errors.Wrap(err, "Convert timestamp to scn")
+	}
+	return scn, nil
+}
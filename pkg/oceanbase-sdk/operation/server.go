@@ -62,6 +62,80 @@ func (m *OceanbaseOperationManager) DeleteServer(serverInfo *model.ServerInfo) e
 	return nil
 }
 
+func (m *OceanbaseOperationManager) StopServer(serverInfo *model.ServerInfo) error {
+	server := fmt.Sprintf("%s:%d", serverInfo.Ip, serverInfo.Port)
+	err := m.ExecWithDefaultTimeout(sql.StopServer, server)
+	if err != nil {
+		m.Logger.Error(err, "Got exception when stop server")
+		return errors.Wrap(err, "Stop server")
+	}
+	return nil
+}
+
+func (m *OceanbaseOperationManager) StartServer(serverInfo *model.ServerInfo) error {
+	server := fmt.Sprintf("%s:%d", serverInfo.Ip, serverInfo.Port)
+	err := m.ExecWithDefaultTimeout(sql.StartServer, server)
+	if err != nil {
+		m.Logger.Error(err, "Got exception when start server")
+		return errors.Wrap(err, "Start server")
+	}
+	return nil
+}
+
+// CountLeadersOfServer returns the number of log stream leaders located on the server
+func (m *OceanbaseOperationManager) CountLeadersOfServer(serverInfo *model.ServerInfo) (int, error) {
+	count := 0
+	err := m.QueryCount(&count, sql.CountLeadersOfServer, serverInfo.Ip, serverInfo.Port)
+	if err != nil {
+		return 0, errors.Wrap(err, "Count leaders of server")
+	}
+	return count, nil
+}
+
+// ListLSReplicasOfLSOnServer returns all the replicas of log streams that have a replica on the server
+func (m *OceanbaseOperationManager) ListLSReplicasOfLSOnServer(serverInfo *model.ServerInfo) ([]model.LSReplica, error) {
+	replicas := make([]model.LSReplica, 0)
+	err := m.QueryList(&replicas, sql.ListLSReplicasOfLSOnServer, serverInfo.Ip, serverInfo.Port)
+	if err != nil {
+		return nil, errors.Wrap(err, "List log stream replicas of server")
+	}
+	return replicas, nil
+}
+
+// CountLeadersOfZone returns the number of log stream leaders located in the zone
+func (m *OceanbaseOperationManager) CountLeadersOfZone(zoneName string) (int, error) {
+	count := 0
+	err := m.QueryCount(&count, sql.CountLeadersOfZone, zoneName)
+	if err != nil {
+		return 0, errors.Wrap(err, "Count leaders of zone")
+	}
+	return count, nil
+}
+
+func (m *OceanbaseOperationManager) ListServersOfZone(zoneName string) ([]model.OBServer, error) {
+	observers, err := m.ListServers()
+	if err != nil {
+		return nil, err
+	}
+	zoneServers := make([]model.OBServer, 0, len(observers))
+	for _, observer := range observers {
+		if observer.Zone == zoneName {
+			zoneServers = append(zoneServers, observer)
+		}
+	}
+	return zoneServers, nil
+}
+
+// CountLogStatNotInSync returns the number of log stream replicas whose log is still not in sync with the leader
+func (m *OceanbaseOperationManager) CountLogStatNotInSync() (int, error) {
+	count := 0
+	err := m.QueryCount(&count, sql.CountLogStatNotInSync)
+	if err != nil {
+		return 0, errors.Wrap(err, "Count log stat not in sync")
+	}
+	return count, nil
+}
+
 func (m *OceanbaseOperationManager) ListGVServers() ([]model.GVOBServer, error) {
 	observers := make([]model.GVOBServer, 0)
 	err := m.QueryList(&observers, sql.ListGVServers)
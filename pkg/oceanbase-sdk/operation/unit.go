@@ -28,3 +28,13 @@ func (m *OceanbaseOperationManager) ListUnitsWithServerIP(serverIP string) ([]*m
 	}
 	return units, nil
 }
+
+func (m *OceanbaseOperationManager) ListUnitsWithZone(zone string) ([]*model.OBUnit, error) {
+	units := make([]*model.OBUnit, 0)
+	err := m.QueryList(&units, sql.ListUnitsWithZone, zone)
+	if err != nil {
+		m.Logger.Error(err, "Failed to list ob units")
+		return nil, errors.Wrap(err, "List OB units of zone")
+	}
+	return units, nil
+}
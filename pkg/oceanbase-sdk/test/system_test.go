@@ -52,4 +52,20 @@ var _ = Describe("Test Backup Operation", Label("system"), func() {
 		Expect(err).To(BeNil())
 		printSlice(units, "list units with server IP")
 	})
+
+	It("List major compactions", func() {
+		compactions, err := con.ListMajorCompactions()
+		Expect(err).To(BeNil())
+		printSlice(compactions, "list major compactions")
+		compaction, err := con.GetMajorCompactionByTenantID(1)
+		Expect(err).To(BeNil())
+		printObject(compaction, "get major compaction of sys tenant")
+		zoneCompactions, err := con.ListZoneMajorCompactionsByTenantID(1)
+		Expect(err).To(BeNil())
+		printSlice(zoneCompactions, "list zone major compactions of sys tenant")
+	})
+
+	It("Refuse to major freeze tenants with invalid names", func() {
+		Expect(con.MajorFreezeTenants("sys", "t1 or 1=1")).NotTo(Succeed())
+	})
 })
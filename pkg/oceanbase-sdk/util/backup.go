@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+const backupTimestampLayout = "2006-01-02 15:04:05.999999999"
+
+// BackupRetentionRule describes which backup sets should be kept besides the recovery window of OceanBase.
+// Zero value of each field means the corresponding rule is disabled.
+type BackupRetentionRule struct {
+	KeepLastFullBackups int           // Keep the latest N full backup sets and the incremental sets based on them
+	KeepOneOlderThan    time.Duration // Keep at least one full backup set that is older than the duration
+	MaxTotalBytes       int64         // Delete the oldest backup sets if total size exceeds the limit
+}
+
+// BackupSetDecision is the result of applying retention rules to a backup set
+type BackupSetDecision struct {
+	BackupSetID int64
+	BackupType  string
+	Delete      bool
+	Reason      string
+}
+
+type backupSetChain struct {
+	full         *model.OBBackupJob
+	incrementals []*model.OBBackupJob
+	endTime      time.Time
+	bytes        int64
+}
+
+// ComputeBackupSetsToDelete applies rule to completed backup jobs whose backup sets are available in dataPath.
+// A full backup set is always deleted together with the incremental sets based on it, incremental sets come first.
+// The latest full backup set is never deleted. Decisions to keep a backup set are returned only if the set
+// is protected by KeepOneOlderThan from being deleted by other rules.
+func ComputeBackupSetsToDelete(jobs []*model.OBBackupJob, files []*model.OBBackupSetFile, dataPath string, rule BackupRetentionRule, now time.Time) []BackupSetDecision {
+	dataPath = normalizeDestPath(dataPath)
+	availableFiles := make(map[int64]*model.OBBackupSetFile)
+	for _, file := range files {
+		if normalizeDestPath(file.Path) == dataPath && file.FileStatus == "AVAILABLE" {
+			availableFiles[file.BackupSetID] = file
+		}
+	}
+
+	completed := make([]*model.OBBackupJob, 0, len(jobs))
+	for _, job := range jobs {
+		if _, ok := availableFiles[job.BackupSetID]; ok && job.Status == "COMPLETED" && job.EndTimestamp != nil {
+			completed = append(completed, job)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].BackupSetID < completed[j].BackupSetID
+	})
+
+	chains := make([]*backupSetChain, 0)
+	for _, job := range completed {
+		if job.BackupType == "FULL" {
+			endTime, err := time.ParseInLocation(backupTimestampLayout, *job.EndTimestamp, time.Local)
+			if err != nil {
+				continue
+			}
+			chains = append(chains, &backupSetChain{
+				full:    job,
+				endTime: endTime,
+				bytes:   availableFiles[job.BackupSetID].OutputBytes,
+			})
+		} else if len(chains) > 0 {
+			chain := chains[len(chains)-1]
+			chain.incrementals = append(chain.incrementals, job)
+			chain.bytes += availableFiles[job.BackupSetID].OutputBytes
+		}
+	}
+
+	// Newest chain first
+	for i, j := 0, len(chains)-1; i < j; i, j = i+1, j-1 {
+		chains[i], chains[j] = chains[j], chains[i]
+	}
+
+	protected := -1
+	if rule.KeepOneOlderThan > 0 {
+		threshold := now.Add(-rule.KeepOneOlderThan)
+		for i, chain := range chains {
+			if !chain.endTime.After(threshold) {
+				protected = i
+				break
+			}
+		}
+	}
+
+	decisions := make([]BackupSetDecision, 0)
+	var keptBytes int64
+	sizeExceeded := false
+	for i, chain := range chains {
+		reason := ""
+		if i > 0 && rule.KeepLastFullBackups > 0 && i >= rule.KeepLastFullBackups {
+			reason = fmt.Sprintf("not in the latest %d full backup sets", rule.KeepLastFullBackups)
+		} else if i > 0 && rule.MaxTotalBytes > 0 && (sizeExceeded || keptBytes+chain.bytes > rule.MaxTotalBytes) {
+			sizeExceeded = true
+			reason = fmt.Sprintf("total size of backup sets exceeds %d bytes", rule.MaxTotalBytes)
+		}
+		if reason == "" {
+			keptBytes += chain.bytes
+			continue
+		}
+		if i == protected {
+			keptBytes += chain.bytes
+			decisions = append(decisions, BackupSetDecision{
+				BackupSetID: chain.full.BackupSetID,
+				BackupType:  chain.full.BackupType,
+				Reason:      fmt.Sprintf("%s, but it is the latest full backup set older than %s", reason, rule.KeepOneOlderThan),
+			})
+			continue
+		}
+		for k := len(chain.incrementals) - 1; k >= 0; k-- {
+			decisions = append(decisions, BackupSetDecision{
+				BackupSetID: chain.incrementals[k].BackupSetID,
+				BackupType:  chain.incrementals[k].BackupType,
+				Delete:      true,
+				Reason:      fmt.Sprintf("based on full backup set %d, which is %s", chain.full.BackupSetID, reason),
+			})
+		}
+		decisions = append(decisions, BackupSetDecision{
+			BackupSetID: chain.full.BackupSetID,
+			BackupType:  chain.full.BackupType,
+			Delete:      true,
+			Reason:      reason,
+		})
+	}
+	return decisions
+}
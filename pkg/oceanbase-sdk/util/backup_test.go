@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+var _ = Describe("Test Backup Utilities", func() {
+	dataPath := "file:///ob/backup/t1/data"
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	newJob := func(setId int64, backupType string, daysAgo int) *model.OBBackupJob {
+		endTime := now.AddDate(0, 0, -daysAgo).Format(time.DateTime)
+		job := &model.OBBackupJob{
+			BackupSetID: setId,
+			BackupType:  backupType,
+		}
+		job.Status = "COMPLETED"
+		job.EndTimestamp = &endTime
+		return job
+	}
+	jobs := []*model.OBBackupJob{
+		newJob(1, "FULL", 60),
+		newJob(2, "INC", 59),
+		newJob(3, "FULL", 20),
+		newJob(4, "INC", 19),
+		newJob(5, "FULL", 1),
+		newJob(6, "INC", 0),
+	}
+	files := make([]*model.OBBackupSetFile, 0)
+	for _, job := range jobs {
+		files = append(files, &model.OBBackupSetFile{
+			BackupSetID: job.BackupSetID,
+			FileStatus:  "AVAILABLE",
+			OutputBytes: 100,
+			Path:        dataPath,
+		})
+	}
+	idsOf := func(decisions []BackupSetDecision, deleted bool) []int64 {
+		ids := make([]int64, 0)
+		for _, d := range decisions {
+			if d.Delete == deleted {
+				ids = append(ids, d.BackupSetID)
+			}
+		}
+		return ids
+	}
+
+	It("Delete nothing without rules", func() {
+		Expect(ComputeBackupSetsToDelete(jobs, files, dataPath, BackupRetentionRule{}, now)).To(BeEmpty())
+	})
+
+	It("Keep last N full backup sets", func() {
+		decisions := ComputeBackupSetsToDelete(jobs, files, dataPath, BackupRetentionRule{KeepLastFullBackups: 1}, now)
+		Expect(idsOf(decisions, true)).To(Equal([]int64{4, 3, 2, 1}))
+	})
+
+	It("Keep one full backup set older than threshold", func() {
+		decisions := ComputeBackupSetsToDelete(jobs, files, dataPath, BackupRetentionRule{
+			KeepLastFullBackups: 1,
+			KeepOneOlderThan:    30 * 24 * time.Hour,
+		}, now)
+		Expect(idsOf(decisions, true)).To(Equal([]int64{4, 3}))
+		Expect(idsOf(decisions, false)).To(Equal([]int64{1}))
+	})
+
+	It("Delete oldest backup sets when size exceeds", func() {
+		decisions := ComputeBackupSetsToDelete(jobs, files, dataPath, BackupRetentionRule{MaxTotalBytes: 450}, now)
+		Expect(idsOf(decisions, true)).To(Equal([]int64{2, 1}))
+	})
+
+	It("Never delete the latest full backup set", func() {
+		decisions := ComputeBackupSetsToDelete(jobs, files, dataPath, BackupRetentionRule{MaxTotalBytes: 10}, now)
+		Expect(idsOf(decisions, true)).To(Equal([]int64{4, 3, 2, 1}))
+	})
+
+	It("Ignore unavailable backup sets and other paths", func() {
+		otherFiles := []*model.OBBackupSetFile{
+			{BackupSetID: 1, FileStatus: "DELETED", Path: dataPath},
+			{BackupSetID: 3, FileStatus: "AVAILABLE", Path: dataPath + "/"},
+			{BackupSetID: 5, FileStatus: "AVAILABLE", Path: "file:///ob/backup/t2/data"},
+		}
+		decisions := ComputeBackupSetsToDelete(jobs, otherFiles, dataPath, BackupRetentionRule{KeepLastFullBackups: 1}, now)
+		Expect(decisions).To(BeEmpty())
+	})
+})
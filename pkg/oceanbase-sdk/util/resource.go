@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+var capacityUnits = map[string]int64{
+	"B": 1,
+	"K": 1 << 10,
+	"M": 1 << 20,
+	"G": 1 << 30,
+	"T": 1 << 40,
+	"P": 1 << 50,
+}
+
+// ParseCapacity parses value of capacity parameters like memory_limit and system_memory into bytes, e.g. 10G, 1024M.
+// Values without unit are in MB, which is the default unit of these parameters.
+func ParseCapacity(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	// Units like GB and MB are the same as G and M
+	if len(value) > 2 && value[len(value)-1] == 'B' {
+		if _, ok := capacityUnits[value[len(value)-2:len(value)-1]]; ok {
+			value = value[:len(value)-1]
+		}
+	}
+	if value == "" {
+		return 0, nil
+	}
+	unit := int64(1 << 20)
+	if factor, ok := capacityUnits[value[len(value)-1:]]; ok {
+		unit = factor
+		value = value[:len(value)-1]
+	}
+	num, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Parse capacity %s", value)
+	}
+	return num * unit, nil
+}
+
+// ServerCapacity is the resource that an observer could allocate to units
+type ServerCapacity struct {
+	CpuCount    int64
+	MemoryLimit int64
+	// Memory reserved for system, which could not be allocated to units
+	SystemMemory int64
+}
+
+// CheckUnitsFitServer checks whether units hosted on an observer fit into the capacity of the server
+func CheckUnitsFitServer(units []*model.OBUnit, capacity ServerCapacity) error {
+	var minCpu float64
+	var memory int64
+	for _, unit := range units {
+		minCpu += unit.MinCpu
+		memory += unit.MemorySize
+	}
+	if minCpu > float64(capacity.CpuCount) {
+		return errors.Errorf("min cpu of units %.2f exceeds cpu count %d", minCpu, capacity.CpuCount)
+	}
+	if available := capacity.MemoryLimit - capacity.SystemMemory; memory > available {
+		return errors.Errorf("memory of units %d exceeds available memory %d (memory_limit %d - system_memory %d)", memory, available, capacity.MemoryLimit, capacity.SystemMemory)
+	}
+	return nil
+}
+
+// ServerResource is the resource of an observer that units could be placed on
+type ServerResource struct {
+	Cpu     float64
+	Memory  int64
+	LogDisk int64
+}
+
+// CheckZoneScaleIn checks whether units of the zone could be moved onto remaining servers,
+// resource of every server is taken as the average of the zone
+func CheckZoneScaleIn(units []*model.OBUnit, total *model.ResourceTotal, remainingServers []string) error {
+	if total.ServerCount == 0 {
+		return errors.New("no server found in zone")
+	}
+	return SimulateUnitPlacement(units, remainingServers, ServerResource{
+		Cpu:     total.CPUTotal / float64(total.ServerCount),
+		Memory:  total.MemTotal / total.ServerCount,
+		LogDisk: total.LogDiskTotal / total.ServerCount,
+	})
+}
+
+// SimulateUnitPlacement places units not on remaining servers onto them,
+// the way rootservice migrates units: at most one unit of a tenant on a server, larger units first on the freest server.
+func SimulateUnitPlacement(units []*model.OBUnit, remainingServers []string, capacity ServerResource) error {
+	type serverLoad struct {
+		ServerResource
+		ip      string
+		tenants map[int64]struct{}
+	}
+	loads := make([]*serverLoad, 0, len(remainingServers))
+	loadMap := make(map[string]*serverLoad, len(remainingServers))
+	for _, ip := range remainingServers {
+		load := &serverLoad{ip: ip, tenants: make(map[int64]struct{})}
+		loads = append(loads, load)
+		loadMap[ip] = load
+	}
+	unitsToMove := make([]*model.OBUnit, 0)
+	for _, unit := range units {
+		load, ok := loadMap[unit.SvrIp]
+		if !ok {
+			unitsToMove = append(unitsToMove, unit)
+			continue
+		}
+		load.Cpu += unit.MinCpu
+		load.Memory += unit.MemorySize
+		load.LogDisk += unit.LogDiskSize
+		load.tenants[unit.TenantId] = struct{}{}
+	}
+	sort.SliceStable(unitsToMove, func(i, j int) bool {
+		if unitsToMove[i].MemorySize != unitsToMove[j].MemorySize {
+			return unitsToMove[i].MemorySize > unitsToMove[j].MemorySize
+		}
+		return unitsToMove[i].MinCpu > unitsToMove[j].MinCpu
+	})
+	for _, unit := range unitsToMove {
+		var target *serverLoad
+		for _, load := range loads {
+			if _, ok := load.tenants[unit.TenantId]; ok {
+				continue
+			}
+			if load.Cpu+unit.MinCpu > capacity.Cpu || load.Memory+unit.MemorySize > capacity.Memory || load.LogDisk+unit.LogDiskSize > capacity.LogDisk {
+				continue
+			}
+			if target == nil || load.Memory < target.Memory {
+				target = load
+			}
+		}
+		if target == nil {
+			return errors.Errorf("unit %d of tenant %d (min cpu %.2f, memory %d, log disk %d) on server %s could not be placed on remaining servers",
+				unit.UnitId, unit.TenantId, unit.MinCpu, unit.MemorySize, unit.LogDiskSize, unit.SvrIp)
+		}
+		target.Cpu += unit.MinCpu
+		target.Memory += unit.MemorySize
+		target.LogDisk += unit.LogDiskSize
+		target.tenants[unit.TenantId] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+var _ = Describe("Test Resource Utilities", func() {
+	It("Parse capacity", func() {
+		for value, expected := range map[string]int64{
+			"0":     0,
+			"0M":    0,
+			"1024":  1 << 30,
+			"10G":   10 << 30,
+			"10GB":  10 << 30,
+			"512m":  512 << 20,
+			"1T":    1 << 40,
+			"100B":  100,
+			" 2g  ": 2 << 30,
+		} {
+			Expect(ParseCapacity(value)).To(Equal(expected), value)
+		}
+		_, err := ParseCapacity("ten")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Check units fit server", func() {
+		units := []*model.OBUnit{{MinCpu: 2, MemorySize: 4 << 30}, {MinCpu: 1.5, MemorySize: 2 << 30}}
+		Expect(CheckUnitsFitServer(units, ServerCapacity{CpuCount: 16, MemoryLimit: 10 << 30, SystemMemory: 3 << 30})).To(Succeed())
+		Expect(CheckUnitsFitServer(units, ServerCapacity{CpuCount: 3, MemoryLimit: 10 << 30, SystemMemory: 3 << 30})).NotTo(Succeed())
+		Expect(CheckUnitsFitServer(units, ServerCapacity{CpuCount: 16, MemoryLimit: 8 << 30, SystemMemory: 3 << 30})).NotTo(Succeed())
+		Expect(CheckUnitsFitServer(nil, ServerCapacity{CpuCount: 16, MemoryLimit: 8 << 30, SystemMemory: 3 << 30})).To(Succeed())
+	})
+
+	It("Simulate unit placement", func() {
+		capacity := ServerResource{Cpu: 8, Memory: 16 << 30, LogDisk: 100 << 30}
+		units := []*model.OBUnit{
+			{UnitId: 1, TenantId: 1, SvrIp: "10.0.0.1", MinCpu: 2, MemorySize: 4 << 30, LogDiskSize: 10 << 30},
+			{UnitId: 2, TenantId: 1001, SvrIp: "10.0.0.1", MinCpu: 4, MemorySize: 8 << 30, LogDiskSize: 20 << 30},
+			{UnitId: 3, TenantId: 1001, SvrIp: "10.0.0.2", MinCpu: 4, MemorySize: 8 << 30, LogDiskSize: 20 << 30},
+			{UnitId: 4, TenantId: 1002, SvrIp: "10.0.0.3", MinCpu: 2, MemorySize: 4 << 30, LogDiskSize: 10 << 30},
+		}
+		By("Units of server 10.0.0.1 could be moved onto 10.0.0.3")
+		Expect(SimulateUnitPlacement(units, []string{"10.0.0.2", "10.0.0.3"}, capacity)).To(Succeed())
+
+		By("Units of the same tenant could not be placed on the same server")
+		Expect(SimulateUnitPlacement(units, []string{"10.0.0.2"}, capacity)).NotTo(Succeed())
+
+		By("Remaining server is not large enough")
+		Expect(SimulateUnitPlacement(units, []string{"10.0.0.2", "10.0.0.3"}, ServerResource{Cpu: 8, Memory: 11 << 30, LogDisk: 100 << 30})).NotTo(Succeed())
+
+		By("Nothing to move")
+		Expect(SimulateUnitPlacement(units, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, capacity)).To(Succeed())
+	})
+
+	It("Check zone scale in", func() {
+		units := []*model.OBUnit{
+			{UnitId: 1, TenantId: 1001, SvrIp: "10.0.0.1", MinCpu: 2, MemorySize: 4 << 30, LogDiskSize: 10 << 30},
+		}
+		total := &model.ResourceTotal{CPUTotal: 16, MemTotal: 16 << 30, LogDiskTotal: 100 << 30, ServerCount: 2}
+		Expect(CheckZoneScaleIn(units, total, []string{"10.0.0.2"})).To(Succeed())
+		total.MemTotal = 6 << 30
+		Expect(CheckZoneScaleIn(units, total, []string{"10.0.0.2"})).NotTo(Succeed())
+		Expect(CheckZoneScaleIn(units, &model.ResourceTotal{}, []string{"10.0.0.2"})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	"strings"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+// ComputeRestorableWindow computes the range of scn that could be restored to with backup sets in dataPath
+// and archive log pieces in archivePath. Archive log is only continuous within one round, so only the latest
+// round is taken into consideration. Nil is returned if there is no usable backup set or archive log.
+func ComputeRestorableWindow(sets []*model.OBBackupSetFile, pieces []*model.OBArchiveLogPieceFile, dataPath, archivePath string) *model.RestorableWindow {
+	dataPath = normalizeDestPath(dataPath)
+	archivePath = normalizeDestPath(archivePath)
+
+	var latestRound int64 = -1
+	for _, piece := range pieces {
+		if normalizeDestPath(piece.Path) == archivePath && piece.RoundID > latestRound {
+			latestRound = piece.RoundID
+		}
+	}
+	if latestRound < 0 {
+		return nil
+	}
+	var archiveStart *model.OBArchiveLogPieceFile
+	var archiveEnd *model.OBArchiveLogPieceFile
+	for _, piece := range pieces {
+		if normalizeDestPath(piece.Path) != archivePath || piece.RoundID != latestRound {
+			continue
+		}
+		if archiveStart == nil || piece.StartScn < archiveStart.StartScn {
+			archiveStart = piece
+		}
+		if archiveEnd == nil || piece.CheckpointScn > archiveEnd.CheckpointScn {
+			archiveEnd = piece
+		}
+	}
+
+	var earliestSet *model.OBBackupSetFile
+	for _, set := range sets {
+		if normalizeDestPath(set.Path) != dataPath || set.Status != "SUCCESS" || set.FileStatus != "AVAILABLE" {
+			continue
+		}
+		// Log replay of the backup set must be covered by the archive log
+		if set.StartReplayScn < archiveStart.StartScn || set.MinRestoreScn > archiveEnd.CheckpointScn {
+			continue
+		}
+		if earliestSet == nil || set.MinRestoreScn < earliestSet.MinRestoreScn {
+			earliestSet = set
+		}
+	}
+	if earliestSet == nil {
+		return nil
+	}
+	return &model.RestorableWindow{
+		LowerBoundScn:     earliestSet.MinRestoreScn,
+		LowerBoundDisplay: earliestSet.MinRestoreScnDisplay,
+		UpperBoundScn:     archiveEnd.CheckpointScn,
+		UpperBoundDisplay: archiveEnd.CheckpointScnDisplay,
+	}
+}
+
+// IsSameBackupDest checks whether two destination paths point to the same location, parameters are ignored
+func IsSameBackupDest(destPath, otherPath string) bool {
+	return normalizeDestPath(destPath) == normalizeDestPath(otherPath)
+}
+
+// normalizeDestPath strips parameters like host and access key of object storage and the trailing slash
+func normalizeDestPath(destPath string) string {
+	if idx := strings.Index(destPath, "?"); idx >= 0 {
+		destPath = destPath[:idx]
+	}
+	return strings.TrimRight(destPath, "/")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+var _ = Describe("Test Restore Utilities", func() {
+	dataPath := "file:///ob/backup/t1/data"
+	archivePath := "file:///ob/backup/t1/archive"
+	sets := []*model.OBBackupSetFile{{
+		BackupSetID:          1,
+		Status:               "SUCCESS",
+		FileStatus:           "AVAILABLE",
+		StartReplayScn:       150,
+		MinRestoreScn:        200,
+		MinRestoreScnDisplay: "set-1",
+		Path:                 dataPath,
+	}, {
+		BackupSetID:    2,
+		Status:         "FAILED",
+		FileStatus:     "AVAILABLE",
+		StartReplayScn: 110,
+		MinRestoreScn:  120,
+		Path:           dataPath,
+	}, {
+		BackupSetID:          3,
+		Status:               "SUCCESS",
+		FileStatus:           "AVAILABLE",
+		StartReplayScn:       400,
+		MinRestoreScn:        450,
+		MinRestoreScnDisplay: "set-3",
+		Path:                 dataPath + "/",
+	}}
+	pieces := []*model.OBArchiveLogPieceFile{{
+		RoundID:              1,
+		PieceID:              1,
+		StartScn:             10,
+		CheckpointScn:        90,
+		CheckpointScnDisplay: "round-1",
+		Path:                 archivePath,
+	}, {
+		RoundID:              2,
+		PieceID:              2,
+		StartScn:             100,
+		CheckpointScn:        300,
+		CheckpointScnDisplay: "piece-2",
+		Path:                 archivePath,
+	}, {
+		RoundID:              2,
+		PieceID:              3,
+		StartScn:             300,
+		CheckpointScn:        600,
+		CheckpointScnDisplay: "piece-3",
+		Path:                 archivePath,
+	}}
+
+	It("ComputeRestorableWindow", func() {
+		window := ComputeRestorableWindow(sets, pieces, dataPath, archivePath)
+		Expect(window).ShouldNot(BeNil())
+		Expect(window.LowerBoundScn).Should(BeEquivalentTo(200))
+		Expect(window.LowerBoundDisplay).Should(Equal("set-1"))
+		Expect(window.UpperBoundScn).Should(BeEquivalentTo(600))
+		Expect(window.UpperBoundDisplay).Should(Equal("piece-3"))
+	})
+
+	It("ComputeRestorableWindow with object storage parameters", func() {
+		window := ComputeRestorableWindow(sets, pieces, dataPath+"?host=a&access_id=b", archivePath+"?host=a")
+		Expect(window).ShouldNot(BeNil())
+		Expect(window.LowerBoundScn).Should(BeEquivalentTo(200))
+	})
+
+	It("ComputeRestorableWindow without usable data", func() {
+		Expect(ComputeRestorableWindow(sets, pieces, "file:///ob/backup/t2/data", archivePath)).Should(BeNil())
+		Expect(ComputeRestorableWindow(sets[1:2], pieces, dataPath, archivePath)).Should(BeNil())
+		Expect(ComputeRestorableWindow(sets, pieces[:1], dataPath, archivePath)).Should(BeNil())
+	})
+	It("IsSameBackupDest", func() {
+		Expect(IsSameBackupDest(dataPath, dataPath+"/")).Should(BeTrue())
+		Expect(IsSameBackupDest("oss://bucket/t1?host=a&access_id=b", "oss://bucket/t1/?host=c")).Should(BeTrue())
+		Expect(IsSameBackupDest(dataPath, archivePath)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/server"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+// paxosReplicaTypes are replica types that are members of paxos group of log stream
+var paxosReplicaTypes = map[string]struct{}{
+	"FULL":               {},
+	"LOGONLY":            {},
+	"ENCRYPTION_LOGONLY": {},
+}
+
+// CheckMajorityReplicasHealthy checks whether every log stream keeps the majority of its paxos replicas healthy without the excluded server,
+// a replica is healthy only if the server it's located on is active and not stopped.
+func CheckMajorityReplicasHealthy(replicas []model.LSReplica, servers []model.OBServer, excluded *model.ServerInfo) bool {
+	healthyServers := make(map[string]struct{}, len(servers))
+	for _, s := range servers {
+		if s.Status == server.Active && s.StopTime == 0 {
+			healthyServers[fmt.Sprintf("%s:%d", s.Ip, s.Port)] = struct{}{}
+		}
+	}
+	type lsKey struct {
+		tenantID int64
+		lsID     int64
+	}
+	paxosCount := make(map[lsKey]int)
+	healthyCount := make(map[lsKey]int)
+	for _, r := range replicas {
+		if _, ok := paxosReplicaTypes[r.ReplicaType]; !ok {
+			continue
+		}
+		key := lsKey{tenantID: r.TenantID, lsID: r.LSID}
+		paxosCount[key]++
+		if r.Ip == excluded.Ip && r.Port == excluded.Port {
+			continue
+		}
+		if _, ok := healthyServers[fmt.Sprintf("%s:%d", r.Ip, r.Port)]; ok {
+			healthyCount[key]++
+		}
+	}
+	for key, count := range paxosCount {
+		if healthyCount[key] <= count/2 {
+			return false
+		}
+	}
+	return true
+}
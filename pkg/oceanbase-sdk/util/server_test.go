@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/const/status/server"
+	"github.com/oceanbase/ob-operator/pkg/oceanbase-sdk/model"
+)
+
+var _ = Describe("Test Server Utilities", func() {
+	It("Check majority replicas healthy", func() {
+		failed := &model.ServerInfo{Ip: "10.0.0.1", Port: 2882}
+		servers := []model.OBServer{
+			{Zone: "zone1", Ip: "10.0.0.1", Port: 2882, Status: server.Inactive},
+			{Zone: "zone1", Ip: "10.0.0.4", Port: 2882, Status: server.Active},
+			{Zone: "zone2", Ip: "10.0.0.2", Port: 2882, Status: server.Active},
+			{Zone: "zone3", Ip: "10.0.0.3", Port: 2882, Status: server.Active},
+		}
+		replicas := []model.LSReplica{
+			{TenantID: 1001, LSID: 1001, Ip: "10.0.0.1", Port: 2882, ReplicaType: "FULL"},
+			{TenantID: 1001, LSID: 1001, Ip: "10.0.0.2", Port: 2882, ReplicaType: "FULL"},
+			{TenantID: 1001, LSID: 1001, Ip: "10.0.0.3", Port: 2882, ReplicaType: "FULL"},
+		}
+		Expect(CheckMajorityReplicasHealthy(replicas, servers, failed)).To(BeTrue())
+
+		By("Another replica of the log stream is on a stopped server")
+		servers[3].StopTime = 1
+		Expect(CheckMajorityReplicasHealthy(replicas, servers, failed)).To(BeFalse())
+
+		By("Read only replicas are not counted")
+		servers[3].StopTime = 0
+		replicas = append(replicas, model.LSReplica{TenantID: 1001, LSID: 1001, Ip: "10.0.0.4", Port: 2882, ReplicaType: "READONLY"})
+		Expect(CheckMajorityReplicasHealthy(replicas, servers, failed)).To(BeTrue())
+
+		By("Log stream with two paxos replicas loses majority")
+		replicas = []model.LSReplica{
+			{TenantID: 1002, LSID: 1, Ip: "10.0.0.1", Port: 2882, ReplicaType: "FULL"},
+			{TenantID: 1002, LSID: 1, Ip: "10.0.0.2", Port: 2882, ReplicaType: "FULL"},
+		}
+		Expect(CheckMajorityReplicasHealthy(replicas, servers, failed)).To(BeFalse())
+	})
+})
@@ -25,6 +25,19 @@ const (
 	ReplicaPattern = "([a-zA-Z]+)\\{([\\d]+)\\}@([\\w]+)"
 )
 
+var identifierPattern = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]{0,127}$")
+
+// IsValidIdentifier tells whether the name of tenant, database or user consists of characters, digits and underscores only,
+// names going to be put into sql statements should be checked
+func IsValidIdentifier(name string) bool {
+	return identifierPattern.MatchString(name)
+}
+
+// QuoteIdentifier quotes the name with backticks to be used as an identifier in sql statements
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
 func ConvertFromReplicaStr(replica string) *model.Replica {
 	p := regexp.MustCompile(ReplicaPattern)
 	replicaParts := p.FindStringSubmatch(replica)
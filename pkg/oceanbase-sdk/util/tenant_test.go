@@ -13,6 +13,8 @@ See the Mulan PSL v2 for more details.
 package util
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -23,4 +25,16 @@ var _ = Describe("Test Tenant Utilities", func() {
 		replicas := ConvertFromLocalityStr(locality)
 		Expect(len(replicas)).Should(Equal(3))
 	})
+
+	It("Check and quote identifiers", func() {
+		Expect(IsValidIdentifier("t1")).Should(BeTrue())
+		Expect(IsValidIdentifier("_tenant_2")).Should(BeTrue())
+		Expect(IsValidIdentifier("")).Should(BeFalse())
+		Expect(IsValidIdentifier("1t")).Should(BeFalse())
+		Expect(IsValidIdentifier("t1; drop tenant t2")).Should(BeFalse())
+		Expect(IsValidIdentifier("t1,t2")).Should(BeFalse())
+		Expect(IsValidIdentifier(strings.Repeat("t", 129))).Should(BeFalse())
+		Expect(QuoteIdentifier("t1")).Should(Equal("`t1`"))
+		Expect(QuoteIdentifier("t`1")).Should(Equal("`t``1`"))
+	})
 })
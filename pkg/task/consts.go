@@ -12,11 +12,17 @@ See the Mulan PSL v2 for more details.
 
 package task
 
+import "time"
+
 const (
-	debugTaskEnv    = "DEBUG_TASK"
-	taskPoolSizeEnv = "TASK_POOL_SIZE"
+	debugTaskEnv             = "DEBUG_TASK"
+	taskPoolSizeEnv          = "TASK_POOL_SIZE"
+	taskObjectConcurrencyEnv = "TASK_OBJECT_CONCURRENCY"
 )
 
 const (
-	defaultTaskPoolSize = 10000
+	defaultTaskPoolSize          = 10000
+	defaultTaskObjectConcurrency = 1
+	// Waiting tasks are promoted by one priority level every interval to avoid starvation
+	priorityAgingInterval = time.Minute
 )
@@ -20,6 +20,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -33,45 +34,18 @@ import (
 var taskManager *TaskManager
 var taskManagerOnce sync.Once
 
-func taskManagerInit() {
-	taskPoolSize := defaultTaskPoolSize
-	if psEnv := os.Getenv(taskPoolSizeEnv); psEnv != "" {
-		if ps, err := strconv.Atoi(psEnv); err == nil {
-			taskPoolSize = ps
+func getEnvInt(key string, defaultValue int) int {
+	if env := os.Getenv(key); env != "" {
+		if v, err := strconv.Atoi(env); err == nil && v > 0 {
+			return v
 		}
 	}
-	logger := log.FromContext(context.TODO())
-	taskManager = &TaskManager{
-		Logger: &logger,
-		tokens: make(chan struct{}, taskPoolSize),
-	}
+	return defaultValue
 }
 
-func runTask(f tasktypes.TaskFunc, ch chan<- *tasktypes.TaskResult, tokens chan struct{}) {
-	defer func() {
-		if r := recover(); r != nil {
-			ch <- &tasktypes.TaskResult{
-				Status: taskstatus.Failed,
-				Error:  errors.Errorf("Observed a panic: %v, stacktrace: %s", r, string(debug.Stack())),
-			}
-		}
-		<-tokens
-		close(ch)
-	}()
-
-	tokens <- struct{}{}
-	err := f()
-	if err != nil {
-		ch <- &tasktypes.TaskResult{
-			Status: taskstatus.Failed,
-			Error:  err,
-		}
-	} else {
-		ch <- &tasktypes.TaskResult{
-			Status: taskstatus.Successful,
-			Error:  nil,
-		}
-	}
+func taskManagerInit() {
+	logger := log.FromContext(context.TODO())
+	taskManager = NewTaskManager(getEnvInt(taskPoolSizeEnv, defaultTaskPoolSize), getEnvInt(taskObjectConcurrencyEnv, defaultTaskObjectConcurrency), &logger)
 }
 
 func GetTaskManager() *TaskManager {
@@ -79,61 +53,212 @@ func GetTaskManager() *TaskManager {
 	return taskManager
 }
 
+// NewTaskManager creates a task manager running at most poolSize tasks at the same time,
+// and at most objectConcurrency tasks of the same owner.
+func NewTaskManager(poolSize, objectConcurrency int, logger *logr.Logger) *TaskManager {
+	m := &TaskManager{
+		Logger:            logger,
+		poolSize:          poolSize,
+		objectConcurrency: objectConcurrency,
+		objectRunning:     make(map[string]int),
+		entries:           make(map[tasktypes.TaskID]*taskEntry),
+		queues:            newTaskQueues(),
+		notify:            make(chan struct{}, 1),
+	}
+	go m.dispatch()
+	return m
+}
+
 type TaskManager struct {
 	ResultMap       sync.Map
 	Logger          *logr.Logger
 	TaskResultCache sync.Map
 
-	mu          sync.Mutex
-	workerCount uint32
-	tokens      chan struct{}
+	mu                sync.Mutex
+	workerCount       uint32
+	poolSize          int
+	objectConcurrency int
+	running           int
+	objectRunning     map[string]int
+	entries           map[tasktypes.TaskID]*taskEntry
+	queues            *taskQueues
+	notify            chan struct{}
 }
 
+// Submit submits a task without owner, it's scheduled with normal priority
 func (m *TaskManager) Submit(f tasktypes.TaskFunc) tasktypes.TaskID {
+	return m.SubmitTask(f, tasktypes.TaskMeta{})
+}
+
+// SubmitTask queues a task, which is scheduled according to its meta
+func (m *TaskManager) SubmitTask(f tasktypes.TaskFunc, meta tasktypes.TaskMeta) tasktypes.TaskID {
 	retCh := make(chan *tasktypes.TaskResult, 1)
 	// Notes: casting type here is important as equality of interface including type equality and value equality
 	taskId := tasktypes.TaskID(uuid.New().String())
 	m.ResultMap.Store(taskId, retCh)
 	m.TaskResultCache.Delete(taskId)
 
-	go runTask(f, retCh, m.tokens)
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &taskEntry{
+		id:       taskId,
+		f:        f,
+		meta:     meta,
+		retCh:    retCh,
+		ctx:      ctx,
+		cancel:   cancel,
+		submitAt: time.Now(),
+	}
+	m.mu.Lock()
+	m.entries[taskId] = entry
+	m.queues.push(entry)
+	m.workerCount++
+	m.mu.Unlock()
+	m.signal()
 
-	if os.Getenv(debugTaskEnv) == "true" {
-		mu.Lock()
-		m.workerCount++
-		mu.Unlock()
+	m.printMemoryUsage("[Submit] Memory usage")
+	return taskId
+}
 
-		var ms runtime.MemStats
-		runtime.ReadMemStats(&ms)
+// Cancel cancels a queued or running task, the task results in failure.
+// Failure of running task is reported immediately, but its worker is kept until the task function returns.
+func (m *TaskManager) Cancel(taskId tasktypes.TaskID) {
+	m.mu.Lock()
+	entry, exists := m.entries[taskId]
+	if !exists {
+		m.mu.Unlock()
+		return
+	}
+	if !entry.running && m.queues.remove(entry) {
+		delete(m.entries, taskId)
+		m.mu.Unlock()
+		entry.cancel()
+		entry.retCh <- &tasktypes.TaskResult{
+			Status:       taskstatus.Failed,
+			Error:        errors.Wrap(tasktypes.ErrTaskCanceled, "Canceled before running"),
+			WaitDuration: time.Since(entry.submitAt),
+		}
+		close(entry.retCh)
+		return
+	}
+	m.mu.Unlock()
+	entry.cancel()
+}
 
-		m.Logger.Info("[Submit] Memory usage",
-			"Alloc", fmt.Sprintf("%v MiB", ms.Alloc>>20),
-			"TotalAlloc", fmt.Sprintf("%v MiB", ms.TotalAlloc>>20),
-			"Sys", fmt.Sprintf("%v MiB", ms.Sys>>20),
-			"NumGC", ms.NumGC,
-			"Running task workers", len(m.tokens),
-			"Total task workers", m.workerCount,
-			"Pool size", cap(m.tokens),
-		)
+// Abandon cancels all abandonable tasks of the owner, it's used when the owner is being deleted
+func (m *TaskManager) Abandon(kind, owner string) {
+	m.mu.Lock()
+	ids := make([]tasktypes.TaskID, 0)
+	for id, entry := range m.entries {
+		if entry.meta.Abandonable && entry.meta.Kind == kind && entry.meta.Owner == owner {
+			ids = append(ids, id)
+		}
+	}
+	m.mu.Unlock()
+	for _, id := range ids {
+		m.Logger.Info("Abandon task of deleting resource", "kind", kind, "owner", owner, "taskId", id)
+		m.Cancel(id)
 	}
-	return taskId
 }
 
-func (m *TaskManager) GetTaskResult(taskId tasktypes.TaskID) (*tasktypes.TaskResult, error) {
-	if os.Getenv(debugTaskEnv) == "true" {
-		var ms runtime.MemStats
-		runtime.ReadMemStats(&ms)
-
-		m.Logger.Info("[GetResult] Memory usage",
-			"Alloc", fmt.Sprintf("%v MiB", ms.Alloc>>20),
-			"TotalAlloc", fmt.Sprintf("%v MiB", ms.TotalAlloc>>20),
-			"Sys", fmt.Sprintf("%v MiB", ms.Sys>>20),
-			"NumGC", ms.NumGC,
-			"Running task workers", len(m.tokens),
-			"Total task workers", m.workerCount,
-			"Pool size", cap(m.tokens),
-		)
+func (m *TaskManager) signal() {
+	select {
+	case m.notify <- struct{}{}:
+	default:
 	}
+}
+
+func (m *TaskManager) dispatch() {
+	for range m.notify {
+		m.schedule()
+	}
+}
+
+// schedule starts queued tasks until worker pool is full or no task could be started
+func (m *TaskManager) schedule() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for m.running < m.poolSize {
+		entry := m.queues.pop(now, func(e *taskEntry) bool {
+			key := e.objectKey()
+			return key == "" || m.objectRunning[key] < m.objectConcurrency
+		})
+		if entry == nil {
+			return
+		}
+		entry.running = true
+		m.running++
+		if key := entry.objectKey(); key != "" {
+			m.objectRunning[key]++
+		}
+		go m.runTask(entry)
+	}
+}
+
+func (m *TaskManager) release(entry *taskEntry) {
+	m.mu.Lock()
+	m.running--
+	if key := entry.objectKey(); key != "" {
+		m.objectRunning[key]--
+		if m.objectRunning[key] <= 0 {
+			delete(m.objectRunning, key)
+		}
+	}
+	delete(m.entries, entry.id)
+	m.mu.Unlock()
+	entry.cancel()
+	m.signal()
+}
+
+func (m *TaskManager) runTask(entry *taskEntry) {
+	// Time waiting in queue is not counted in duration of the task
+	startTime := time.Now()
+	ctx := entry.ctx
+	if entry.meta.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(entry.ctx, entry.meta.Timeout)
+		defer cancel()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		// worker and slot of the owner are kept until the task function returns,
+		// so that a retry never runs together with a former run ignoring ctx
+		defer m.release(entry)
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- errors.Errorf("Observed a panic: %v, stacktrace: %s", r, string(debug.Stack()))
+			}
+		}()
+		errCh <- entry.f(ctx)
+	}()
+
+	result := &tasktypes.TaskResult{
+		Status:       taskstatus.Successful,
+		WaitDuration: startTime.Sub(entry.submitAt),
+	}
+	select {
+	case err := <-errCh:
+		if err != nil {
+			result.Status = taskstatus.Failed
+			result.Error = err
+		}
+	case <-ctx.Done():
+		// failure is reported no matter whether the task exits on ctx done
+		result.Status = taskstatus.Failed
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result.Error = errors.Wrapf(tasktypes.ErrTaskTimeout, "Exceeded timeout %s", entry.meta.Timeout)
+		} else {
+			result.Error = tasktypes.ErrTaskCanceled
+		}
+	}
+	result.Duration = time.Since(startTime)
+	entry.retCh <- result
+	close(entry.retCh)
+}
+
+func (m *TaskManager) GetTaskResult(taskId tasktypes.TaskID) (*tasktypes.TaskResult, error) {
+	m.printMemoryUsage("[GetResult] Memory usage")
 	result, exists := m.TaskResultCache.Load(taskId)
 	if !exists {
 		retChAny, exists := m.ResultMap.Load(taskId)
@@ -166,23 +291,31 @@ func (m *TaskManager) CleanTaskResult(taskId tasktypes.TaskID) error {
 	m.ResultMap.Delete(taskId)
 	m.TaskResultCache.Delete(taskId)
 
-	if os.Getenv("DEBUG_TASK") == "true" {
-		mu.Lock()
-		m.workerCount--
-		mu.Unlock()
-		var ms runtime.MemStats
-		runtime.ReadMemStats(&ms)
+	m.mu.Lock()
+	m.workerCount--
+	m.mu.Unlock()
+	m.printMemoryUsage("[Clean] Memory usage")
+	return nil
+}
 
-		m.Logger.Info("[Clean] Memory usage",
-			"Alloc", fmt.Sprintf("%v MiB", ms.Alloc>>20),
-			"TotalAlloc", fmt.Sprintf("%v MiB", ms.TotalAlloc>>20),
-			"Sys", fmt.Sprintf("%v MiB", ms.Sys>>20),
-			"NumGC", ms.NumGC,
-			"Running task workers", len(m.tokens),
-			"Total task workers", m.workerCount,
-			"Pool size", cap(m.tokens),
-		)
+func (m *TaskManager) printMemoryUsage(msg string) {
+	if os.Getenv(debugTaskEnv) != "true" {
+		return
 	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
 
-	return nil
+	m.mu.Lock()
+	running, queued, total := m.running, m.queues.len(), m.workerCount
+	m.mu.Unlock()
+	m.Logger.Info(msg,
+		"Alloc", fmt.Sprintf("%v MiB", ms.Alloc>>20),
+		"TotalAlloc", fmt.Sprintf("%v MiB", ms.TotalAlloc>>20),
+		"Sys", fmt.Sprintf("%v MiB", ms.Sys>>20),
+		"NumGC", ms.NumGC,
+		"Running task workers", running,
+		"Queued tasks", queued,
+		"Total task workers", total,
+		"Pool size", m.poolSize,
+	)
 }
@@ -13,6 +13,7 @@ See the Mulan PSL v2 for more details.
 package task
 
 import (
+	"context"
 	"errors"
 	"math/rand"
 	"os"
@@ -21,9 +22,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
 	taskstatus "github.com/oceanbase/ob-operator/pkg/task/const/status"
 	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
 )
@@ -33,20 +36,20 @@ const taskMemoryUsageEnv = "TASK_MEMORY_USAGE"
 const taskNumEnv = "TASK_NUM"
 const taskSleepEnv = "TASK_SLEEP"
 
-func successfulTask() tasktypes.TaskError {
+func successfulTask(_ context.Context) tasktypes.TaskError {
 	time.Sleep(time.Second)
 	return nil
 }
 
-func failedTask() tasktypes.TaskError {
+func failedTask(_ context.Context) tasktypes.TaskError {
 	return errors.New("failed task")
 }
 
-func panickyTask() tasktypes.TaskError {
+func panickyTask(_ context.Context) tasktypes.TaskError {
 	panic("panicky task")
 }
 
-func longrunTask() tasktypes.TaskError {
+func longrunTask(_ context.Context) tasktypes.TaskError {
 	time.Sleep(3 * time.Second)
 	return nil
 }
@@ -65,6 +68,9 @@ var _ = Describe("Test TaskManager", Serial, func() {
 			return err == nil && result != nil && result.Status == taskstatus.Successful
 		}, 3, 1).Should(BeTrue())
 
+		result, err := GetTaskManager().GetTaskResult(taskId)
+		Expect(err).Should(BeNil())
+		Expect(result.Duration).Should(BeNumerically(">=", time.Second))
 		Expect(GetTaskManager().CleanTaskResult(taskId)).Should(Succeed())
 	})
 
@@ -105,6 +111,128 @@ var _ = Describe("Test TaskManager", Serial, func() {
 		Expect(GetTaskManager().CleanTaskResult(taskId)).Should(Succeed())
 	})
 
+	It("Run tasks by priority", func() {
+		logger := logr.Discard()
+		tm := NewTaskManager(1, 1, &logger)
+		blocker := make(chan struct{})
+		blockId := tm.SubmitTask(func(_ context.Context) tasktypes.TaskError {
+			<-blocker
+			return nil
+		}, tasktypes.TaskMeta{Kind: "OBTenantRestore", Owner: "default/restore"})
+
+		orderMu := sync.Mutex{}
+		order := make([]string, 0)
+		record := func(name string) tasktypes.TaskFunc {
+			return func(_ context.Context) tasktypes.TaskError {
+				orderMu.Lock()
+				defer orderMu.Unlock()
+				order = append(order, name)
+				return nil
+			}
+		}
+		ids := []tasktypes.TaskID{
+			tm.SubmitTask(record("backup"), tasktypes.TaskMeta{Kind: "OBTenantBackup", Owner: "default/backup", Priority: priority.Low}),
+			tm.SubmitTask(record("tenant"), tasktypes.TaskMeta{Kind: "OBTenant", Owner: "default/tenant"}),
+			tm.SubmitTask(record("observer"), tasktypes.TaskMeta{Kind: "OBServer", Owner: "default/observer", Priority: priority.High}),
+		}
+		close(blocker)
+		for _, id := range append(ids, blockId) {
+			Eventually(func() bool {
+				result, err := tm.GetTaskResult(id)
+				return err == nil && result != nil && result.Status == taskstatus.Successful
+			}, 3, 0.1).Should(BeTrue())
+		}
+		Expect(order).Should(Equal([]string{"observer", "tenant", "backup"}))
+
+		result, err := tm.GetTaskResult(ids[0])
+		Expect(err).Should(BeNil())
+		Expect(result.WaitDuration).Should(BeNumerically(">", 0))
+	})
+
+	It("Limit concurrency of the same owner", func() {
+		logger := logr.Discard()
+		tm := NewTaskManager(10, 1, &logger)
+		meta := tasktypes.TaskMeta{Kind: "OBServer", Owner: "default/observer"}
+		first := tm.SubmitTask(longrunTask, meta)
+		second := tm.SubmitTask(successfulTask, meta)
+		Consistently(func() *tasktypes.TaskResult {
+			result, _ := tm.GetTaskResult(second)
+			return result
+		}, 2, 0.5).Should(BeNil())
+		Eventually(func() bool {
+			result, err := tm.GetTaskResult(second)
+			return err == nil && result != nil && result.Status == taskstatus.Successful
+		}, 5, 0.5).Should(BeTrue())
+		result, err := tm.GetTaskResult(first)
+		Expect(err).Should(BeNil())
+		Expect(result.Status).Should(BeEquivalentTo(taskstatus.Successful))
+	})
+
+	It("Cancel and time out tasks", func() {
+		logger := logr.Discard()
+		tm := NewTaskManager(10, 1, &logger)
+		By("Task times out")
+		timeoutId := tm.SubmitTask(longrunTask, tasktypes.TaskMeta{Timeout: 100 * time.Millisecond})
+		Eventually(func() bool {
+			result, err := tm.GetTaskResult(timeoutId)
+			return err == nil && result != nil && result.Status == taskstatus.Failed && errors.Is(result.Error, tasktypes.ErrTaskTimeout)
+		}, 2, 0.1).Should(BeTrue())
+
+		By("Context of task is done once it times out")
+		exited := make(chan struct{})
+		tm.SubmitTask(func(ctx context.Context) tasktypes.TaskError {
+			defer close(exited)
+			<-ctx.Done()
+			return ctx.Err()
+		}, tasktypes.TaskMeta{Timeout: 100 * time.Millisecond})
+		Eventually(exited, 2, 0.1).Should(BeClosed())
+
+		By("Abandon running and queued tasks of deleting owner")
+		meta := tasktypes.TaskMeta{Kind: "OBTenant", Owner: "default/tenant", Abandonable: true}
+		runningId := tm.SubmitTask(longrunTask, meta)
+		queuedId := tm.SubmitTask(longrunTask, meta)
+		time.Sleep(100 * time.Millisecond)
+		tm.Abandon("OBTenant", "default/tenant")
+		for _, id := range []tasktypes.TaskID{runningId, queuedId} {
+			Eventually(func() bool {
+				result, err := tm.GetTaskResult(id)
+				return err == nil && result != nil && result.Status == taskstatus.Failed && errors.Is(result.Error, tasktypes.ErrTaskCanceled)
+			}, 1, 0.1).Should(BeTrue())
+		}
+	})
+
+	It("Keep slot of the owner until task ignoring ctx returns", func() {
+		logger := logr.Discard()
+		tm := NewTaskManager(10, 1, &logger)
+		meta := tasktypes.TaskMeta{Kind: "OBServer", Owner: "default/observer", Timeout: 100 * time.Millisecond}
+		returned := make(chan struct{})
+		first := tm.SubmitTask(func(_ context.Context) tasktypes.TaskError {
+			defer close(returned)
+			time.Sleep(time.Second)
+			return nil
+		}, meta)
+		Eventually(func() bool {
+			result, err := tm.GetTaskResult(first)
+			return err == nil && result != nil && result.Status == taskstatus.Failed && errors.Is(result.Error, tasktypes.ErrTaskTimeout)
+		}, 1, 0.05).Should(BeTrue())
+
+		retryStarted := make(chan bool, 1)
+		retry := tm.SubmitTask(func(_ context.Context) tasktypes.TaskError {
+			select {
+			case <-returned:
+				retryStarted <- true
+			default:
+				retryStarted <- false
+			}
+			return nil
+		}, meta)
+		Eventually(func() bool {
+			result, err := tm.GetTaskResult(retry)
+			return err == nil && result != nil && result.Status == taskstatus.Successful
+		}, 3, 0.1).Should(BeTrue())
+		Expect(<-retryStarted).Should(BeTrue())
+	})
+
 	It("Submit 1e6 tasks", Label("long-run", "sum"), func() {
 		// 1e6 unit tasks take about 2.1G memory
 		for i := 0; i < 1e6; i++ {
@@ -159,16 +287,13 @@ var _ = Describe("Test TaskManager", Serial, func() {
 			taskSleep = 10
 		}
 		defer GinkgoRecover()
-		tm := &TaskManager{
-			ResultMap:       sync.Map{},
-			TaskResultCache: sync.Map{},
-			tokens:          make(chan struct{}, poolSize),
-		}
+		logger := logr.Discard()
+		tm := NewTaskManager(poolSize, defaultTaskObjectConcurrency, &logger)
 		wg := sync.WaitGroup{}
 		for i := 0; i < taskNum; i++ {
 			wg.Add(1)
 			sleepTime := rand.Intn(taskSleep) + 1
-			taskId := tm.Submit(func() tasktypes.TaskError {
+			taskId := tm.Submit(func(_ context.Context) tasktypes.TaskError {
 				bts := make([]byte, 1<<taskMemory, 1<<taskMemory)
 				bts[0] = 0
 				time.Sleep(time.Duration(sleepTime) * time.Second)
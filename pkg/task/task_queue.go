@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/oceanbase/ob-operator/pkg/task/const/priority"
+	tasktypes "github.com/oceanbase/ob-operator/pkg/task/types"
+)
+
+// taskEntry is a task submitted to task manager, either queued or running
+type taskEntry struct {
+	id       tasktypes.TaskID
+	f        tasktypes.TaskFunc
+	meta     tasktypes.TaskMeta
+	retCh    chan *tasktypes.TaskResult
+	ctx      context.Context
+	cancel   context.CancelFunc
+	submitAt time.Time
+	running  bool
+}
+
+func (e *taskEntry) objectKey() string {
+	if e.meta.Owner == "" {
+		return ""
+	}
+	return e.meta.Kind + "/" + e.meta.Owner
+}
+
+// effectivePriority promotes priority of the task by one level every aging interval it waits
+func (e *taskEntry) effectivePriority(now time.Time) tasktypes.TaskPriority {
+	return normalizePriority(normalizePriority(e.meta.Priority) + tasktypes.TaskPriority(now.Sub(e.submitAt)/priorityAgingInterval))
+}
+
+// taskQueues holds waiting tasks in per-kind queues, each of which keeps a FIFO list for every priority
+type taskQueues struct {
+	kinds    []string
+	queues   map[string]map[tasktypes.TaskPriority][]*taskEntry
+	count    int
+	nextKind int
+}
+
+func newTaskQueues() *taskQueues {
+	return &taskQueues{
+		queues: make(map[string]map[tasktypes.TaskPriority][]*taskEntry),
+	}
+}
+
+func normalizePriority(p tasktypes.TaskPriority) tasktypes.TaskPriority {
+	if p > priority.High {
+		return priority.High
+	}
+	if p < priority.Low {
+		return priority.Low
+	}
+	return p
+}
+
+func (q *taskQueues) push(e *taskEntry) {
+	kindQueues, exists := q.queues[e.meta.Kind]
+	if !exists {
+		kindQueues = make(map[tasktypes.TaskPriority][]*taskEntry)
+		q.queues[e.meta.Kind] = kindQueues
+		q.kinds = append(q.kinds, e.meta.Kind)
+	}
+	p := normalizePriority(e.meta.Priority)
+	kindQueues[p] = append(kindQueues[p], e)
+	q.count++
+}
+
+func (q *taskQueues) remove(e *taskEntry) bool {
+	kindQueues := q.queues[e.meta.Kind]
+	p := normalizePriority(e.meta.Priority)
+	for i, entry := range kindQueues[p] {
+		if entry == e {
+			kindQueues[p] = append(kindQueues[p][:i], kindQueues[p][i+1:]...)
+			q.count--
+			return true
+		}
+	}
+	return false
+}
+
+func (q *taskQueues) len() int {
+	return q.count
+}
+
+// pop takes the next task to run. Tasks of higher effective priority go first, kinds take turns within the same priority
+// and tasks of the same kind and priority keep FIFO order. Tasks whose owner can not accept more running tasks are skipped.
+func (q *taskQueues) pop(now time.Time, accept func(e *taskEntry) bool) *taskEntry {
+	if q.count == 0 {
+		return nil
+	}
+	for p := priority.High; p >= priority.Low; p-- {
+		for i := 0; i < len(q.kinds); i++ {
+			kindIdx := (q.nextKind + i) % len(q.kinds)
+			kindQueues := q.queues[q.kinds[kindIdx]]
+			// only tasks submitted with priority no higher than p could reach p, either directly or by aging
+			for base := p; base >= priority.Low; base-- {
+				queue := kindQueues[base]
+				for j, e := range queue {
+					// entries are ordered by submission, the following ones have waited less and are not promoted either
+					if e.effectivePriority(now) < p {
+						break
+					}
+					if !accept(e) {
+						continue
+					}
+					if j == 0 {
+						kindQueues[base] = queue[1:]
+					} else {
+						kindQueues[base] = append(queue[:j], queue[j+1:]...)
+					}
+					q.count--
+					q.nextKind = (kindIdx + 1) % len(q.kinds)
+					return e
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -12,4 +12,13 @@ See the Mulan PSL v2 for more details.
 
 package types
 
+import "github.com/pkg/errors"
+
 type TaskError error
+
+var (
+	// ErrTaskTimeout is reported when a task fails to finish before its deadline
+	ErrTaskTimeout = errors.New("Task timed out")
+	// ErrTaskCanceled is reported when a task is canceled before it finishes
+	ErrTaskCanceled = errors.New("Task was canceled")
+)
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Max number of finished flows kept in status of a resource
+	FlowHistoryLimit = 10
+	// Max number of task records kept for a flow, the earliest ones are dropped first
+	FlowTaskRecordLimit = 50
+)
+
+// TaskRecord records a finished run of a task in a flow
+type TaskRecord struct {
+	Name     TaskName        `json:"name"`
+	Status   TaskStatus      `json:"status"`
+	Duration metav1.Duration `json:"duration"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// FlowRecord records a finished task flow, including tasks ran in it
+type FlowRecord struct {
+	Name      FlowName     `json:"name"`
+	Status    TaskStatus   `json:"status"`
+	StartTime metav1.Time  `json:"startTime"`
+	EndTime   metav1.Time  `json:"endTime"`
+	LastError string       `json:"lastError,omitempty"`
+	Tasks     []TaskRecord `json:"tasks,omitempty"`
+}
+
+// AppendTaskRecord appends record of a finished task to the operation context
+func (c *OperationContext) AppendTaskRecord(record TaskRecord) {
+	c.TaskRecords = append(c.TaskRecords, record)
+	if len(c.TaskRecords) > FlowTaskRecordLimit {
+		c.TaskRecords = c.TaskRecords[len(c.TaskRecords)-FlowTaskRecordLimit:]
+	}
+}
+
+// AppendFlowRecord appends the record to history and keeps the latest FlowHistoryLimit records.
+// A flow paused on failure is reported as finished repeatedly, record of the same run replaces the former one only if it changes.
+func AppendFlowRecord(history []FlowRecord, record *FlowRecord) []FlowRecord {
+	if n := len(history); n > 0 && history[n-1].Name == record.Name && history[n-1].StartTime.Equal(&record.StartTime) {
+		if history[n-1].Status != record.Status || len(history[n-1].Tasks) != len(record.Tasks) {
+			history[n-1] = *record
+		}
+		return history
+	}
+	history = append(history, *record)
+	if len(history) > FlowHistoryLimit {
+		history = history[len(history)-FlowHistoryLimit:]
+	}
+	return history
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowRecord) DeepCopyInto(out *FlowRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]TaskRecord, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowRecord.
+func (in *FlowRecord) DeepCopy() *FlowRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowRecord)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -12,6 +12,10 @@ See the Mulan PSL v2 for more details.
 
 package types
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type OperationContext struct {
 	Name         FlowName    `json:"name"`
 	Tasks        []TaskName  `json:"tasks"`
@@ -21,6 +25,10 @@ type OperationContext struct {
 	TaskId       TaskID      `json:"taskId"`
 	TargetStatus string      `json:"targetStatus"`
 	OnFailure    FailureRule `json:"failureRule,omitempty"`
+	// Time when the flow started, it's set by coordinator once the flow is taken
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Records of tasks finished in the flow, including failed and retried ones
+	TaskRecords []TaskRecord `json:"taskRecords,omitempty"`
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -32,6 +40,15 @@ func (in *OperationContext) DeepCopyInto(out *OperationContext) {
 		copy(*out, *in)
 	}
 	out.OnFailure = in.OnFailure
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TaskRecords != nil {
+		in, out := &in.TaskRecords, &out.TaskRecords
+		*out = make([]TaskRecord, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationContext.
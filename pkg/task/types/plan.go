@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2023 OceanBase
+ob-operator is licensed under Mulan PSL v2.
+You can use this software according to the terms and conditions of the Mulan PSL v2.
+You may obtain a copy of Mulan PSL v2 at:
+         http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+*/
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PlannedFlow is a task flow that would run to apply a proposed spec
+type PlannedFlow struct {
+	Name  FlowName   `json:"name"`
+	Tasks []TaskName `json:"tasks,omitempty"`
+}
+
+// ChangePlan records task flows that would run if a proposed spec is applied, nothing in it is executed
+type ChangePlan struct {
+	ProposalHash       string        `json:"proposalHash"`
+	ObservedGeneration int64         `json:"observedGeneration"`
+	PlannedAt          metav1.Time   `json:"plannedAt"`
+	Flows              []PlannedFlow `json:"flows,omitempty"`
+	Error              string        `json:"error,omitempty"`
+}
+
+func NewPlannedFlow(flow *TaskFlow) PlannedFlow {
+	tasks := make([]TaskName, len(flow.OperationContext.Tasks))
+	copy(tasks, flow.OperationContext.Tasks)
+	return PlannedFlow{
+		Name:  flow.OperationContext.Name,
+		Tasks: tasks,
+	}
+}
+
+func HashProposal(proposal string) string {
+	sum := sha256.Sum256([]byte(proposal))
+	return hex.EncodeToString(sum[:])
+}
+
+// SameAs tells whether two plans are of the same proposal and have the same result, planned time is ignored
+func (p *ChangePlan) SameAs(other *ChangePlan) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return p.ProposalHash == other.ProposalHash &&
+		p.ObservedGeneration == other.ObservedGeneration &&
+		p.Error == other.Error &&
+		reflect.DeepEqual(p.Flows, other.Flows)
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedFlow) DeepCopyInto(out *PlannedFlow) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]TaskName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedFlow.
+func (in *PlannedFlow) DeepCopy() *PlannedFlow {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedFlow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChangePlan) DeepCopyInto(out *ChangePlan) {
+	*out = *in
+	in.PlannedAt.DeepCopyInto(&out.PlannedAt)
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = make([]PlannedFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangePlan.
+func (in *ChangePlan) DeepCopy() *ChangePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangePlan)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -12,7 +12,12 @@ See the Mulan PSL v2 for more details.
 
 package types
 
+import "time"
+
 type TaskResult struct {
-	Status TaskStatus
-	Error  error
+	Status   TaskStatus
+	Error    error
+	Duration time.Duration
+	// Time the task spent in queue before running
+	WaitDuration time.Duration
 }
@@ -12,7 +12,13 @@ See the Mulan PSL v2 for more details.
 
 package types
 
-type TaskFunc func() TaskError
+import (
+	"context"
+	"time"
+)
+
+// TaskFunc is the function executed by a task, ctx is done once the task times out or is canceled
+type TaskFunc func(ctx context.Context) TaskError
 
 type TaskName string
 
@@ -21,5 +27,19 @@ func (n TaskName) Display() string {
 }
 
 type TaskID string
+type TaskPriority int
+
+// TaskMeta describes the owner of a task and how the task is scheduled
+type TaskMeta struct {
+	// Kind of the resource owning the task, tasks of different kinds are queued separately
+	Kind string
+	// Namespaced name of the resource owning the task, tasks of the same owner are limited in concurrency
+	Owner    string
+	Priority TaskPriority
+	// Max duration of running the task, zero means no limit
+	Timeout time.Duration
+	// Abandonable tasks are canceled once their owner is being deleted
+	Abandonable bool
+}
 type TaskStatus string
 type TaskFailureStrategy string